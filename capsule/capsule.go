@@ -24,6 +24,7 @@ func New() *Manager {
 		Pool:        &Pool{},
 		Connections: &sync.Map{},
 		Option:      &dbal.Option{},
+		Scopes:      map[string]func(qb query.Query){},
 	}
 }
 
@@ -100,6 +101,7 @@ func (manager *Manager) AddConnection(name string, driver string, datasource str
 		Driver:   driver,
 		DSN:      datasource,
 		ReadOnly: readonly,
+		Timeout:  manager.Timeout,
 	}
 
 	db := sqlx.MustOpen(config.Driver, config.DSN)
@@ -121,8 +123,9 @@ func (manager *Manager) AddConnection(name string, driver string, datasource str
 
 	<-ctx.Done()
 	conn := &Connection{
-		DB:     *db,
-		Config: &config,
+		DB:       *db,
+		Config:   &config,
+		Metadata: query.NewMetadata(),
 	}
 
 	manager.Pool.Primary = append(manager.Pool.Primary, conn)
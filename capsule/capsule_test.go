@@ -1,10 +1,14 @@
 package capsule
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/query"
 	"github.com/yaoapp/xun/unit"
 )
 
@@ -71,3 +75,99 @@ func TestPing(t *testing.T) {
 	err = conn.Ping(1 * time.Second)
 	assert.Equal(t, "context deadline exceeded", err.Error())
 }
+
+func TestSetRetryPolicy(t *testing.T) {
+	unit.SetLogger()
+	manager := New()
+	_, err := manager.Add("test", unit.Driver(), unit.DSN(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	retries := 0
+	manager.SetRetryPolicy(dbal.RetryPolicy{
+		BaseDelay: time.Millisecond,
+		OnRetry:   func(attempt int, err error, delay time.Duration) { retries++ },
+	})
+
+	attempts := 0
+	err = manager.Transaction(func(qb query.Query) error {
+		attempts++
+		if attempts < 2 {
+			return dbal.NewError(dbal.ErrDeadlock, fmt.Errorf("deadlock found"))
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, retries)
+}
+
+func TestSetTimeout(t *testing.T) {
+	unit.SetLogger()
+	manager := New()
+	_, err := manager.Add("test", unit.Driver(), unit.DSN(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.SetTimeout(time.Nanosecond)
+	conn, err := manager.Primary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, time.Nanosecond, conn.Config.Timeout)
+
+	_, err = manager.Query().Exec("select 1")
+	assert.True(t, errors.Is(err, dbal.ErrQueryTimeout))
+
+	// the connections registered later have the default timeout
+	_, err = manager.Add("test2", unit.Driver(), unit.DSN(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err = manager.ReadOnly()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, time.Nanosecond, conn.Config.Timeout)
+}
+
+func TestAddScope(t *testing.T) {
+	unit.SetLogger()
+	manager := NewWithOption(dbal.Option{Prefix: "xun_"})
+	_, err := manager.Add("test", unit.Driver(), unit.DSN(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	manager.AddScope("users", func(qb query.Query) {
+		qb.Where("tenant_id", 7)
+	})
+
+	qb := manager.Query().Table("users as u").Where("vote", ">", 10)
+	assert.Contains(t, qb.ToSQL(), "tenant_id")
+	assert.Equal(t, []interface{}{10, 7}, qb.GetBindings())
+
+	qb.WithoutScope("users")
+	assert.NotContains(t, qb.ToSQL(), "tenant_id")
+	assert.Equal(t, []interface{}{10}, qb.GetBindings())
+}
+
+func TestQueryMetadata(t *testing.T) {
+	unit.SetLogger()
+	manager := New()
+	_, err := manager.Add("test", unit.Driver(), unit.DSN(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the metadata is cached by the connection, the query builders share it
+	conn, err := manager.Primary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotNil(t, conn.Metadata)
+	assert.Same(t, conn.Metadata, manager.Query().Builder().Conn.Metadata)
+	assert.Same(t, manager.Query().Builder().Conn.Metadata, manager.Query().Builder().Conn.Metadata)
+}
@@ -3,6 +3,7 @@ package capsule
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun/dbal"
@@ -18,6 +19,7 @@ func (manager *Manager) Add(name string, driver string, datasource string, reado
 		Driver:   driver,
 		DSN:      datasource,
 		ReadOnly: readonly,
+		Timeout:  manager.Timeout,
 	}
 
 	db, err := sqlx.Open(config.Driver, config.DSN)
@@ -26,8 +28,9 @@ func (manager *Manager) Add(name string, driver string, datasource string, reado
 	}
 
 	conn := &Connection{
-		DB:     *db,
-		Config: &config,
+		DB:       *db,
+		Config:   &config,
+		Metadata: query.NewMetadata(),
 	}
 
 	if config.ReadOnly == true {
@@ -49,6 +52,45 @@ func (manager *Manager) SetAsGlobal() {
 	Global = manager
 }
 
+// SetRetryPolicy Set the retry policy of the transactions, the transaction closures failed with
+// a deadlock or a serialization failure are re-run by the policy.
+func (manager *Manager) SetRetryPolicy(policy dbal.RetryPolicy) *Manager {
+	if manager.Option == nil {
+		manager.Option = &dbal.Option{}
+	}
+	manager.Option.Retry = &policy
+	return manager
+}
+
+// SetTimeout Set the default timeout of the statements executed by the connections, including the connections
+// registered later, the statements are canceled when it's exceeded. The query builder Timeout method overrides it.
+func (manager *Manager) SetTimeout(timeout time.Duration) *Manager {
+	manager.Timeout = timeout
+	manager.Connections.Range(func(key, value interface{}) bool {
+		if conn, ok := value.(*Connection); ok {
+			conn.Config.Timeout = timeout
+		}
+		return true
+	})
+	return manager
+}
+
+// AddScope Register a global scope of the table, the scope is applied to the select, update and delete statements
+// whenever the table is referenced by the Table, From and Join methods or a subquery. The columns of the conditions
+// are qualified by the alias of the table, the table name is given without the prefix. The scopes should be
+// registered before the queries are built, the WithoutScope and WithoutScopes methods remove them from a query.
+//
+//	manager.AddScope("orders", func(qb query.Query) {
+//		qb.Where("tenant_id", tenantID)
+//	})
+func (manager *Manager) AddScope(table string, scope func(qb query.Query)) *Manager {
+	if manager.Scopes == nil {
+		manager.Scopes = map[string]func(qb query.Query){}
+	}
+	manager.Scopes[table] = scope
+	return manager
+}
+
 // Primary select a primary connection
 func (manager *Manager) Primary() (*Connection, error) {
 	return manager.Pool.RandPrimary()
@@ -92,9 +134,21 @@ func (manager *Manager) Query() query.Query {
 			Read:        &read.DB,
 			ReadConfig:  read.Config,
 			Option:      manager.Option,
+			Scopes:      manager.Scopes,
+			Metadata:    write.Metadata,
 		})
 }
 
+// Transaction Execute the callback within a transaction using the primary connection.
+func (manager *Manager) Transaction(callback func(qb query.Query) error) error {
+	return manager.Query().Transaction(callback)
+}
+
+// Begin Start a new transaction using the primary connection.
+func (manager *Manager) Begin() (query.Query, error) {
+	return manager.Query().Begin()
+}
+
 // Close the connections
 func (manager *Manager) Close() error {
 
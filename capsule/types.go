@@ -2,9 +2,11 @@ package capsule
 
 import (
 	"sync"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/query"
 )
 
 // Manager The database manager
@@ -12,6 +14,8 @@ type Manager struct {
 	Pool        *Pool
 	Connections *sync.Map // map[string]*Connection
 	Option      *dbal.Option
+	Scopes      map[string]func(qb query.Query) // The global scopes of the tables
+	Timeout     time.Duration                   // The default timeout of the statements of the connections
 }
 
 // Pool the connection pool
@@ -23,5 +27,6 @@ type Pool struct {
 // Connection The database connection
 type Connection struct {
 	sqlx.DB
-	Config *dbal.Config
+	Config   *dbal.Config
+	Metadata *query.Metadata // The metadata of the database server cached by the query builders
 }
@@ -0,0 +1,57 @@
+package dbal
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// contextExecutor the executor which runs every statement with the bound context
+type contextExecutor struct {
+	ctx context.Context
+	Executor
+}
+
+// WithContext bind the context to the executor, all of the statements will be executed using the context.
+func WithContext(ctx context.Context, executor Executor) Executor {
+	if ctx == nil {
+		return executor
+	}
+	return &contextExecutor{ctx: ctx, Executor: executor}
+}
+
+// Exec executes a query using the bound context
+func (executor *contextExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return executor.Executor.ExecContext(executor.ctx, query, args...)
+}
+
+// Query executes a query that returns rows using the bound context
+func (executor *contextExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return executor.Executor.QueryContext(executor.ctx, query, args...)
+}
+
+// Queryx executes a query that returns *sqlx.Rows using the bound context
+func (executor *contextExecutor) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return executor.Executor.QueryxContext(executor.ctx, query, args...)
+}
+
+// QueryRowx executes a query that is expected to return at most one row using the bound context
+func (executor *contextExecutor) QueryRowx(query string, args ...interface{}) *sqlx.Row {
+	return executor.Executor.QueryRowxContext(executor.ctx, query, args...)
+}
+
+// Prepare creates a prepared statement using the bound context
+func (executor *contextExecutor) Prepare(query string) (*sql.Stmt, error) {
+	return executor.Executor.PrepareContext(executor.ctx, query)
+}
+
+// Get executes a query and scans the first row into dest using the bound context
+func (executor *contextExecutor) Get(dest interface{}, query string, args ...interface{}) error {
+	return executor.Executor.GetContext(executor.ctx, dest, query, args...)
+}
+
+// Select executes a query and scans each row into dest using the bound context
+func (executor *contextExecutor) Select(dest interface{}, query string, args ...interface{}) error {
+	return executor.Executor.SelectContext(executor.ctx, dest, query, args...)
+}
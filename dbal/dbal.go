@@ -11,6 +11,7 @@ var Grammars = map[string]Grammar{}
 
 // BindingKeys the binding key orders
 var BindingKeys = []string{
+	"with",
 	"select", "from", "join", "where",
 	"groupBy", "having",
 	"order",
@@ -97,12 +98,15 @@ func NewQuery() *Query {
 		Aggregate:          Aggregate{},
 		Groups:             []interface{}{},
 		Havings:            []Having{},
+		Windows:            []NamedWindow{},
 		Limit:              -1,
 		Offset:             -1,
 		UnionLimit:         -1,
 		UnionOffset:        -1,
 		BindingOffset:      0,
+		CTEs:               []CTE{},
 		Bindings: map[string][]interface{}{
+			"with":       {},
 			"select":     {},
 			"from":       {},
 			"join":       {},
@@ -272,6 +276,14 @@ func (name Name) As() string {
 	return name.Alias
 }
 
+// Reference get the name the columns of the table are referred by, it's the alias if it's given.
+func (name Name) Reference() string {
+	if name.Alias != "" {
+		return name.Alias
+	}
+	return name.Fullname()
+}
+
 // IsEmpty determine if the from value is empty
 func (from From) IsEmpty() bool {
 	return from.Name == nil
@@ -287,6 +299,7 @@ func (query *Query) Clone() *Query {
 
 	new := Query{
 		UseWriteConnection: query.UseWriteConnection,    // Whether to use write connection for the select. default is false
+		CTEs:               query.CopyCTEs(),            // The common table expressions of the query.
 		Lock:               query.CopyLock(),            //  Indicates whether row locking is being used.
 		From:               query.CopyFrom(),            // The table which the query is targeting.
 		Columns:            query.CopyColumns(),         // The columns that should be returned. (Name or Expression)
@@ -302,11 +315,15 @@ func (query *Query) Clone() *Query {
 		Offset:             query.Offset,                // The number of records to skip.
 		Groups:             query.CopyGroups(),          // The groupings for the query.
 		Havings:            query.CopyHavings(),         // The having constraints for the query.
+		Windows:            query.CopyWindows(),         // The named windows for the query.
 		Bindings:           query.CopyBindings(),        // The current query value bindings.
 		Distinct:           query.Distinct,              // Indicates if the query returns distinct results. Occasionally contains the columns that should be distinct. default is false
 		DistinctColumns:    query.CopyDistinctColumns(), // Indicates if the query returns distinct results. Occasionally contains the columns that should be distinct.
 		IsJoinClause:       query.IsJoinClause,          // Determine if the query is a join clause.
 		BindingOffset:      query.BindingOffset,         // The Binding offset before select
+		Timeout:            query.Timeout,               // The timeout of the query
+		RawValues:          query.RawValues,             // Keep the values returned by the driver
+		Unscoped:           append([]string{}, query.Unscoped...), // The global scopes which are not applied to the query
 	}
 
 	// // new := NewQuery()
@@ -330,6 +347,15 @@ func (query *Query) CopyBindings() map[string][]interface{} {
 	return new
 }
 
+// CopyCTEs copy CTEs
+func (query *Query) CopyCTEs() []CTE {
+	new := []CTE{}
+	for _, cte := range query.CTEs {
+		new = append(new, cte)
+	}
+	return new
+}
+
 // CopyAggregate copy Aggregate
 func (query *Query) CopyAggregate() Aggregate {
 	new := query.Aggregate
@@ -420,6 +446,15 @@ func (query *Query) CopyGroups() []interface{} {
 	return new
 }
 
+// CopyWindows copy Windows
+func (query *Query) CopyWindows() []NamedWindow {
+	new := []NamedWindow{}
+	for _, window := range query.Windows {
+		new = append(new, window)
+	}
+	return new
+}
+
 // CopyHavings copy Havings
 func (query *Query) CopyHavings() []Having {
 	new := []Having{}
@@ -432,7 +467,7 @@ func (query *Query) CopyHavings() []Having {
 // AddColumn add a column to query
 func (query *Query) AddColumn(column interface{}) *Query {
 	switch column.(type) {
-	case Expression:
+	case Expression, WindowFunction, AggregateFunction, FullText:
 		query.Columns = append(query.Columns, column)
 	case string:
 		query.Columns = append(query.Columns, NewName(column.(string)))
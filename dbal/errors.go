@@ -0,0 +1,56 @@
+package dbal
+
+import (
+	"errors"
+)
+
+// The kinds of the database errors, the grammars translate the errors of the drivers into *Error values
+// matching them, so they could be checked with errors.Is for all the drivers.
+//
+//	if errors.Is(err, dbal.ErrUniqueViolation) { ... }
+var (
+	ErrNotFound            = errors.New("the record is not found")
+	ErrUniqueViolation     = errors.New("the unique constraint is violated")
+	ErrForeignKeyViolation = errors.New("the foreign key constraint is violated")
+	ErrNotNullViolation    = errors.New("the not null constraint is violated")
+	ErrDeadlock            = errors.New("the deadlock is detected")
+	ErrSerialization       = errors.New("the transaction could not be serialized")
+	ErrLockTimeout         = errors.New("the lock wait is timeout")
+	ErrQueryCanceled       = errors.New("the query is canceled")
+	ErrQueryTimeout        = errors.New("the query is timeout")
+)
+
+// Error the database error translated from the error of the driver, the original error is wrapped,
+// errors.As could still get it. The constraint, table and column are given if the driver provides them.
+//
+//	var dbErr *dbal.Error
+//	if errors.As(err, &dbErr) && errors.Is(err, dbal.ErrUniqueViolation) {
+//		fmt.Println(dbErr.Column)
+//	}
+type Error struct {
+	Kind       error
+	Constraint string
+	Table      string
+	Column     string
+	Err        error
+}
+
+// NewError create a new database error of the given kind wrapping the error of the driver
+func NewError(kind error, err error) *Error {
+	return &Error{Kind: kind, Err: err}
+}
+
+// Error the message of the original error
+func (err *Error) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap get the original error
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// Is determine if the error is the given kind
+func (err *Error) Is(target error) bool {
+	return err.Kind == target
+}
@@ -1,6 +1,9 @@
 package dbal
 
 import (
+	"context"
+	"time"
+
 	"github.com/jmoiron/sqlx"
 )
 
@@ -23,6 +26,8 @@ type Grammar interface {
 	GetDatabase() string
 	GetSchema() string
 	GetOperators() []string
+	GetMaxBindings() int
+	GetMaxPacketSize() (int, error)
 
 	// Grammar for migrating
 	GetTables() ([]string, error)
@@ -43,6 +48,7 @@ type Grammar interface {
 	CompileInsertUsing(query *Query, columns []interface{}, sql string) string
 	CompileUpsert(query *Query, columns []interface{}, values [][]interface{}, uniqueBy []interface{}, updateValues interface{}) (string, []interface{})
 	CompileUpdate(query *Query, values map[string]interface{}) (string, []interface{})
+	CompileUpdateBatch(query *Query, key string, columns []string, values []map[string]interface{}, strategy string) (string, []interface{})
 	CompileDelete(query *Query) (string, []interface{})
 	CompileTruncate(query *Query) ([]string, [][]interface{})
 	CompileSelect(query *Query) string
@@ -50,6 +56,61 @@ type Grammar interface {
 	CompileExists(query *Query) string
 
 	ProcessInsertGetID(sql string, bindings []interface{}, sequence string) (int64, error)
+
+	// Grammar for transactions
+	NewWithTx(tx *sqlx.Tx) (Grammar, error)
+	SupportsTransactionalDDL() bool
+	CompileSavepoint(name string) string
+	CompileSavepointRelease(name string) string
+	CompileSavepointRollback(name string) string
+
+	// Grammar for context
+	NewWithContext(ctx context.Context) (Grammar, error)
+
+	// Grammar for window functions
+	SupportsWindowFunctions(version *Version) bool
+
+	// Grammar for full-text search
+	SupportsFullText(version *Version) bool
+
+	// Grammar for the returning clause
+	SupportsReturning(version *Version) bool
+	CompileReturning(columns []interface{}) string
+
+	// Grammar for the batch updates
+	SupportsUpdateBatchValues(version *Version) bool
+
+	// Grammar for the joined deletes
+	SupportsDeleteJoinOrderLimit() bool
+
+	// Grammar for the bulk loads
+	BulkLoad(query *Query, columns []string, rows RowIterator) (int64, error)
+
+	// Grammar for the timeouts
+	CompileStatementTimeout(timeout time.Duration) string
+	CompileCurrentStatementTimeout() string
+	CompileRestoreStatementTimeout() string
+
+	// Grammar for the errors
+	TranslateError(err error) error
+}
+
+// RowIterator the source rows of a bulk load, the values are in the order of the loaded columns.
+// Next returns io.EOF after the last row.
+type RowIterator interface {
+	Next() ([]interface{}, error)
+}
+
+// Executor the statement executor interface, implemented by both *sqlx.DB and *sqlx.Tx
+type Executor interface {
+	sqlx.Ext
+	sqlx.ExtContext
+	sqlx.Preparer
+	sqlx.PreparerContext
+	Get(dest interface{}, query string, args ...interface{}) error
+	Select(dest interface{}, query string, args ...interface{}) error
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
 }
 
 // Quoter the database quoting query text intrface
@@ -64,4 +125,6 @@ type Quoter interface {
 	Parameter(value interface{}, num int) string
 	Parameterize(values []interface{}, offset int) string
 	Columnize(columns []interface{}) string
+	WrapJSONSelector(selector string) string
+	WrapJSONSet(target string, selector string, parameter string) string
 }
@@ -0,0 +1,151 @@
+package dbal
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrOffline the error returned by the statements executed on an offline connection
+var ErrOffline = errors.New("the statement could not be executed, the connection is offline")
+
+// Offline the database handle of the offline connections, nothing is sent to a database server.
+// The statements return the ErrOffline error, when pretending the statements without rows
+// (DDL, insert, update, delete) are recorded and succeed, the queries still return the ErrOffline error.
+type Offline struct {
+	pretend    bool
+	statements []string
+	mutex      sync.Mutex
+}
+
+// NewOffline create a new offline database handle, the statements are recorded if pretend is true.
+func NewOffline(pretend bool) *Offline {
+	return &Offline{pretend: pretend, statements: []string{}}
+}
+
+// Open open a new *sqlx.DB of the given driver name on the offline handle
+func (offline *Offline) Open(driverName string) *sqlx.DB {
+	return sqlx.NewDb(sql.OpenDB(offlineConnector{offline: offline}), driverName)
+}
+
+// Statements get the recorded statements in the executing order
+func (offline *Offline) Statements() []string {
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	statements := make([]string, len(offline.statements))
+	copy(statements, offline.statements)
+	return statements
+}
+
+// Reset clear the recorded statements
+func (offline *Offline) Reset() {
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	offline.statements = []string{}
+}
+
+// exec record the statement when pretending, otherwise return the ErrOffline error
+func (offline *Offline) exec(query string) (driver.Result, error) {
+	if !offline.pretend {
+		return nil, ErrOffline
+	}
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	offline.statements = append(offline.statements, query)
+	return driver.RowsAffected(0), nil
+}
+
+// offlineConnector the connector of the offline handle, the connections never dial a database server
+type offlineConnector struct {
+	offline *Offline
+}
+
+func (connector offlineConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return offlineConn{offline: connector.offline}, nil
+}
+
+func (connector offlineConnector) Driver() driver.Driver {
+	return offlineDriver{offline: connector.offline}
+}
+
+// offlineDriver the driver of the offline handle
+type offlineDriver struct {
+	offline *Offline
+}
+
+func (drv offlineDriver) Open(name string) (driver.Conn, error) {
+	return offlineConn{offline: drv.offline}, nil
+}
+
+// offlineConn the connection of the offline handle
+type offlineConn struct {
+	offline *Offline
+}
+
+func (conn offlineConn) Prepare(query string) (driver.Stmt, error) {
+	if !conn.offline.pretend {
+		return nil, ErrOffline
+	}
+	return offlineStmt{offline: conn.offline, query: query}, nil
+}
+
+func (conn offlineConn) Close() error {
+	return nil
+}
+
+func (conn offlineConn) Begin() (driver.Tx, error) {
+	if !conn.offline.pretend {
+		return nil, ErrOffline
+	}
+	return offlineTx{}, nil
+}
+
+func (conn offlineConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return conn.offline.exec(query)
+}
+
+func (conn offlineConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return nil, ErrOffline
+}
+
+// CheckNamedValue accept all of the bindings, they are never sent to a database server
+func (conn offlineConn) CheckNamedValue(value *driver.NamedValue) error {
+	return nil
+}
+
+// offlineStmt the prepared statement of the offline connection
+type offlineStmt struct {
+	offline *Offline
+	query   string
+}
+
+func (stmt offlineStmt) Close() error {
+	return nil
+}
+
+func (stmt offlineStmt) NumInput() int {
+	return -1
+}
+
+func (stmt offlineStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return stmt.offline.exec(stmt.query)
+}
+
+func (stmt offlineStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, ErrOffline
+}
+
+// offlineTx the transaction of the pretending connection
+type offlineTx struct{}
+
+func (tx offlineTx) Commit() error {
+	return nil
+}
+
+func (tx offlineTx) Rollback() error {
+	return nil
+}
@@ -2,12 +2,35 @@ package query
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/yaoapp/xun"
 	"github.com/yaoapp/xun/dbal"
 	"github.com/yaoapp/xun/utils"
 )
 
+// aggregateFunctions the functions of the aggregate columns
+var aggregateFunctions = []string{"count", "sum", "avg", "min", "max"}
+
+// SelectAggregate Add an aggregate function column to the query, the function could be count, sum, avg, min or max.
+//
+//	GroupBy("status").SelectAggregate("count", "*", "total")
+//	SelectAggregate("count", "email", "emails", true) // count(distinct `email`) as `emails`
+func (builder *Builder) SelectAggregate(fn string, column interface{}, alias string, distinct ...bool) Query {
+	fn = strings.ToLower(fn)
+	if !utils.StringHave(aggregateFunctions, fn) {
+		panic(fmt.Errorf("the aggregate function %q is not supported", fn))
+	}
+
+	builder.Query.AddColumn(dbal.AggregateFunction{
+		Func:     fn,
+		Column:   column,
+		Distinct: len(distinct) > 0 && distinct[0],
+		Alias:    alias,
+	})
+	return builder
+}
+
 // Count Retrieve the "count" result of the query.
 func (builder *Builder) Count(columns ...interface{}) (int64, error) {
 	if len(columns) == 0 {
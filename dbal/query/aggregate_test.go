@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
 	"github.com/yaoapp/xun/dbal/schema"
 	"github.com/yaoapp/xun/unit"
 )
@@ -75,6 +76,31 @@ func TestAggregateUnionMustCount(t *testing.T) {
 	assert.Equal(t, int64(7), value, "the return value should be 7")
 }
 
+func TestAggregateSelectAggregate(t *testing.T) {
+	NewTableFoAggregateTest()
+	qb := getTestBuilder()
+	rows := qb.Table("table_test_aggregate_t1").
+		Select("status").
+		SelectAggregate("count", "*", "total").
+		SelectAggregate("max", "vote", "max_vote").
+		GroupBy("status").
+		Having(dbal.AggregateFunction{Func: "count", Column: "*"}, ">", 1).
+		MustGet()
+
+	if assert.Equal(t, 1, len(rows), "the return value should has 1 row") {
+		assert.Equal(t, "DONE", rows[0]["status"], "the status should be DONE")
+		assert.Equal(t, 2, rows[0].GetInt("total"), "the total should be 2")
+		assert.Equal(t, 125, rows[0].GetInt("max_vote"), "the max vote should be 125")
+	}
+
+	value := qb.Table("table_test_aggregate_t1").SelectAggregate("count", "status", "statuses", true).MustFirst()
+	assert.Equal(t, 3, value.GetInt("statuses"), "the distinct statuses should be 3")
+
+	assert.PanicsWithError(t, `the aggregate function "median" is not supported`, func() {
+		qb.Table("table_test_aggregate_t1").SelectAggregate("median", "vote", "median")
+	})
+}
+
 // clean the test data
 func TestAggregateClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
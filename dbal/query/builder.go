@@ -51,6 +51,20 @@ func (builder *Builder) Driver() (string, error) {
 	return "", fmt.Errorf("the connection is nil")
 }
 
+// getVersion get the version of the connection database
+func (builder *Builder) getVersion() (*dbal.Version, error) {
+	if builder.Conn.Version != nil {
+		return builder.Conn.Version, nil
+	}
+
+	version, err := builder.Grammar.GetVersion()
+	if err != nil {
+		return nil, err
+	}
+	builder.Conn.Version = version
+	return version, nil
+}
+
 // Clone create a new builder instance with current builder
 func (builder *Builder) Clone() Query {
 	return builder.clone()
@@ -120,6 +134,9 @@ func newBuilder(driver string, dsn string) *Builder {
 // useBuilder create a new schema builder instance using the given connection
 func useBuilder(conn *Connection) *Builder {
 	grammar := newGrammar(conn)
+	if conn.Metadata == nil {
+		conn.Metadata = NewMetadata()
+	}
 	return &Builder{
 		Mode:     "production",
 		Conn:     conn,
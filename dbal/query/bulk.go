@@ -0,0 +1,229 @@
+package query
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+// BulkLoad Load a large number of rows into the table, it's much faster than the insert statements.
+// It uses "copy ... from stdin" on PostgreSQL, "load data local infile" on MySQL and a prepared insert statement
+// on the others, the rows are loaded in one transaction. The source could be a dbal.RowIterator, a [][]interface{}
+// or an io.Reader of CSV without the header line, the values are in the order of the columns and coerced
+// to the types of the table columns. It returns the number of the loaded rows.
+//
+//	BulkLoad([]string{"email", "vote"}, file)
+//	BulkLoad("email,vote", [][]interface{}{{"max@yao.run", 1}, {"ken@yao.run", 2}})
+func (builder *Builder) BulkLoad(columns interface{}, source interface{}) (int64, error) {
+	names := []string{}
+	for _, column := range builder.prepareColumns(columns) {
+		names = append(names, fmt.Sprintf("%v", column))
+	}
+
+	if len(names) == 0 {
+		return 0, fmt.Errorf("the bulk load needs at least one column")
+	}
+
+	name, ok := builder.Query.From.Name.(dbal.Name)
+	if !ok {
+		return 0, fmt.Errorf("the bulk load needs a table")
+	}
+
+	table, err := builder.Grammar.GetTable(name.Fullname())
+	if err != nil {
+		return 0, err
+	}
+
+	targets := []*dbal.Column{}
+	for _, column := range names {
+		target, has := table.ColumnMap[column]
+		if !has {
+			return 0, fmt.Errorf("the column %s does not exist in the table %s", column, name.Fullname())
+		}
+		targets = append(targets, target)
+	}
+
+	rows, err := newBulkRows(source, targets)
+	if err != nil {
+		return 0, err
+	}
+
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+
+	var loaded int64 = 0
+	err = builder.transaction(func(qb Query) error {
+		loaded, err = qb.Builder().Grammar.BulkLoad(builder.Query, names, rows)
+		return builder.translateError(err)
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return loaded, nil
+}
+
+// MustBulkLoad Load a large number of rows into the table, it's much faster than the insert statements.
+func (builder *Builder) MustBulkLoad(columns interface{}, source interface{}) int64 {
+	loaded, err := builder.BulkLoad(columns, source)
+	utils.PanicIF(err)
+	return loaded
+}
+
+// bulkRows the source rows of a bulk load, the values are coerced to the types of the table columns
+type bulkRows struct {
+	columns []*dbal.Column
+	next    func() ([]interface{}, error)
+	line    int
+}
+
+// sliceRows the rows of a [][]interface{} source
+type sliceRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+// newBulkRows Create the source rows of a bulk load
+func newBulkRows(source interface{}, columns []*dbal.Column) (*bulkRows, error) {
+	rows := &bulkRows{columns: columns}
+	switch src := source.(type) {
+	case dbal.RowIterator:
+		rows.next = src.Next
+	case [][]interface{}:
+		rows.next = (&sliceRows{rows: src}).Next
+	case io.Reader:
+		reader := csv.NewReader(src)
+		reader.FieldsPerRecord = len(columns)
+		rows.next = func() ([]interface{}, error) {
+			record, err := reader.Read()
+			if err != nil {
+				return nil, err
+			}
+			row := []interface{}{}
+			for _, field := range record {
+				row = append(row, field)
+			}
+			return row, nil
+		}
+	default:
+		return nil, fmt.Errorf("the bulk load source must be a dbal.RowIterator, a [][]interface{} or an io.Reader of CSV")
+	}
+	return rows, nil
+}
+
+// Next get the next row of a [][]interface{} source
+func (rows *sliceRows) Next() ([]interface{}, error) {
+	if rows.pos >= len(rows.rows) {
+		return nil, io.EOF
+	}
+	rows.pos++
+	return rows.rows[rows.pos-1], nil
+}
+
+// Next get the next row with the values coerced to the types of the table columns
+func (rows *bulkRows) Next() ([]interface{}, error) {
+	row, err := rows.next()
+	if err != nil {
+		return nil, err
+	}
+
+	rows.line++
+	if len(row) != len(rows.columns) {
+		return nil, fmt.Errorf("the row %d has %d values, %d columns expected", rows.line, len(row), len(rows.columns))
+	}
+
+	values := []interface{}{}
+	for i, column := range rows.columns {
+		value, err := coerceBulkValue(column, row[i])
+		if err != nil {
+			return nil, fmt.Errorf("the row %d: %s", rows.line, err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// coerceBulkValue Cast the value to the type of the column, the strings of CSV are parsed by the column type
+// and the empty strings are NULL for the nullable columns which are not strings. The maps, slices and structs
+// are encoded as JSON.
+func coerceBulkValue(column *dbal.Column, value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case nil, []byte, time.Time:
+		return value, nil
+	}
+
+	kind := reflect.TypeOf(value).Kind()
+	if kind == reflect.Map || kind == reflect.Slice || kind == reflect.Array || kind == reflect.Struct {
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("the value of the column %s is not a valid json: %s", column.Name, err)
+		}
+		return string(bytes), nil
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if str == "" && column.Nullable && !isBulkStringType(column.Type) {
+		return nil, nil
+	}
+
+	switch column.Type {
+	case "tinyInteger", "smallInteger", "integer", "bigInteger", "year":
+		if column.IsUnsigned {
+			v, err := strconv.ParseUint(str, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("the value %q of the column %s is not a valid %s", str, column.Name, column.Type)
+			}
+			return v, nil
+		}
+		v, err := strconv.ParseInt(str, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the value %q of the column %s is not a valid %s", str, column.Name, column.Type)
+		}
+		return v, nil
+
+	case "float", "double":
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return nil, fmt.Errorf("the value %q of the column %s is not a valid %s", str, column.Name, column.Type)
+		}
+		return v, nil
+
+	case "decimal":
+		// the decimal is kept as a string to keep the precision
+		if _, err := strconv.ParseFloat(str, 64); err != nil {
+			return nil, fmt.Errorf("the value %q of the column %s is not a valid %s", str, column.Name, column.Type)
+		}
+		return str, nil
+
+	case "boolean":
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return nil, fmt.Errorf("the value %q of the column %s is not a valid %s", str, column.Name, column.Type)
+		}
+		return v, nil
+	}
+	return str, nil
+}
+
+// isBulkStringType Determine if the empty string is a valid value of the column type
+func isBulkStringType(typ string) bool {
+	switch typ {
+	case "string", "char", "text", "mediumText", "longText", "enum", "binary":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package query
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestBulkLoadMustBulkLoadCSV(t *testing.T) {
+	NewTableForBulkTest()
+	qb := getTestBuilder()
+	csv := strings.NewReader("max@yao.run,19,86.5,true,\"{\"\"tags\"\": [\"\"a\"\"]}\"\nken@yao.run,,,false,\n")
+	loaded := qb.Table("table_test_bulk").MustBulkLoad([]string{"email", "vote", "score", "active", "meta"}, csv)
+	assert.Equal(t, int64(2), loaded, "The loaded rows should be 2")
+
+	rows := qb.Table("table_test_bulk").OrderBy("id").MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "19", fmt.Sprintf("%v", rows[0]["vote"]), "the vote of 1st row should be 19")
+		assert.Equal(t, "86.5", fmt.Sprintf("%v", rows[0]["score"]), "the score of 1st row should be 86.5")
+		assert.Nil(t, rows[1]["vote"], "the vote of 2nd row should be NULL")
+	}
+}
+
+func TestBulkLoadMustBulkLoadRows(t *testing.T) {
+	NewTableForBulkTest()
+	qb := getTestBuilder()
+	rows := [][]interface{}{}
+	for i := 0; i < 2000; i++ {
+		rows = append(rows, []interface{}{fmt.Sprintf("user%d@yao.run", i), i, map[string]interface{}{"id": i}})
+	}
+	loaded := qb.Table("table_test_bulk").MustBulkLoad("email,vote,meta", rows)
+	assert.Equal(t, int64(2000), loaded, "The loaded rows should be 2000")
+	assert.Equal(t, int64(2000), qb.Table("table_test_bulk").MustCount(), "The table should has 2000 rows")
+}
+
+func TestBulkLoadMustBulkLoadIterator(t *testing.T) {
+	NewTableForBulkTest()
+	qb := getTestBuilder()
+	loaded := qb.Table("table_test_bulk").MustBulkLoad([]string{"email", "vote"}, &testBulkIterator{count: 3})
+	assert.Equal(t, int64(3), loaded, "The loaded rows should be 3")
+	vote := qb.Table("table_test_bulk").Where("email", "user2@yao.run").MustValue("vote")
+	assert.Equal(t, "2", fmt.Sprintf("%v", vote), "the vote of user2 should be 2")
+}
+
+func TestBulkLoadBulkLoadError(t *testing.T) {
+	NewTableForBulkTest()
+	qb := getTestBuilder()
+
+	_, err := qb.Table("table_test_bulk").BulkLoad([]string{"email", "vote"}, strings.NewReader("max@yao.run,19\nken@yao.run,many\n"))
+	assert.Equal(t, `the row 2: the value "many" of the column vote is not a valid integer`, err.Error())
+	assert.Equal(t, int64(0), qb.Table("table_test_bulk").MustCount(), "The loaded rows should be rolled back")
+
+	_, err = qb.Table("table_test_bulk").BulkLoad([]string{"email", "missing"}, [][]interface{}{})
+	assert.Equal(t, "the column missing does not exist in the table table_test_bulk", err.Error())
+
+	_, err = qb.Table("table_test_bulk").BulkLoad([]string{"email"}, "max@yao.run")
+	assert.Equal(t, "the bulk load source must be a dbal.RowIterator, a [][]interface{} or an io.Reader of CSV", err.Error())
+}
+
+// clean the test data
+func TestBulkLoadClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_bulk")
+}
+
+func NewTableForBulkTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_bulk")
+	builder.MustCreateTable("table_test_bulk", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.Integer("vote").Null()
+		table.Float("score", 5, 2).Null()
+		table.Boolean("active").SetDefault(false)
+		table.JSON("meta").Null()
+	})
+}
+
+type testBulkIterator struct {
+	count int
+	pos   int
+}
+
+func (iter *testBulkIterator) Next() ([]interface{}, error) {
+	if iter.pos >= iter.count {
+		return nil, io.EOF
+	}
+	iter.pos++
+	return []interface{}{fmt.Sprintf("user%d@yao.run", iter.pos-1), iter.pos - 1}, nil
+}
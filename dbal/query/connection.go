@@ -1,6 +1,9 @@
 package query
 
-import "github.com/jmoiron/sqlx"
+import (
+	"github.com/jmoiron/sqlx"
+	"github.com/yaoapp/xun/dbal"
+)
 
 // DB Get the sqlx.DB pointer instance
 func (builder *Builder) DB(usewrite ...bool) *sqlx.DB {
@@ -10,6 +13,14 @@ func (builder *Builder) DB(usewrite ...bool) *sqlx.DB {
 	return builder.Conn.Read
 }
 
+// executor Get the statement executor, the transaction if the builder is bound to one, or the sqlx.DB pointer instance
+func (builder *Builder) executor(usewrite ...bool) dbal.Executor {
+	if builder.Tx != nil {
+		return dbal.WithContext(builder.Ctx, builder.Tx.Tx)
+	}
+	return dbal.WithContext(builder.Ctx, builder.DB(usewrite...))
+}
+
 // UseWrite Use the write connection for query.
 func (builder *Builder) UseWrite() Query {
 	builder.Query.UseWriteConnection = true
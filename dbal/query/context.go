@@ -0,0 +1,32 @@
+package query
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithContext Create a new builder instance bound to the given context, all of the statements
+// (including the statements executed by the grammar) will be executed using the context.
+func (builder *Builder) WithContext(ctx context.Context) Query {
+	if ctx == nil {
+		panic(fmt.Errorf("the context is nil"))
+	}
+
+	grammar, err := builder.Grammar.NewWithContext(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	new := builder.clone()
+	new.Ctx = ctx
+	new.Grammar = grammar
+	return new
+}
+
+// Context Get the context of the builder, returns context.Background() if the builder is not bound to one
+func (builder *Builder) Context() context.Context {
+	if builder.Ctx != nil {
+		return builder.Ctx
+	}
+	return context.Background()
+}
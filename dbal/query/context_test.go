@@ -0,0 +1,85 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+type testContextKey string
+
+func TestContextWithContext(t *testing.T) {
+	NewTableForContextTest()
+	qb := getTestBuilder()
+	ctx := context.WithValue(context.Background(), testContextKey("request_id"), "1024")
+
+	rows := qb.WithContext(ctx).Table("table_test_context").OrderBy("id").MustGet()
+	assert.Equal(t, 2, len(rows), "the rows count should be 2")
+
+	qbCtx := qb.Table("table_test_context").Where("email", "john@yao.run").WithContext(ctx)
+	assert.Equal(t, "1024", qbCtx.Context().Value(testContextKey("request_id")), "the context should be bound")
+	assert.Equal(t, int64(1), qbCtx.MustCount(), "the where clause should be kept")
+	assert.Equal(t, context.Background(), qb.Context(), "the original builder should not be bound")
+}
+
+func TestContextCanceled(t *testing.T) {
+	NewTableForContextTest()
+	qb := getTestBuilder()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := qb.WithContext(ctx).Table("table_test_context").Get()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = qb.WithContext(ctx).Table("table_test_context").Insert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = qb.WithContext(ctx).Table("table_test_context").Where("email", "john@yao.run").Update(xun.R{"vote": 100})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = qb.WithContext(ctx).Table("table_test_context").Where("email", "john@yao.run").Delete()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	err = qb.WithContext(ctx).Transaction(func(qb Query) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = getTestSchemaBuilder().WithContext(ctx).GetTables()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, int64(2), qb.Table("table_test_context").MustCount(), "the rows count should be 2")
+}
+
+func TestContextNil(t *testing.T) {
+	qb := getTestBuilder()
+	assert.Panics(t, func() {
+		qb.WithContext(nil)
+	})
+}
+
+// clean the test data
+func TestContextClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_context")
+}
+
+func NewTableForContextTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_context")
+	builder.MustCreateTable("table_test_context", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email").Unique()
+		table.String("name").Index()
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_context").MustInsert([]xun.R{
+		{"email": "john@yao.run", "name": "John", "vote": 10},
+		{"email": "lee@yao.run", "name": "Lee", "vote": 5},
+	})
+}
@@ -0,0 +1,200 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/utils"
+)
+
+// Cursor the streaming row iterator, it reads the rows one at a time and holds the
+// connection until all of the rows were read or the cursor was closed.
+type Cursor struct {
+	builder  *Builder
+	stmt     *sql.Stmt
+	rows     *sql.Rows
+	columns  []string
+	kinds    []string
+	values   []interface{}
+	fieldMap map[string]reflect.StructField
+	dest     reflect.Type
+	cancel   context.CancelFunc
+	closed   bool
+}
+
+// Cursor Execute the query as a "select" statement and get a cursor to iterate the results row by row.
+// The cursor must be closed after using, otherwise the connection will not be released.
+//
+//	cur, err := qb.Table("users").Cursor()
+//	defer cur.Close()
+//	for cur.Next() {
+//		row, err := cur.Row()
+//	}
+func (builder *Builder) Cursor() (*Cursor, error) {
+	builder, err := builder.scoped()
+	if err != nil {
+		return nil, err
+	}
+
+	err = builder.checkWindowFunctions()
+	if err != nil {
+		return nil, err
+	}
+
+	// the context of the timeout is released when the cursor is closed
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	sql := builder.ToSQL()
+	bindings := builder.GetBindings()
+	defer log.With(log.F{"bindings": bindings}).Trace("builder cursor sql:%s", sql)
+	stmt, err := builder.executor().Prepare(sql)
+	if err != nil {
+		cancel()
+		return nil, builder.translateError(err)
+	}
+
+	rows, err := stmt.QueryContext(builder.Context(), bindings...)
+	if err != nil {
+		stmt.Close()
+		cancel()
+		return nil, builder.translateError(err)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		stmt.Close()
+		cancel()
+		return nil, err
+	}
+
+	kinds, err := builder.getDecodeKinds(rows, len(columns))
+	if err != nil {
+		rows.Close()
+		stmt.Close()
+		cancel()
+		return nil, err
+	}
+
+	return &Cursor{
+		builder: builder,
+		stmt:    stmt,
+		rows:    rows,
+		columns: columns,
+		kinds:   kinds,
+		values:  builder.makeMapValues(len(columns)),
+		cancel:  cancel,
+	}, nil
+}
+
+// MustCursor Execute the query as a "select" statement and get a cursor to iterate the results row by row.
+func (builder *Builder) MustCursor() *Cursor {
+	cur, err := builder.Cursor()
+	utils.PanicIF(err)
+	return cur
+}
+
+// Each Execute the query and feed each row of the results into the callback, the rows are read one at a time.
+// The iteration will be stopped if the callback returns an error.
+func (builder *Builder) Each(callback func(row xun.R) error) error {
+	cur, err := builder.Cursor()
+	if err != nil {
+		return err
+	}
+	defer cur.Close()
+
+	for cur.Next() {
+		row, err := cur.Row()
+		if err != nil {
+			return err
+		}
+		if err := callback(row); err != nil {
+			return err
+		}
+	}
+	return cur.Err()
+}
+
+// MustEach Execute the query and feed each row of the results into the callback, the rows are read one at a time.
+func (builder *Builder) MustEach(callback func(row xun.R) error) {
+	err := builder.Each(callback)
+	utils.PanicIF(err)
+}
+
+// Next Prepare the next row for reading, returns false if there are no more rows or an error occurred.
+// The cursor will be closed automatically when all of the rows were read.
+func (cur *Cursor) Next() bool {
+	if cur.closed {
+		return false
+	}
+	if !cur.rows.Next() {
+		cur.Close()
+		return false
+	}
+	return true
+}
+
+// Columns Get the column names of the results
+func (cur *Cursor) Columns() []string {
+	return cur.columns
+}
+
+// Row Get the current row as xun.R
+func (cur *Cursor) Row() (xun.R, error) {
+	return cur.builder.mapScanRow(cur.rows, cur.columns, cur.kinds, cur.values)
+}
+
+// Scan Scan the current row into the given struct pointer, the value could be reused for each row.
+func (cur *Cursor) Scan(v interface{}) error {
+	reflectPtr := reflect.ValueOf(v)
+	if reflectPtr.Kind() != reflect.Ptr || reflectPtr.IsNil() {
+		return fmt.Errorf("The input param is %s, it should be a pointer", reflectPtr.Kind().String())
+	}
+
+	structType := reflectPtr.Elem().Type()
+	if structType.Kind() != reflect.Struct {
+		return cur.rows.Scan(v)
+	}
+
+	if cur.dest != structType {
+		fieldMap, err := cur.builder.getFieldMap(structType)
+		if err != nil {
+			return err
+		}
+		cur.fieldMap = fieldMap
+		cur.dest = structType
+	}
+
+	reflectPtr.Elem().Set(reflect.Zero(structType))
+	values, err := cur.builder.makeStructValues(reflectPtr, cur.fieldMap, cur.columns)
+	if err != nil {
+		return err
+	}
+	return cur.rows.Scan(values...)
+}
+
+// Err Get the error encountered during the iteration
+func (cur *Cursor) Err() error {
+	return cur.builder.translateError(cur.rows.Err())
+}
+
+// Close Close the cursor and release the connection, it is safe to call Close more than once.
+func (cur *Cursor) Close() error {
+	if cur.closed {
+		return nil
+	}
+	cur.closed = true
+	err := cur.rows.Close()
+	if errStmt := cur.stmt.Close(); err == nil {
+		err = errStmt
+	}
+	cur.cancel()
+	return err
+}
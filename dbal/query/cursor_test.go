@@ -0,0 +1,114 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestCursorNext(t *testing.T) {
+	NewTableForCursorTest()
+	qb := getTestBuilder()
+	cur := qb.Table("table_test_cursor").Select("id", "email", "vote").OrderBy("id").MustCursor()
+	defer cur.Close()
+
+	IDs := []int64{}
+	for cur.Next() {
+		row, err := cur.Row()
+		if err != nil {
+			t.Fatal(err)
+		}
+		IDs = append(IDs, row.Get("id").(int64))
+	}
+	assert.Nil(t, cur.Err(), "the error should be nil")
+	assert.Equal(t, []string{"id", "email", "vote"}, cur.Columns())
+	assert.Equal(t, []int64{1, 2, 3, 4}, IDs, "the ids should be []int64{1,2,3,4}")
+	assert.False(t, cur.Next(), "the cursor should be closed")
+	assert.Nil(t, cur.Close(), "the cursor could be closed more than once")
+}
+
+func TestCursorScan(t *testing.T) {
+
+	type Item struct {
+		ID    int64
+		Email string
+		Vote  int
+	}
+
+	NewTableForCursorTest()
+	qb := getTestBuilder()
+	cur := qb.Table("table_test_cursor").Select("id", "email", "vote").OrderBy("id").MustCursor()
+	defer cur.Close()
+
+	item := Item{}
+	emails := []string{}
+	for cur.Next() {
+		err := cur.Scan(&item)
+		if err != nil {
+			t.Fatal(err)
+		}
+		emails = append(emails, item.Email)
+	}
+	assert.Equal(t, []string{"john@yao.run", "lee@yao.run", "ken@yao.run", "ben@yao.run"}, emails)
+	assert.Equal(t, int64(4), item.ID, "the last id should be 4")
+}
+
+func TestCursorEach(t *testing.T) {
+	NewTableForCursorTest()
+	qb := getTestBuilder()
+	votes := 0
+	qb.Table("table_test_cursor").Where("vote", ">", 5).MustEach(func(row xun.R) error {
+		votes = votes + int(row.Get("vote").(int64))
+		return nil
+	})
+	assert.Equal(t, 141, votes, "the votes should be 141")
+
+	hits := 0
+	err := qb.Table("table_test_cursor").OrderBy("id").Each(func(row xun.R) error {
+		hits++
+		if hits == 2 {
+			return fmt.Errorf("stop")
+		}
+		return nil
+	})
+	assert.Equal(t, "stop", err.Error())
+	assert.Equal(t, 2, hits, "the hits should be 2")
+
+	// the connection should be released
+	assert.Equal(t, int64(4), qb.Table("table_test_cursor").MustCount(), "the rows count should be 4")
+}
+
+func TestCursorError(t *testing.T) {
+	qb := getTestBuilder()
+	_, err := qb.Table("table_test_cursor_not_exists").Cursor()
+	assert.NotNil(t, err, "the error should not be nil")
+}
+
+// clean the test data
+func TestCursorClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_cursor")
+}
+
+func NewTableForCursorTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_cursor")
+	builder.MustCreateTable("table_test_cursor", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email").Unique()
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_cursor").MustInsert([]xun.R{
+		{"email": "john@yao.run", "vote": 10},
+		{"email": "lee@yao.run", "vote": 5},
+		{"email": "ken@yao.run", "vote": 125},
+		{"email": "ben@yao.run", "vote": 6},
+	})
+}
@@ -0,0 +1,302 @@
+package query
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// decodeKinds the decoding kinds of the database types reported by the drivers (mysql, postgres and sqlite3)
+var decodeKinds = map[string]string{
+	"JSON":        "json",
+	"JSONB":       "json",
+	"DECIMAL":     "decimal",
+	"NUMERIC":     "decimal",
+	"BOOL":        "bool",
+	"BOOLEAN":     "bool",
+	"DATE":        "time",
+	"TIME":        "time",
+	"TIMETZ":      "time",
+	"DATETIME":    "time",
+	"TIMESTAMP":   "time",
+	"TIMESTAMPTZ": "time",
+	"BLOB":        "binary",
+	"TINYBLOB":    "binary",
+	"MEDIUMBLOB":  "binary",
+	"LONGBLOB":    "binary",
+	"BINARY":      "binary",
+	"VARBINARY":   "binary",
+	"BYTEA":       "binary",
+}
+
+// metadataTypes the database types of which the decoding kinds are found by the table metadata,
+// the MySQL BOOLEAN columns are TINYINT(1) and the SQLite JSON columns are TEXT.
+var metadataTypes = map[string]bool{
+	"TINYINT": true,
+	"TEXT":    true,
+}
+
+// KeepRawValues Keep the values of the map results as they are returned by the driver, the values are not decoded
+// by the column types (the bytes are still converted to strings).
+//
+// The map results are decoded by the column types by default: JSON and JSONB to map[string]interface{} or []interface{},
+// DECIMAL and NUMERIC to xun.N, BOOLEAN to bool, DATE, TIME and TIMESTAMP to xun.T and the BLOB bytes are kept.
+// The MySQL BOOLEAN (TINYINT(1)) and the SQLite JSON (TEXT) columns are found by the metadata of the tables of the query,
+// the metadata is cached by the connection, the values are kept as they are if the columns could not be found.
+//
+//	rows, err := qb.Table("orders").KeepRawValues().Get()
+func (builder *Builder) KeepRawValues() Query {
+	builder.Query.RawValues = true
+	return builder
+}
+
+// getDecodeKinds Get the decoding kinds of the result columns, the kinds are empty if the raw values are kept
+func (builder *Builder) getDecodeKinds(rows *sql.Rows, length int) ([]string, error) {
+	kinds := make([]string, length)
+	if builder.Query.RawValues {
+		return kinds, nil
+	}
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var columns map[string]string
+	for i, typ := range types {
+		name := strings.ToUpper(typ.DatabaseTypeName())
+		if pos := strings.Index(name, "("); pos > 0 {
+			name = name[:pos]
+		}
+		name = strings.TrimSpace(name)
+		kinds[i] = decodeKinds[name]
+
+		if fields := strings.Fields(name); kinds[i] == "" && len(fields) > 0 && metadataTypes[fields[0]] {
+			if columns == nil {
+				columns = builder.getColumnKinds()
+			}
+			kinds[i] = columns[typ.Name()]
+		}
+	}
+	return kinds, nil
+}
+
+// getColumnKinds Get the decoding kinds of the result columns found by the table metadata, the keys are the names of
+// the result columns. The selected columns are resolved to the columns of the tables of the query by the table names
+// or aliases, the ambiguous columns are not decoded.
+func (builder *Builder) getColumnKinds() map[string]string {
+	tables := []dbal.Name{}
+	if name, ok := builder.Query.From.Name.(dbal.Name); ok {
+		tables = append(tables, name)
+	}
+	for _, join := range builder.Query.Joins {
+		if name, ok := join.Name.(dbal.Name); ok {
+			tables = append(tables, name)
+		}
+	}
+
+	// the columns of the given table, or the unqualified column of all the tables
+	lookup := func(table string, column string) (string, bool) {
+		kind, found := "", false
+		for _, name := range tables {
+			if table != "" && table != name.Reference() && table != name.Fullname() && table != name.Name {
+				continue
+			}
+			columns := builder.getTableColumnKinds(name.Fullname())
+			if _, has := columns[column]; !has {
+				continue
+			}
+			if found && kind != columns[column] {
+				return "", false
+			}
+			kind, found = columns[column], true
+		}
+		return kind, found
+	}
+
+	// the wildcard columns of the given table, or of all the tables
+	res := map[string]string{}
+	ambiguous := map[string]bool{}
+	wildcard := func(table string) {
+		for _, name := range tables {
+			if table != "" && table != name.Reference() && table != name.Fullname() && table != name.Name {
+				continue
+			}
+			for column, kind := range builder.getTableColumnKinds(name.Fullname()) {
+				if current, has := res[column]; has && current != kind {
+					ambiguous[column] = true
+				}
+				res[column] = kind
+			}
+		}
+	}
+
+	selected := map[string]string{}
+	if len(builder.Query.Columns) == 0 {
+		wildcard("")
+	}
+	for _, column := range builder.Query.Columns {
+		var name dbal.Name
+		switch value := column.(type) {
+		case string:
+			name = dbal.NewName(value)
+		case dbal.Name:
+			name = value
+		case dbal.Expression:
+			if raw, ok := value.Value.(string); ok && strings.TrimSpace(raw) == "*" {
+				wildcard("")
+			}
+			continue
+		default:
+			continue
+		}
+
+		table, field := "", name.Name
+		if pos := strings.LastIndex(field, "."); pos >= 0 {
+			table, field = field[:pos], field[pos+1:]
+		}
+		if field == "*" {
+			wildcard(table)
+			continue
+		}
+
+		key := field
+		if name.Alias != "" {
+			key = name.Alias
+		}
+		if kind, ok := lookup(table, field); ok {
+			selected[key] = kind
+		} else {
+			ambiguous[key] = true
+		}
+	}
+
+	for column := range ambiguous {
+		delete(res, column)
+	}
+	for column, kind := range selected {
+		res[column] = kind
+	}
+	return res
+}
+
+// getTableColumnKinds Get the decoding kinds of the columns of the table by the table metadata, the MySQL BOOLEAN (TINYINT(1))
+// and the SQLite JSON columns. The kinds are cached by the connection, there is no kind if the metadata could not be read.
+func (builder *Builder) getTableColumnKinds(table string) map[string]string {
+	metadata := builder.metadata()
+	metadata.mutex.RLock()
+	kinds, has := metadata.columns[table]
+	metadata.mutex.RUnlock()
+	if has {
+		return kinds
+	}
+
+	kinds = map[string]string{}
+	columns, err := builder.Grammar.GetColumnListing(builder.Database, table)
+	if err != nil {
+		log.Warn("the metadata of the %s table is not read, the values are not decoded: %s", table, err.Error())
+	}
+
+	for _, column := range columns {
+		typeName := strings.ToUpper(column.TypeName)
+		switch {
+		case column.Type == "boolean" || typeName == "TINYINT(1)":
+			kinds[column.Name] = "bool"
+		case column.Type == "json" || column.Type == "jsonb" || strings.Contains(typeName, "JSON"):
+			kinds[column.Name] = "json"
+		default:
+			kinds[column.Name] = ""
+		}
+	}
+
+	metadata.mutex.Lock()
+	if metadata.columns == nil {
+		metadata.columns = map[string]map[string]string{}
+	}
+	metadata.columns[table] = kinds
+	metadata.mutex.Unlock()
+	return kinds
+}
+
+// decodeValue Decode the scanned value by the kind of the column type:
+// JSON to map[string]interface{} or []interface{}, DECIMAL to xun.N, BOOLEAN to bool,
+// DATE/TIME/TIMESTAMP to xun.T, the BLOB bytes are kept and the other bytes are converted to strings.
+func (builder *Builder) decodeValue(kind string, src interface{}) interface{} {
+	value := src
+	if reflect.TypeOf(src).Kind() == reflect.Ptr {
+		value = reflect.Indirect(reflect.ValueOf(src)).Interface()
+	}
+
+	if value == nil {
+		return nil
+	}
+
+	switch kind {
+	case "json":
+		var data []byte
+		switch v := value.(type) {
+		case []byte:
+			data = v
+		case string:
+			data = []byte(v)
+		default:
+			return value
+		}
+		var res interface{}
+		if err := json.Unmarshal(data, &res); err != nil {
+			return string(data)
+		}
+		return res
+
+	case "decimal":
+		if v, ok := value.([]byte); ok {
+			return xun.MakeN(string(v))
+		}
+		return xun.MakeN(value)
+
+	case "bool":
+		switch v := value.(type) {
+		case bool:
+			return v
+		case int64:
+			return v != 0
+		case []byte:
+			if res, err := strconv.ParseBool(string(v)); err == nil {
+				return res
+			}
+			return string(v)
+		case string:
+			if res, err := strconv.ParseBool(v); err == nil {
+				return res
+			}
+			return v
+		}
+		return value
+
+	case "time":
+		switch v := value.(type) {
+		case time.Time:
+			return xun.MakeTime(v)
+		case []byte:
+			return xun.MakeTime(string(v))
+		case string:
+			return xun.MakeTime(v)
+		}
+		return value
+
+	case "binary":
+		return value
+	}
+
+	if v, ok := value.([]byte); ok {
+		return string(v)
+	}
+	return value
+}
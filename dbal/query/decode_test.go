@@ -0,0 +1,176 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestDecodeGet(t *testing.T) {
+	NewTableForDecodeTest()
+	qb := getTestBuilder()
+	rows := qb.Table("table_test_decode").OrderBy("id").MustGet()
+	if !assert.Equal(t, 2, len(rows), "the return value should has 2 rows") {
+		return
+	}
+
+	assert.Equal(t, map[string]interface{}{"color": "red", "tags": []interface{}{"a", "b"}}, rows[0]["meta"], "the json object should be decoded")
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, rows[1]["meta"], "the json array should be decoded")
+
+	price, ok := rows[0]["price"].(xun.N)
+	if assert.True(t, ok, "the decimal should be a xun.N") {
+		assert.Equal(t, 12.5, price.MustToFixed(2), "the price should be 12.5")
+	}
+	assert.Equal(t, 12.5, rows[0].GetFloat("price", 2), "the price should be 12.5")
+
+	assert.Equal(t, true, rows[0]["active"], "the boolean should be decoded")
+	assert.Equal(t, false, rows[1]["active"], "the boolean should be decoded")
+	assert.True(t, rows[0].GetBool("active"), "the active should be true")
+
+	paidAt, ok := rows[0]["paid_at"].(xun.T)
+	assert.True(t, ok, "the timestamp should be a xun.T")
+	assert.Equal(t, "2021-03-25 00:21:16", paidAt.MustToTime().Format("2006-01-02 15:04:05"), "the paid_at should be 2021-03-25 00:21:16")
+
+	assert.Equal(t, []byte("raw"), rows[0]["data"], "the binary should be kept as bytes")
+	assert.Nil(t, rows[1]["price"], "the NULL value should be nil")
+	assert.Nil(t, rows[1]["paid_at"], "the NULL value should be nil")
+}
+
+func TestDecodeSelectAndJoin(t *testing.T) {
+	NewTableForDecodeTest()
+	qb := getTestBuilder()
+
+	// the aliased columns are decoded by the columns of the tables
+	row := qb.Table("table_test_decode as d").Select("d.active as a", "meta as m", "id").OrderBy("id").MustFirst()
+	assert.Equal(t, true, row["a"], "the aliased boolean should be decoded")
+	assert.Equal(t, map[string]interface{}{"color": "red", "tags": []interface{}{"a", "b"}}, row["m"], "the aliased json should be decoded")
+
+	// the columns of the joined table sharing the names are not mixed up
+	rows := qb.Table("table_test_decode as d").
+		Join("table_test_decode_flag as f", "f.decode_id", "=", "d.id").
+		Select("d.active", "f.active as flag", "f.meta as label").
+		OrderBy("d.id").
+		MustGet()
+	if assert.Equal(t, 2, len(rows), "the return value should has 2 rows") {
+		assert.Equal(t, true, rows[0]["active"], "the boolean should be decoded")
+		assert.Equal(t, int64(5), rows[0].Get("flag"), "the integer of the joined table should not be decoded")
+		assert.Equal(t, `{"color":"red"}`, rows[0]["label"], "the text of the joined table should not be decoded")
+	}
+}
+
+func TestDecodeMetadataError(t *testing.T) {
+	NewTableForDecodeTest()
+	qb := getTestBuilder()
+	builder := qb.Builder()
+	conn := builder.Conn
+	metadata := conn.Metadata
+	conn.Metadata = NewMetadata()
+	defer func() { conn.Metadata = metadata }()
+
+	grammar := &columnListingGrammar{Grammar: builder.Grammar}
+	builder.Grammar = grammar
+	defer func() { builder.Grammar = grammar.Grammar }()
+
+	// the values are kept as they are if the metadata could not be read
+	grammar.err = fmt.Errorf("the metadata is not readable")
+	row, err := qb.Table("table_test_decode").Select("id", "meta").OrderBy("id").First()
+	assert.Nil(t, err, "the query should not fail by the metadata")
+	if unit.DriverIs("sqlite3") {
+		assert.Equal(t, `{"color":"red","tags":["a","b"]}`, row["meta"], "the json should be kept")
+	}
+
+	// the metadata is read once per table
+	conn.Metadata = NewMetadata()
+	grammar.err = nil
+	grammar.calls = 0
+	for i := 0; i < 3; i++ {
+		qb.Table("table_test_decode").Select("id", "meta", "active").MustGet()
+	}
+	assert.LessOrEqual(t, grammar.calls, 1, "the metadata should be cached")
+}
+
+// columnListingGrammar the grammar counting the metadata queries
+type columnListingGrammar struct {
+	dbal.Grammar
+	calls int
+	err   error
+}
+
+func (grammar *columnListingGrammar) GetColumnListing(schemaName string, tableName string) ([]*dbal.Column, error) {
+	grammar.calls++
+	if grammar.err != nil {
+		return nil, grammar.err
+	}
+	return grammar.Grammar.GetColumnListing(schemaName, tableName)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	NewTableForDecodeTest()
+	qb := getTestBuilder()
+	cur, err := qb.Table("table_test_decode").OrderBy("id").Cursor()
+	if !assert.Nil(t, err, "the cursor should be created") {
+		return
+	}
+	defer cur.Close()
+
+	assert.True(t, cur.Next(), "the cursor should has the first row")
+	row, err := cur.Row()
+	assert.Nil(t, err, "the row should be scanned")
+	assert.Equal(t, true, row["active"], "the boolean should be decoded")
+}
+
+func TestDecodeKeepRawValues(t *testing.T) {
+	NewTableForDecodeTest()
+	qb := getTestBuilder()
+	row := qb.Table("table_test_decode").KeepRawValues().OrderBy("id").MustFirst()
+	meta, ok := row["meta"].(string)
+	if assert.True(t, ok, "the json should be kept as a string") {
+		assert.Contains(t, meta, "red", "the json should be kept as it is")
+	}
+	assert.IsType(t, "", row["data"], "the bytes should be converted to a string")
+}
+
+// clean the test data
+func TestDecodeClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_decode")
+	builder.DropTableIfExists("table_test_decode_flag")
+}
+
+func NewTableForDecodeTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_decode")
+	builder.MustCreateTable("table_test_decode", func(table schema.Blueprint) {
+		table.ID("id")
+		table.JSON("meta")
+		table.Decimal("price", 10, 2).Null()
+		table.Boolean("active")
+		table.Timestamp("paid_at").Null()
+		table.Binary("data", 16).Null()
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_decode").MustInsert([]xun.R{
+		{"meta": `{"color":"red","tags":["a","b"]}`, "price": 12.5, "active": true, "paid_at": "2021-03-25 00:21:16", "data": []byte("raw")},
+		{"meta": `[1,2]`, "price": nil, "active": false, "paid_at": nil, "data": nil},
+	})
+
+	// the table sharing the column names with the other types
+	builder.DropTableIfExists("table_test_decode_flag")
+	builder.MustCreateTable("table_test_decode_flag", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("decode_id")
+		table.Integer("active")
+		table.Text("meta")
+	})
+	qb.Table("table_test_decode_flag").MustInsert([]xun.R{
+		{"decode_id": 1, "active": 5, "meta": `{"color":"red"}`},
+		{"decode_id": 2, "active": 6, "meta": `[1]`},
+	})
+}
@@ -1,23 +1,55 @@
 package query
 
 import (
+	"fmt"
+
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/xun/utils"
 )
 
 // Delete Delete records from the database.
 func (builder *Builder) Delete() (int64, error) {
+	builder, err := builder.scoped()
+	if err != nil {
+		return 0, err
+	}
+
+	err = builder.checkDelete()
+	if err != nil {
+		return 0, err
+	}
+
 	sql, bindings := builder.Grammar.CompileDelete(builder.Query)
 	defer log.With(log.F{"bindings": bindings}).Debug(sql)
 
-	res, err := builder.UseWrite().DB().Exec(sql, bindings...)
+	builder.UseWrite()
+	builder, cancel, err := builder.withTimeout()
 	if err != nil {
 		return 0, err
 	}
+	defer cancel()
+	res, err := builder.executor().Exec(sql, bindings...)
+	if err != nil {
+		return 0, builder.translateError(err)
+	}
 
 	return res.RowsAffected()
 }
 
+// checkDelete Check if the order and the limit of the joined delete are supported by the grammar,
+// they're not dropped silently.
+func (builder *Builder) checkDelete() error {
+	if len(builder.Query.Joins) == 0 || (len(builder.Query.Orders) == 0 && builder.Query.Limit < 0) {
+		return nil
+	}
+
+	if !builder.Grammar.SupportsDeleteJoinOrderLimit() {
+		driver, _ := builder.Driver()
+		return fmt.Errorf("the joined delete with the order or the limit is not supported by %s", driver)
+	}
+	return nil
+}
+
 // MustDelete Delete records from the database.
 func (builder *Builder) MustDelete() int64 {
 	affected, err := builder.Delete()
@@ -27,12 +59,19 @@ func (builder *Builder) MustDelete() int64 {
 
 // Truncate Run a truncate statement on the table.
 func (builder *Builder) Truncate() error {
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
 	sqls, bindings := builder.Grammar.CompileTruncate(builder.Query)
 	for i, sql := range sqls {
 		defer log.With(log.F{"bindings": bindings}).Debug(sql)
-		_, err := builder.UseWrite().DB().Exec(sql, bindings[i]...)
+		builder.UseWrite()
+		_, err := builder.executor().Exec(sql, bindings[i]...)
 		if err != nil {
-			return err
+			return builder.translateError(err)
 		}
 	}
 	return nil
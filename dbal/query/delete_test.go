@@ -3,8 +3,10 @@ package query
 import (
 	"testing"
 
+	"github.com/blang/semver/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
 	"github.com/yaoapp/xun/dbal/schema"
 	"github.com/yaoapp/xun/unit"
 )
@@ -45,6 +47,67 @@ func TestDeleteMustDeleteWithJoin(t *testing.T) {
 	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
 }
 
+func TestDeleteMustDeleteWithJoinTable(t *testing.T) {
+	if unit.DriverIs("hdb") {
+		return
+	}
+
+	NewTableForDeleteTest()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_delete_banned")
+	builder.MustCreateTable("table_test_delete_banned", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_delete_banned").MustInsert([]xun.R{
+		{"email": "lee@yao.run"},
+		{"email": "ben@yao.run"},
+	})
+
+	affected := qb.Table("table_test_delete").
+		Join("table_test_delete_banned", "table_test_delete_banned.email", "=", "table_test_delete.email").
+		Where("table_test_delete.vote", "<", 6).
+		MustDelete()
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+	assert.Equal(t, int64(3), qb.Table("table_test_delete").MustCount(), "The rows count should be 3")
+	assert.Equal(t, int64(0), qb.Table("table_test_delete").Where("email", "lee@yao.run").MustCount(), "The lee@yao.run should be deleted")
+	builder.DropTableIfExists("table_test_delete_banned")
+}
+
+func TestDeleteMustDeleteWithOrderAndLimit(t *testing.T) {
+	if unit.DriverIs("hdb") {
+		return
+	}
+
+	NewTableForDeleteTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_delete").
+		Where("vote", "<", 100).
+		OrderBy("vote", "desc").
+		Limit(2).
+		MustDelete()
+	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
+
+	rows := qb.Table("table_test_delete").OrderBy("id").MustGet()
+	assert.Equal(t, 2, len(rows), "The rows count should be 2")
+	if len(rows) == 2 {
+		assert.Equal(t, "lee@yao.run", rows[0]["email"], "The lee@yao.run should be kept")
+		assert.Equal(t, "ken@yao.run", rows[1]["email"], "The ken@yao.run should be kept")
+	}
+}
+
+func TestDeleteWithJoinAndLimitError(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	_, err := qb.Table("users").
+		Join("scores", "scores.user_id", "=", "users.id").
+		OrderBy("users.id").
+		Limit(2).
+		Delete()
+	assert.EqualError(t, err, "the joined delete with the order or the limit is not supported by mysql", "the order and limit should not be dropped")
+}
+
 func TestDeleteMustTruncate(t *testing.T) {
 	NewTableForDeleteTest()
 	qb := getTestBuilder()
@@ -0,0 +1,125 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// ToSQLFor Compile the query with the grammar of the given driver, the placeholders, the identifier quoting,
+// the limit and the lock clauses are rendered for the driver. The raw expressions are kept as they are given.
+//
+//	sql, bindings := qb.Table("users").Where("vote", ">", 10).LockForUpdate().ToSQLFor("postgres")
+func (builder *Builder) ToSQLFor(driver string) (string, []interface{}) {
+	dialect := builder.dialect(driver)
+	return dialect.ToSQL(), dialect.GetBindings()
+}
+
+// Dialect Get a copy of the query compiled by the grammar of the given driver, the copy could not be executed,
+// its statements could be compiled by the ToSQL, ToInsertSQL, ToUpdateSQL, ToUpsertSQL and ToDeleteSQL methods.
+//
+//	sql, bindings := qb.Table("users").Dialect("mysql").ToUpsertSQL(rows, "email", []string{"vote"})
+func (builder *Builder) Dialect(driver string) Query {
+	return builder.dialect(driver)
+}
+
+// dialect Create an offline builder of the given driver with a copy of the query, the subqueries compiled
+// by the grammar of the connection are compiled again. The version of the connection is assumed
+// if the driver is the same one.
+func (builder *Builder) dialect(driver string) *Builder {
+	var version *dbal.Version
+	if current, err := builder.Driver(); err == nil && current == driver {
+		if v, err := builder.getVersion(); err == nil {
+			copied := *v
+			version = &copied
+		}
+	}
+
+	option := dbal.Option{}
+	if builder.Conn != nil && builder.Conn.Option != nil {
+		option = *builder.Conn.Option
+	}
+
+	new := newOfflineBuilder(driver, version, &option)
+	new.Query = translateQuery(new.Grammar, builder.mustScoped().Query)
+	return new
+}
+
+// translateQuery Copy the query and compile its subqueries by the given grammar
+func translateQuery(grammar dbal.Grammar, query *dbal.Query) *dbal.Query {
+	if query == nil {
+		return nil
+	}
+
+	new := query.Clone()
+	if new.From.Query != nil {
+		new.From.Query = translateQuery(grammar, new.From.Query)
+		new.From.SQL = fmt.Sprintf("(%s)", compileSub(grammar, new.From.Query))
+	}
+
+	for i, column := range new.Columns {
+		if sel, ok := column.(dbal.Select); ok && sel.Query != nil {
+			sel.Query = translateQuery(grammar, sel.Query)
+			sel.SQL = fmt.Sprintf("(%s)", compileSub(grammar, sel.Query))
+			new.Columns[i] = sel
+		}
+	}
+
+	for i, cte := range new.CTEs {
+		new.CTEs[i].Query = translateQuery(grammar, cte.Query)
+	}
+
+	for i, join := range new.Joins {
+		if sub, ok := join.SQL.(*dbal.Query); ok {
+			sub = translateQuery(grammar, sub)
+			new.Joins[i].Name = sub
+			new.Joins[i].SQL = sub
+		}
+		new.Joins[i].Query = translateQuery(grammar, join.Query)
+	}
+
+	new.Wheres = translateWheres(grammar, new.Wheres)
+	new.Orders = translateOrders(grammar, new.Orders)
+	new.UnionOrders = translateOrders(grammar, new.UnionOrders)
+	for i, union := range new.Unions {
+		new.Unions[i].Query = translateQuery(grammar, union.Query)
+	}
+	return new
+}
+
+// translateWheres Copy the where clauses and compile their subqueries by the given grammar
+func translateWheres(grammar dbal.Grammar, wheres []dbal.Where) []dbal.Where {
+	new := []dbal.Where{}
+	for _, where := range wheres {
+		if where.Query != nil {
+			where.Query = translateQuery(grammar, where.Query)
+			switch where.Type {
+			case "in":
+				where.ValuesIn = dbal.Raw(compileSub(grammar, where.Query))
+			case "basic":
+				where.Column = dbal.Raw(fmt.Sprintf("(%s)", compileSub(grammar, where.Query)))
+			}
+		}
+		new = append(new, where)
+	}
+	return new
+}
+
+// translateOrders Copy the orders and compile their subqueries by the given grammar
+func translateOrders(grammar dbal.Grammar, orders []dbal.Order) []dbal.Order {
+	new := []dbal.Order{}
+	for _, order := range orders {
+		if order.Query != nil {
+			order.Query = translateQuery(grammar, order.Query)
+			order.Column = dbal.Raw(fmt.Sprintf("(%s)", compileSub(grammar, order.Query)))
+		}
+		new = append(new, order)
+	}
+	return new
+}
+
+// compileSub Compile the subquery by the given grammar from its binding offset
+func compileSub(grammar dbal.Grammar, query *dbal.Query) string {
+	offset := query.BindingOffset
+	return grammar.CompileSelectOffset(query, &offset)
+}
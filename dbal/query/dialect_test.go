@@ -0,0 +1,103 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestDialectToSQLFor(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	qb.Table("users").
+		Select("id", "name").
+		Where("vote", ">", 10).
+		WhereIn("status", []string{"active", "locked"}).
+		OrderBy("id", "desc").
+		Limit(5).
+		Offset(10).
+		LockForUpdate()
+
+	sql, bindings := qb.ToSQLFor("postgres")
+	assert.Equal(t, `select "id", "name" from "users" where "vote" > $1 and "status" in ($2,$3) order by "id" desc limit 5 offset 10 for update`, sql, "the postgres sql not equal")
+	assert.Equal(t, []interface{}{10, "active", "locked"}, bindings, "the bindings not equal")
+
+	sql, _ = qb.ToSQLFor("mysql")
+	assert.Equal(t, "select `id`, `name` from `users` where `vote` > ? and `status` in (?,?) order by `id` desc limit 5 offset 10 for update", sql, "the mysql sql not equal")
+
+	sql, _ = qb.ToSQLFor("sqlite3")
+	assert.Equal(t, "select `id`, `name` from `users` where `vote` > ? and `status` in (?,?) order by `id` desc limit 5 offset 10", sql, "the sqlite3 sql not equal")
+}
+
+func TestDialectToSQLForSubqueries(t *testing.T) {
+	build := func(qb Query) {
+		qb.FromSub(func(sub Query) {
+			sub.From("users").Where("vote", ">", 10)
+		}, "u").
+			SelectSub(func(sub Query) {
+				sub.From("posts").SelectRaw("count(*)").WhereColumn("posts.user_id", "u.id").Where("posts.status", "published")
+			}, "posts").
+			WhereIn("u.id", func(sub Query) {
+				sub.From("members").Select("user_id").Where("team", "core")
+			}).
+			Where(func(sub Query) {
+				sub.From("scores").SelectRaw("max(score)").WhereColumn("scores.user_id", "u.id")
+			}, ">", 60).
+			OrderBy(func(sub Query) {
+				sub.From("logins").SelectRaw("max(created_at)").WhereColumn("logins.user_id", "u.id")
+			}, "desc")
+	}
+
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	build(qb)
+	mysql := qb.ToSQL()
+
+	// the translated query is compiled as it's built on the postgres connection
+	pg := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+	build(pg)
+	sql, bindings := qb.ToSQLFor("postgres")
+	assert.Equal(t, pg.ToSQL(), sql, "the postgres sql not equal")
+	assert.Equal(t, pg.GetBindings(), bindings, "the bindings not equal")
+	assert.NotContains(t, sql, "?", "the placeholders of the subqueries should be translated")
+	assert.NotContains(t, sql, "`", "the identifiers of the subqueries should be translated")
+
+	// the source query is not changed
+	assert.Equal(t, mysql, qb.ToSQL(), "the source query should not be changed")
+
+	qb = NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.From("users").WhereIn("id", func(sub Query) {
+		sub.From("members").Select("user_id").Where("team", "core")
+	}).Where("vote", ">", 10)
+	sql, bindings = qb.ToSQLFor("postgres")
+	assert.Equal(t, `select * from "users" where "id" in (select "user_id" from "members" where "team" = $1) and "vote" > $2`, sql, "the postgres sql not equal")
+	assert.Equal(t, []interface{}{"core", 10}, bindings, "the bindings not equal")
+}
+
+func TestDialectPrefix(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")}, dbal.Option{Prefix: "xun_"})
+	qb.Table("users").Where("id", 1)
+	sql, bindings := qb.ToSQLFor("postgres")
+	assert.Equal(t, `select * from "xun_users" where "id" = $1`, sql, "the postgres sql not equal")
+	assert.Equal(t, []interface{}{1}, bindings, "the bindings not equal")
+}
+
+func TestDialectUpsert(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	sql, _ := qb.Table("users").Dialect("mysql").ToUpsertSQL([][]interface{}{{"ada@example.com", 10}}, "email", []string{"vote"}, "email", "vote")
+	assert.Equal(t, "insert into `users` (`email`, `vote`) values (?,?) on duplicate key update `vote`=values(`vote`)", sql, "the mysql sql not equal")
+
+	sql, _ = qb.Table("users").Dialect("postgres").ToUpsertSQL([][]interface{}{{"ada@example.com", 10}}, "email", []string{"vote"}, "email", "vote")
+	assert.Equal(t, `insert into "users" ("email", "vote") values ($1,$2) on conflict ("email") do update set "vote"=excluded."vote"`, sql, "the postgres sql not equal")
+}
+
+func TestDialectConnection(t *testing.T) {
+	qb := getTestBuilder()
+	qb.Table("users").Where("vote", ">", 10).Limit(1)
+	sql, bindings := qb.ToSQLFor("postgres")
+	assert.Equal(t, `select * from "users" where "vote" > $1 limit 1`, sql, "the postgres sql not equal")
+	assert.Equal(t, []interface{}{10}, bindings, "the bindings not equal")
+
+	_, err := qb.Dialect("postgres").Get()
+	assert.ErrorIs(t, err, dbal.ErrOffline, "the dialect copy should not be executed")
+}
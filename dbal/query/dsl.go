@@ -0,0 +1,711 @@
+package query
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// dslScope the tables and the aliases the columns of a query document are validated against
+type dslScope struct {
+	tables     map[string]*dbal.Table
+	names      []string
+	aliases    map[string]bool
+	aggregates map[string]dbal.AggregateFunction
+	operators  []string
+}
+
+// FromDSL Create a new query of the connection from the query document, the document could be a DSL,
+// a map or the JSON or YAML text of it. The column names are validated against the live tables.
+//
+//	qb, err := query.FromDSL(`{"version": "1.0", "from": "users", "wheres": [{"column": "vote", "op": ">", "value": 10}]}`, conn)
+func FromDSL(doc interface{}, conn *Connection) (Query, error) {
+	return useBuilder(conn).LoadDSL(doc)
+}
+
+// LoadDSL Replace the query with the query document, the document could be a DSL, a map or the JSON or YAML text of it.
+// The column names are validated against the live tables, the query is not changed if the document is invalid.
+//
+//	qb.LoadDSL("version: '1.0'\nfrom: users\nselect: [id, name]\norders: [{column: id, direction: desc}]")
+func (builder *Builder) LoadDSL(doc interface{}) (Query, error) {
+	dsl, err := parseDSL(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if dsl.Version == "" {
+		return nil, fmt.Errorf("the version of the DSL is required")
+	}
+
+	if dsl.Version != DSLVersion {
+		return nil, fmt.Errorf("the version %s of the DSL is not supported, the supported version is %s", dsl.Version, DSLVersion)
+	}
+
+	scope, err := builder.dslScope(dsl)
+	if err != nil {
+		return nil, err
+	}
+
+	err = scope.validate(dsl)
+	if err != nil {
+		return nil, err
+	}
+
+	new := builder.new()
+	err = new.loadDSL(dsl, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Query = new.Query
+	return builder, nil
+}
+
+// ToDSL Serialise the query to the query document, the raw expressions, the subqueries, the unions,
+// the common table expressions, the window functions and the locks could not be serialised.
+func (builder *Builder) ToDSL() (*DSL, error) {
+	query := builder.Query
+	dsl := &DSL{Version: DSLVersion}
+
+	if len(query.CTEs) > 0 || len(query.Unions) > 0 || len(query.Windows) > 0 || query.Lock != nil {
+		return nil, fmt.Errorf("the common table expressions, the unions, the windows and the locks could not be serialised to the DSL")
+	}
+
+	from, ok := query.From.Name.(dbal.Name)
+	if query.From.Type != "basic" || !ok {
+		return nil, fmt.Errorf("the from clause could not be serialised to the DSL, it should be a table")
+	}
+	dsl.From = dslName(from)
+
+	for _, column := range query.Columns {
+		switch col := column.(type) {
+		case string:
+			dsl.Select = append(dsl.Select, col)
+		case dbal.Name:
+			dsl.Select = append(dsl.Select, dslName(col))
+		case dbal.AggregateFunction:
+			name, ok := col.Column.(string)
+			if !ok {
+				return nil, fmt.Errorf("the column of the %s function could not be serialised to the DSL", col.Func)
+			}
+			dsl.Aggregates = append(dsl.Aggregates, DSLAggregate{Func: col.Func, Column: name, Alias: col.Alias, Distinct: col.Distinct})
+		case dbal.Expression:
+			if col.GetValue() != "*" {
+				return nil, fmt.Errorf("the raw column %s could not be serialised to the DSL", col.GetValue())
+			}
+		default:
+			return nil, fmt.Errorf("the column %v could not be serialised to the DSL", column)
+		}
+	}
+
+	if len(query.DistinctColumns) > 0 {
+		return nil, fmt.Errorf("the distinct columns could not be serialised to the DSL")
+	}
+	dsl.Distinct = query.Distinct
+
+	for _, join := range query.Joins {
+		item, err := dslJoin(join)
+		if err != nil {
+			return nil, err
+		}
+		dsl.Joins = append(dsl.Joins, item)
+	}
+
+	wheres, err := dslWheres(query.Wheres)
+	if err != nil {
+		return nil, err
+	}
+	dsl.Wheres = wheres
+
+	for _, group := range query.Groups {
+		name, ok := group.(string)
+		if !ok {
+			return nil, fmt.Errorf("the group %v could not be serialised to the DSL", group)
+		}
+		dsl.Groups = append(dsl.Groups, name)
+	}
+
+	for _, having := range query.Havings {
+		item, err := dslHaving(having, dsl.Aggregates)
+		if err != nil {
+			return nil, err
+		}
+		dsl.Havings = append(dsl.Havings, item)
+	}
+
+	for _, order := range query.Orders {
+		name, ok := order.Column.(string)
+		if order.Type != "basic" || order.Query != nil || !ok {
+			return nil, fmt.Errorf("the order %v could not be serialised to the DSL", order.Column)
+		}
+		dsl.Orders = append(dsl.Orders, DSLOrder{Column: name, Direction: order.Direction})
+	}
+
+	if query.Limit > 0 {
+		dsl.Limit = query.Limit
+	}
+
+	if query.Offset > 0 {
+		dsl.Offset = query.Offset
+	}
+
+	return dsl, nil
+}
+
+// parseDSL Parse the query document
+func parseDSL(doc interface{}) (*DSL, error) {
+	switch value := doc.(type) {
+	case DSL:
+		return &value, nil
+	case *DSL:
+		if value == nil {
+			return nil, fmt.Errorf("the DSL document is nil")
+		}
+		dsl := *value
+		return &dsl, nil
+	case []byte:
+		return unmarshalDSL(value)
+	case string:
+		return unmarshalDSL([]byte(value))
+	case map[string]interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return unmarshalDSL(data)
+	}
+	return nil, fmt.Errorf("the DSL document should be a DSL, a map, a JSON or a YAML text, %T given", doc)
+}
+
+// unmarshalDSL Parse the JSON or YAML text of the query document
+func unmarshalDSL(data []byte) (*DSL, error) {
+	dsl := DSL{}
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '{' {
+		err := json.Unmarshal(data, &dsl)
+		if err != nil {
+			return nil, fmt.Errorf("the DSL document is not a valid JSON. (%s)", err)
+		}
+		return &dsl, nil
+	}
+
+	err := yaml.Unmarshal(data, &dsl)
+	if err != nil {
+		return nil, fmt.Errorf("the DSL document is not a valid YAML. (%s)", err)
+	}
+	return &dsl, nil
+}
+
+// dslScope Get the live tables of the query document, the joined tables are not prefixed as the Join method does.
+func (builder *Builder) dslScope(dsl *DSL) (*dslScope, error) {
+	scope := &dslScope{
+		tables:     map[string]*dbal.Table{},
+		names:      []string{},
+		aliases:    map[string]bool{},
+		aggregates: map[string]dbal.AggregateFunction{},
+		operators:  builder.Grammar.GetOperators(),
+	}
+
+	if dsl.From == "" {
+		return nil, fmt.Errorf("the from of the DSL is required")
+	}
+
+	prefix := ""
+	if builder.Conn.Option != nil {
+		prefix = builder.Conn.Option.Prefix
+	}
+
+	err := scope.addTable(builder, dbal.NewName(dsl.From, prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, join := range dsl.Joins {
+		if join.Table == "" {
+			return nil, fmt.Errorf("the table of the join is required")
+		}
+		err := scope.addTable(builder, dbal.NewName(join.Table))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, column := range dsl.Select {
+		if name := dbal.NewName(column); name.Alias != "" {
+			scope.aliases[name.Alias] = true
+		}
+	}
+
+	for _, aggregate := range dsl.Aggregates {
+		if aggregate.Alias == "" {
+			continue
+		}
+		scope.aliases[aggregate.Alias] = true
+		scope.aggregates[aggregate.Alias] = dbal.AggregateFunction{
+			Func:     strings.ToLower(aggregate.Func),
+			Column:   aggregate.Column,
+			Distinct: aggregate.Distinct,
+		}
+	}
+
+	return scope, nil
+}
+
+// addTable Add the live table to the scope, it's referred by the alias if it's given.
+func (scope *dslScope) addTable(builder *Builder, name dbal.Name) error {
+	has, err := builder.Grammar.TableExists(name.Fullname())
+	if err != nil {
+		return err
+	}
+
+	if !has {
+		return fmt.Errorf("the table %s does not exist", name.Fullname())
+	}
+
+	table, err := builder.Grammar.GetTable(name.Fullname())
+	if err != nil {
+		return err
+	}
+
+	key := name.Name
+	if name.Alias != "" {
+		key = name.Alias
+	}
+
+	if _, has := scope.tables[key]; has {
+		return fmt.Errorf("the table %s is used more than once, give it an alias", key)
+	}
+
+	scope.tables[key] = table
+	scope.names = append(scope.names, key)
+	return nil
+}
+
+// validate Validate the columns, the operators and the values of the query document
+func (scope *dslScope) validate(dsl *DSL) error {
+	for _, column := range dsl.Select {
+		if err := scope.column(column, false); err != nil {
+			return err
+		}
+	}
+
+	for _, aggregate := range dsl.Aggregates {
+		if !utils.StringHave(aggregateFunctions, strings.ToLower(aggregate.Func)) {
+			return fmt.Errorf("the aggregate function %q is not supported", aggregate.Func)
+		}
+		if err := scope.column(aggregate.Column, false); err != nil {
+			return err
+		}
+	}
+
+	for _, join := range dsl.Joins {
+		typ := strings.ToLower(join.Type)
+		if typ != "" && !utils.StringHave([]string{"inner", "left", "right", "cross"}, typ) {
+			return fmt.Errorf("the join type %q is not supported", join.Type)
+		}
+		if typ == "cross" {
+			continue
+		}
+		if join.First == "" || join.Second == "" {
+			return fmt.Errorf("the first and the second columns of the join %s are required", join.Table)
+		}
+		if join.Op != "" && !utils.StringHave(scope.operators, strings.ToLower(join.Op)) {
+			return fmt.Errorf("the operator %q is not supported", join.Op)
+		}
+		for _, column := range []string{join.First, join.Second} {
+			if err := scope.column(column, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := scope.wheres(dsl.Wheres, false); err != nil {
+		return err
+	}
+
+	for _, column := range dsl.Groups {
+		if err := scope.column(column, false); err != nil {
+			return err
+		}
+	}
+
+	if err := scope.wheres(dsl.Havings, true); err != nil {
+		return err
+	}
+
+	for _, order := range dsl.Orders {
+		if order.Direction != "" && !utils.StringHave([]string{"asc", "desc"}, strings.ToLower(order.Direction)) {
+			return fmt.Errorf("the order direction %q is not supported", order.Direction)
+		}
+		if err := scope.column(order.Column, true); err != nil {
+			return err
+		}
+	}
+
+	if dsl.Limit < 0 || dsl.Offset < 0 {
+		return fmt.Errorf("the limit and the offset should not be negative")
+	}
+
+	if dsl.Paginate != nil && (dsl.Paginate.Page < 1 || dsl.Paginate.PageSize < 1) {
+		return fmt.Errorf("the page and the page size of the paginate should be greater than 0")
+	}
+
+	return nil
+}
+
+// wheres Validate the where or having conditions of the query document
+func (scope *dslScope) wheres(wheres []DSLWhere, having bool) error {
+	for _, where := range wheres {
+		if len(where.Wheres) > 0 {
+			if having {
+				return fmt.Errorf("the having conditions could not be nested")
+			}
+			if where.Column != "" {
+				return fmt.Errorf("the nested where should not have the column %s", where.Column)
+			}
+			if err := scope.wheres(where.Wheres, having); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if where.Column == "" {
+			return fmt.Errorf("the column of the where is required")
+		}
+
+		if err := scope.column(where.Column, having); err != nil {
+			return err
+		}
+
+		op := dslOperator(where.Op)
+		switch op {
+		case "in", "not in":
+			if having || !isSlice(where.Value) {
+				return fmt.Errorf("the value of the %s %s condition should be an array", where.Column, op)
+			}
+		case "between", "not between":
+			if !isSlice(where.Value) || reflect.ValueOf(where.Value).Len() != 2 {
+				return fmt.Errorf("the value of the %s %s condition should be an array of two values", where.Column, op)
+			}
+		case "null", "not null":
+			if having {
+				return fmt.Errorf("the %s operator is not supported by the having conditions", op)
+			}
+		default:
+			if !utils.StringHave(scope.operators, op) {
+				return fmt.Errorf("the operator %q is not supported", where.Op)
+			}
+			if isSlice(where.Value) {
+				return fmt.Errorf("the value of the %s %s condition should not be an array", where.Column, op)
+			}
+		}
+	}
+	return nil
+}
+
+// column Validate the column name against the live tables, the aliases of the selected columns
+// are accepted if the aliases is true.
+//
+//	"id", "u.id", "u.*", "*", "options->color", "name as n"
+func (scope *dslScope) column(column string, aliases bool) error {
+	name := dbal.NewName(column).Name
+	if idx := strings.Index(name, "->"); idx > 0 {
+		name = name[:idx]
+	}
+
+	if name == "*" || (aliases && scope.aliases[name]) {
+		return nil
+	}
+
+	if idx := strings.LastIndex(name, "."); idx > 0 {
+		key, field := name[:idx], name[idx+1:]
+		table, has := scope.tables[key]
+		if !has {
+			return fmt.Errorf("the table %s of the column %s is not in the query", key, column)
+		}
+		if _, has := table.ColumnMap[field]; !has && field != "*" {
+			return fmt.Errorf("the column %s does not exist in the table %s", field, key)
+		}
+		return nil
+	}
+
+	for _, key := range scope.names {
+		if _, has := scope.tables[key].ColumnMap[name]; has {
+			return nil
+		}
+	}
+	return fmt.Errorf("the column %s does not exist in the table %s", name, strings.Join(scope.names, ", "))
+}
+
+// loadDSL Add the clauses of the validated query document to the query
+func (builder *Builder) loadDSL(dsl *DSL, scope *dslScope) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	builder.From(dsl.From)
+
+	if len(dsl.Select) > 0 {
+		columns := []interface{}{}
+		for _, column := range dsl.Select {
+			columns = append(columns, column)
+		}
+		builder.Select(columns...)
+	}
+
+	for _, aggregate := range dsl.Aggregates {
+		builder.SelectAggregate(aggregate.Func, aggregate.Column, aggregate.Alias, aggregate.Distinct)
+	}
+
+	if dsl.Distinct {
+		builder.Distinct()
+	}
+
+	for _, join := range dsl.Joins {
+		op := dslOperator(join.Op)
+		switch strings.ToLower(join.Type) {
+		case "left":
+			builder.LeftJoin(join.Table, join.First, op, join.Second)
+		case "right":
+			builder.RightJoin(join.Table, join.First, op, join.Second)
+		case "cross":
+			builder.CrossJoin(join.Table)
+		default:
+			builder.Join(join.Table, join.First, op, join.Second)
+		}
+	}
+
+	builder.loadDSLWheres(dsl.Wheres)
+
+	for _, group := range dsl.Groups {
+		builder.GroupBy(group)
+	}
+
+	for _, having := range dsl.Havings {
+		boolean := "and"
+		if having.Or {
+			boolean = "or"
+		}
+
+		var column interface{} = having.Column
+		if aggregate, has := scope.aggregates[having.Column]; has {
+			column = aggregate
+		}
+
+		switch op := dslOperator(having.Op); op {
+		case "between", "not between":
+			builder.HavingBetween(column, having.Value, boolean, op == "not between")
+		default:
+			builder.Having(column, op, having.Value, boolean)
+		}
+	}
+
+	for _, order := range dsl.Orders {
+		direction := "asc"
+		if order.Direction != "" {
+			direction = order.Direction
+		}
+		builder.OrderBy(order.Column, direction)
+	}
+
+	if dsl.Limit > 0 {
+		builder.Limit(dsl.Limit)
+	}
+
+	if dsl.Offset > 0 {
+		builder.Offset(dsl.Offset)
+	}
+
+	if dsl.Paginate != nil {
+		builder.Limit(dsl.Paginate.PageSize)
+		builder.Offset((dsl.Paginate.Page - 1) * dsl.Paginate.PageSize)
+	}
+
+	return nil
+}
+
+// loadDSLWheres Add the where conditions of the query document to the query
+func (builder *Builder) loadDSLWheres(wheres []DSLWhere) {
+	for _, where := range wheres {
+		boolean := "and"
+		if where.Or {
+			boolean = "or"
+		}
+
+		if len(where.Wheres) > 0 {
+			nested := where.Wheres
+			builder.whereNested(func(qb Query) {
+				qb.Builder().loadDSLWheres(nested)
+			}, boolean)
+			continue
+		}
+
+		switch op := dslOperator(where.Op); op {
+		case "in", "not in":
+			builder.whereIn(where.Column, where.Value, boolean, op == "not in")
+		case "between", "not between":
+			builder.whereBetween(where.Column, where.Value, boolean, op == "not between")
+		case "null", "not null":
+			builder.WhereNull(where.Column, boolean, op == "not null")
+		default:
+			builder.Where(where.Column, op, where.Value, boolean)
+		}
+	}
+}
+
+// dslOperator Get the lower case operator, the default operator is "="
+func dslOperator(op string) string {
+	op = strings.ToLower(strings.TrimSpace(op))
+	if op == "" {
+		return "="
+	}
+	return op
+}
+
+// dslName Get the name of the query document, the prefix is not included.
+func dslName(name dbal.Name) string {
+	if name.Alias != "" {
+		return fmt.Sprintf("%s as %s", name.Name, name.Alias)
+	}
+	return name.Name
+}
+
+// dslJoin Serialise the join clause, only one column comparison is supported by the query document.
+func dslJoin(join dbal.Join) (DSLJoin, error) {
+	name, ok := join.Name.(dbal.Name)
+	if !ok || !utils.StringHave([]string{"inner", "left", "right", "cross"}, join.Type) {
+		return DSLJoin{}, fmt.Errorf("the join could not be serialised to the DSL, it should join a table")
+	}
+
+	item := DSLJoin{Type: join.Type, Table: dslName(name)}
+	if join.Type == "cross" {
+		return item, nil
+	}
+
+	if join.Query == nil || len(join.Query.Wheres) != 1 || join.Query.Wheres[0].Type != "column" {
+		return DSLJoin{}, fmt.Errorf("the join %s could not be serialised to the DSL, it should compare two columns", item.Table)
+	}
+
+	on := join.Query.Wheres[0]
+	first, ok := on.First.(string)
+	second, ok2 := on.Second.(string)
+	if !ok || !ok2 {
+		return DSLJoin{}, fmt.Errorf("the join %s could not be serialised to the DSL, it should compare two columns", item.Table)
+	}
+
+	item.First = first
+	item.Op = on.Operator
+	item.Second = second
+	return item, nil
+}
+
+// dslWheres Serialise the where clauses
+func dslWheres(wheres []dbal.Where) ([]DSLWhere, error) {
+	items := []DSLWhere{}
+	for _, where := range wheres {
+		item := DSLWhere{Or: where.Boolean == "or"}
+
+		if where.Type == "nested" {
+			nested, err := dslWheres(where.Query.Wheres)
+			if err != nil {
+				return nil, err
+			}
+			item.Wheres = nested
+			items = append(items, item)
+			continue
+		}
+
+		column, ok := where.Column.(string)
+		if !ok || where.Query != nil {
+			return nil, fmt.Errorf("the %s where clause could not be serialised to the DSL", where.Type)
+		}
+		item.Column = column
+
+		switch where.Type {
+		case "basic":
+			if dbal.IsExpression(where.Value) {
+				return nil, fmt.Errorf("the raw value of the %s where clause could not be serialised to the DSL", column)
+			}
+			item.Op = where.Operator
+			item.Value = where.Value
+		case "in":
+			if dbal.IsExpression(where.ValuesIn) {
+				return nil, fmt.Errorf("the raw values of the %s where clause could not be serialised to the DSL", column)
+			}
+			item.Op = "in"
+			if where.Not {
+				item.Op = "not in"
+			}
+			item.Value = where.ValuesIn
+		case "between":
+			item.Op = "between"
+			if where.Not {
+				item.Op = "not between"
+			}
+			item.Value = where.Values
+		case "null":
+			item.Op = "null"
+		case "notnull":
+			item.Op = "not null"
+		default:
+			return nil, fmt.Errorf("the %s where clause could not be serialised to the DSL", where.Type)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// dslHaving Serialise the having clause, the aggregate function should be selected with an alias.
+func dslHaving(having dbal.Having, aggregates []DSLAggregate) (DSLWhere, error) {
+	item := DSLWhere{Or: having.Boolean == "or"}
+	switch column := having.Column.(type) {
+	case string:
+		item.Column = column
+	case dbal.AggregateFunction:
+		for _, aggregate := range aggregates {
+			if aggregate.Func == column.Func && aggregate.Column == column.Column && aggregate.Distinct == column.Distinct && aggregate.Alias != "" {
+				item.Column = aggregate.Alias
+				break
+			}
+		}
+	}
+
+	if item.Column == "" {
+		return DSLWhere{}, fmt.Errorf("the having clause %v could not be serialised to the DSL", having.Column)
+	}
+
+	switch having.Type {
+	case "basic":
+		if dbal.IsExpression(having.Value) {
+			return DSLWhere{}, fmt.Errorf("the raw value of the %s having clause could not be serialised to the DSL", item.Column)
+		}
+		item.Op = having.Operator
+		item.Value = having.Value
+	case "between":
+		item.Op = "between"
+		if having.Not {
+			item.Op = "not between"
+		}
+		item.Value = having.Values
+	default:
+		return DSLWhere{}, fmt.Errorf("the %s having clause could not be serialised to the DSL", having.Type)
+	}
+	return item, nil
+}
+
+// isSlice Determine if the value is an array or a slice
+func isSlice(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	kind := reflect.TypeOf(value).Kind()
+	return kind == reflect.Array || kind == reflect.Slice
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestDSLLoadJSON(t *testing.T) {
+	NewTableForDSLTest()
+	qb := getTestBuilder()
+	_, err := qb.LoadDSL(`{
+		"version": "1.0",
+		"from": "table_test_dsl_users as u",
+		"select": ["u.id", "u.name"],
+		"wheres": [
+			{"column": "u.vote", "op": ">", "value": 5},
+			{"wheres": [
+				{"column": "u.status", "op": "in", "value": ["DONE", "PENDING"]},
+				{"column": "u.email", "op": "like", "value": "%@yaojs.org", "or": true}
+			]}
+		],
+		"orders": [{"column": "u.id", "direction": "desc"}],
+		"limit": 2
+	}`)
+	if !assert.Nil(t, err, "the DSL should be loaded") {
+		return
+	}
+
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "u"."id", "u"."name" from "table_test_dsl_users" as "u" where "u"."vote" > $1 and ("u"."status" in ($2,$3) or "u"."email" like $4) order by "u"."id" desc limit 2`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `u`.`id`, `u`.`name` from `table_test_dsl_users` as `u` where `u`.`vote` > ? and (`u`.`status` in (?,?) or `u`.`email` like ?) order by `u`.`id` desc limit 2", sql, "the query sql not equal")
+	}
+
+	rows := qb.MustGet()
+	if assert.Equal(t, 2, len(rows), "the return value should has 2 rows") {
+		assert.Equal(t, "Ken", rows[0]["name"], "the name of the first row should be Ken")
+		assert.Equal(t, "Lee", rows[1]["name"], "the name of the second row should be Lee")
+	}
+}
+
+func TestDSLLoadYAML(t *testing.T) {
+	NewTableForDSLTest()
+	conn := getTestBuilder().Builder().Conn
+	qb, err := FromDSL(`
+version: "1.0"
+from: table_test_dsl_users as u
+select: [u.status]
+joins:
+  - type: left
+    table: table_test_dsl_posts as p
+    first: p.user_id
+    second: u.id
+aggregates:
+  - {func: count, column: p.id, alias: posts}
+wheres:
+  - {column: u.deleted_at, op: "null"}
+groups: [u.status]
+havings:
+  - {column: posts, op: ">=", value: 2}
+orders:
+  - {column: posts, direction: desc}
+paginate: {page: 1, page_size: 10}
+`, conn)
+	if !assert.Nil(t, err, "the DSL should be loaded") {
+		return
+	}
+
+	rows := qb.MustGet()
+	if assert.Equal(t, 1, len(rows), "the return value should has 1 row") {
+		assert.Equal(t, "DONE", rows[0]["status"], "the status should be DONE")
+		assert.Equal(t, 3, rows[0].GetInt("posts"), "the posts should be 3")
+	}
+}
+
+func TestDSLValidate(t *testing.T) {
+	NewTableForDSLTest()
+	qb := getTestBuilder().Table("table_test_dsl_users").Where("id", 1)
+	sql := qb.ToSQL()
+
+	tests := map[string]string{
+		`{"from": "table_test_dsl_users"}`:                                                                                       "the version of the DSL is required",
+		`{"version": "2.0", "from": "table_test_dsl_users"}`:                                                                     "the version 2.0 of the DSL is not supported, the supported version is 1.0",
+		`{"version": "1.0", "from": "table_test_dsl_nothing"}`:                                                                   "the table table_test_dsl_nothing does not exist",
+		`{"version": "1.0", "from": "table_test_dsl_users", "select": ["password"]}`:                                             "the column password does not exist in the table table_test_dsl_users",
+		`{"version": "1.0", "from": "table_test_dsl_users", "select": ["p.id"]}`:                                                 "the table p of the column p.id is not in the query",
+		`{"version": "1.0", "from": "table_test_dsl_users", "orders": [{"column": "total"}]}`:                                    "the column total does not exist in the table table_test_dsl_users",
+		`{"version": "1.0", "from": "table_test_dsl_users", "wheres": [{"column": "id", "op": "~~", "value": 1}]}`:               `the operator "~~" is not supported`,
+		`{"version": "1.0", "from": "table_test_dsl_users", "wheres": [{"column": "id", "op": "in", "value": 1}]}`:               "the value of the id in condition should be an array",
+		`{"version": "1.0", "from": "table_test_dsl_users", "wheres": [{"column": "id", "op": "between", "value": [1]}]}`:        "the value of the id between condition should be an array of two values",
+		`{"version": "1.0", "from": "table_test_dsl_users", "aggregates": [{"func": "median", "column": "vote", "alias": "m"}]}`: `the aggregate function "median" is not supported`,
+	}
+
+	for doc, message := range tests {
+		_, err := qb.LoadDSL(doc)
+		assert.EqualError(t, err, message, "the DSL should be invalid")
+	}
+	assert.Equal(t, sql, qb.ToSQL(), "the query should not be changed if the DSL is invalid")
+}
+
+func TestDSLToDSL(t *testing.T) {
+	NewTableForDSLTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_dsl_users as u").
+		Select("u.status").
+		SelectAggregate("count", "p.id", "posts").
+		LeftJoin("table_test_dsl_posts as p", "p.user_id", "=", "u.id").
+		Where("u.vote", ">", 5).
+		Where(func(qb Query) {
+			qb.WhereIn("u.status", []string{"DONE", "PENDING"}).OrWhereNull("u.deleted_at")
+		}).
+		WhereBetween("u.id", []int{1, 10}).
+		GroupBy("u.status").
+		Having(dbal.AggregateFunction{Func: "count", Column: "p.id"}, ">", 1).
+		OrderBy("posts", "desc").
+		Limit(10).
+		Offset(20)
+
+	dsl, err := qb.ToDSL()
+	if !assert.Nil(t, err, "the query should be serialised") {
+		return
+	}
+
+	assert.Equal(t, DSLVersion, dsl.Version, "the version should be the current one")
+	assert.Equal(t, "table_test_dsl_users as u", dsl.From, "the from should be the table")
+	assert.Equal(t, []DSLAggregate{{Func: "count", Column: "p.id", Alias: "posts"}}, dsl.Aggregates, "the aggregates should be serialised")
+	assert.Equal(t, []DSLJoin{{Type: "left", Table: "table_test_dsl_posts as p", First: "p.user_id", Op: "=", Second: "u.id"}}, dsl.Joins, "the joins should be serialised")
+	if assert.Equal(t, 3, len(dsl.Wheres), "the wheres should be serialised") {
+		assert.Equal(t, 2, len(dsl.Wheres[1].Wheres), "the nested wheres should be serialised")
+		assert.Equal(t, "null", dsl.Wheres[1].Wheres[1].Op, "the where null should be serialised")
+		assert.True(t, dsl.Wheres[1].Wheres[1].Or, "the or where should be serialised")
+	}
+
+	// the serialised document builds the same query
+	new := getTestBuilder().New()
+	_, err = new.LoadDSL(dsl)
+	if assert.Nil(t, err, "the serialised DSL should be loaded") {
+		assert.Equal(t, qb.ToSQL(), new.ToSQL(), "the serialised DSL should build the same query")
+		assert.Equal(t, qb.GetBindings(), new.GetBindings(), "the serialised DSL should build the same bindings")
+	}
+
+	_, err = getTestBuilder().New().Table("table_test_dsl_users").WhereRaw("id = ?", 1).ToDSL()
+	assert.EqualError(t, err, "the raw where clause could not be serialised to the DSL", "the raw where should not be serialised")
+}
+
+// clean the test data
+func TestDSLClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_dsl_users")
+	builder.DropTableIfExists("table_test_dsl_posts")
+}
+
+func NewTableForDSLTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_dsl_users")
+	builder.MustCreateTable("table_test_dsl_users", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.String("name")
+		table.Integer("vote")
+		table.Enum("status", []string{"WAITING", "PENDING", "DONE"}).SetDefault("WAITING")
+		table.SoftDeletes()
+	})
+
+	builder.DropTableIfExists("table_test_dsl_posts")
+	builder.MustCreateTable("table_test_dsl_posts", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("user_id")
+		table.String("title")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_dsl_users").MustInsert([]xun.R{
+		{"email": "john@yao.run", "name": "John", "vote": 10, "status": "WAITING"},
+		{"email": "lee@yao.run", "name": "Lee", "vote": 6, "status": "PENDING"},
+		{"email": "ken@yao.run", "name": "Ken", "vote": 125, "status": "DONE"},
+		{"email": "ben@yao.run", "name": "Ben", "vote": 3, "status": "DONE"},
+	})
+	qb.Table("table_test_dsl_posts").MustInsert([]xun.R{
+		{"user_id": 3, "title": "Xun"},
+		{"user_id": 3, "title": "Kun"},
+		{"user_id": 4, "title": "Yao"},
+		{"user_id": 1, "title": "Gou"},
+	})
+}
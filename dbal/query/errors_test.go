@@ -0,0 +1,77 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestErrorsUniqueViolation(t *testing.T) {
+	NewTableForErrorsTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_errors").MustInsert(xun.R{"email": "max@yao.run", "name": "Max"})
+	err := qb.Table("table_test_errors").Insert(xun.R{"email": "max@yao.run", "name": "Max"})
+	assert.True(t, errors.Is(err, dbal.ErrUniqueViolation), "the error should be a unique violation")
+	assert.False(t, errors.Is(err, dbal.ErrNotNullViolation), "the error should not be a not null violation")
+
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr), "the error should be a *dbal.Error") {
+		assert.NotNil(t, errors.Unwrap(dbErr), "the driver error should be wrapped")
+		if unit.DriverIs("sqlite3") || unit.DriverIs("postgres") {
+			assert.Equal(t, "email", dbErr.Column, "the column should be email")
+		}
+	}
+}
+
+func TestErrorsNotNullViolation(t *testing.T) {
+	NewTableForErrorsTest()
+	qb := getTestBuilder()
+	err := qb.Table("table_test_errors").Insert(xun.R{"email": "max@yao.run", "name": nil})
+	assert.True(t, errors.Is(err, dbal.ErrNotNullViolation), "the error should be a not null violation")
+
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr), "the error should be a *dbal.Error") {
+		assert.Equal(t, "name", dbErr.Column, "the column should be name")
+	}
+}
+
+func TestErrorsQueryCanceled(t *testing.T) {
+	NewTableForErrorsTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	qb := getTestBuilder()
+	_, err := qb.WithContext(ctx).Table("table_test_errors").Get()
+	assert.True(t, errors.Is(err, dbal.ErrQueryCanceled), "the error should be a canceled query")
+	assert.True(t, errors.Is(err, context.Canceled), "the context error should be wrapped")
+}
+
+func TestErrorsTranslateError(t *testing.T) {
+	qb := getTestBuilder()
+	assert.Nil(t, qb.Builder().Grammar.TranslateError(nil), "the nil error should be nil")
+
+	other := errors.New("unknown")
+	assert.Equal(t, other, qb.Builder().Grammar.TranslateError(other), "the unknown error should be returned as it is")
+}
+
+// clean the test data
+func TestErrorsClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_errors")
+}
+
+func NewTableForErrorsTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_errors")
+	builder.MustCreateTable("table_test_errors", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email").Unique()
+		table.String("name")
+	})
+}
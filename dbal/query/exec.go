@@ -4,20 +4,40 @@ import "database/sql"
 
 // Exec Use the current connection to execute the sql, return the result
 func (builder *Builder) Exec(sql string, bindings ...interface{}) (sql.Result, error) {
-	stmt, err := builder.DB().Prepare(sql)
+	builder, cancel, err := builder.withTimeout()
 	if err != nil {
 		return nil, err
 	}
+	defer cancel()
+	stmt, err := builder.executor().Prepare(sql)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
 	defer stmt.Close()
-	return stmt.Exec(bindings...)
+
+	res, err := stmt.ExecContext(builder.Context(), bindings...)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
+	return res, nil
 }
 
 // ExecWrite Use the write connection to execute the sql, return the result
 func (builder *Builder) ExecWrite(sql string, bindings ...interface{}) (sql.Result, error) {
-	stmt, err := builder.DB(true).Prepare(sql)
+	builder, cancel, err := builder.withTimeout()
 	if err != nil {
 		return nil, err
 	}
+	defer cancel()
+	stmt, err := builder.executor(true).Prepare(sql)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
 	defer stmt.Close()
-	return stmt.Exec(bindings...)
+
+	res, err := stmt.ExecContext(builder.Context(), bindings...)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
+	return res, nil
 }
@@ -9,6 +9,9 @@ import (
 // From set the table which the query is targeting.
 func (builder *Builder) From(from string) Query {
 	name := dbal.NewName(from, builder.Conn.Option.Prefix)
+	if builder.isCTE(name.Name) {
+		name.Prefix = ""
+	}
 	builder.Query.From = dbal.From{
 		Type:   "basic",
 		Alias:  name.Alias,
@@ -38,6 +41,7 @@ func (builder *Builder) FromSub(qb interface{}, as string) Query {
 		Alias:  as,
 		SQL:    fmt.Sprintf("(%s)", segment),
 		Offset: fromOffset,
+		Query:  subQuery(sub),
 	}
 	builder.Query.AddBinding("from", bindings)
 	return builder
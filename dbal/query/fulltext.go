@@ -0,0 +1,117 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// WhereFullText Add a "where full-text" clause to the query.
+// The columns could be a column name or a slice of column names, they should be covered by a full-text index.
+// It compiles to MATCH() AGAINST() on MySQL and to_tsvector @@ plainto_tsquery on PostgreSQL,
+// the boolean mode uses the websearch_to_tsquery on PostgreSQL.
+//
+//	WhereFullText([]string{"title", "content"}, "database")
+//	WhereFullText("content", "+mysql -oracle", dbal.FullTextOptions{Mode: "boolean"})
+//	WhereFullText("content", "bases de données", dbal.FullTextOptions{Language: "french"})
+func (builder *Builder) WhereFullText(columns interface{}, term string, options ...dbal.FullTextOptions) Query {
+	return builder.whereFullText(columns, term, "and", options...)
+}
+
+// OrWhereFullText Add an "or where full-text" clause to the query.
+func (builder *Builder) OrWhereFullText(columns interface{}, term string, options ...dbal.FullTextOptions) Query {
+	return builder.whereFullText(columns, term, "or", options...)
+}
+
+// SelectFullTextRank Add the full-text relevance of the given term as a column to the query.
+//
+//	SelectFullTextRank([]string{"title", "content"}, "database", "score")
+func (builder *Builder) SelectFullTextRank(columns interface{}, term string, alias string, options ...dbal.FullTextOptions) Query {
+	fulltext := builder.prepareFullText(columns, term, options...)
+	fulltext.Alias = alias
+	builder.Query.AddColumn(fulltext)
+	builder.Query.AddBinding("select", term)
+	return builder
+}
+
+// OrderByRelevance Add an "order by" clause of the full-text relevance to the query, the most relevant rows come first.
+//
+//	WhereFullText("content", "database").OrderByRelevance("content", "database")
+func (builder *Builder) OrderByRelevance(columns interface{}, term string, options ...dbal.FullTextOptions) Query {
+	fulltext := builder.prepareFullText(columns, term, options...)
+	order := dbal.Order{
+		Type:      "basic",
+		Column:    fulltext,
+		Direction: "desc",
+	}
+
+	if len(builder.Query.Unions) > 0 {
+		builder.Query.UnionOrders = append(builder.Query.UnionOrders, order)
+		builder.Query.AddBinding("unionOrder", term)
+	} else {
+		builder.Query.Orders = append(builder.Query.Orders, order)
+		builder.Query.AddBinding("order", term)
+	}
+	return builder
+}
+
+// whereFullText Add a "where full-text" clause to the query.
+func (builder *Builder) whereFullText(columns interface{}, term string, boolean string, options ...dbal.FullTextOptions) Query {
+	fulltext := builder.prepareFullText(columns, term, options...)
+	builder.Query.Wheres = append(builder.Query.Wheres, dbal.Where{
+		Type:     "fullText",
+		Boolean:  boolean,
+		FullText: &fulltext,
+		Offset:   1,
+	})
+	builder.Query.AddBinding("where", term)
+	return builder
+}
+
+// prepareFullText Cast the given columns, term and options to a full-text search
+func (builder *Builder) prepareFullText(columns interface{}, term string, options ...dbal.FullTextOptions) dbal.FullText {
+	builder.checkFullText()
+
+	fulltext := dbal.FullText{Term: term}
+	switch values := columns.(type) {
+	case string:
+		fulltext.Columns = []interface{}{values}
+	case []string:
+		for _, column := range values {
+			fulltext.Columns = append(fulltext.Columns, column)
+		}
+	case []interface{}:
+		fulltext.Columns = values
+	default:
+		panic(fmt.Errorf("the full-text columns must be a column name or a slice of column names"))
+	}
+
+	if len(fulltext.Columns) == 0 {
+		panic(fmt.Errorf("the full-text columns must not be empty"))
+	}
+
+	if len(options) > 0 {
+		fulltext.FullTextOptions = options[0]
+	}
+
+	if fulltext.Mode == "" {
+		fulltext.Mode = "natural"
+	}
+
+	if fulltext.Mode != "natural" && fulltext.Mode != "boolean" {
+		panic(fmt.Errorf(`the full-text mode must be "natural" or "boolean"`))
+	}
+	return fulltext
+}
+
+// checkFullText Panic if the database server does not support the full-text search
+func (builder *Builder) checkFullText() {
+	version, err := builder.getVersion()
+	if err != nil {
+		panic(err)
+	}
+
+	if !builder.Grammar.SupportsFullText(version) {
+		panic(fmt.Errorf("the full-text search is not supported by %s %s", version.Driver, version.String()))
+	}
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestFullTextWhereFullText(t *testing.T) {
+	if unit.DriverIs("sqlite3") {
+		return
+	}
+
+	NewTableForFullTextTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_fulltext").
+		Select("id").
+		WhereFullText([]string{"title", "content"}, "database").
+		OrderBy("id")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "id" from "table_test_fulltext" where to_tsvector('english', coalesce("title", '') || ' ' || coalesce("content", '')) @@ plainto_tsquery('english', $1) order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `id` from `table_test_fulltext` where match (`title`, `content`) against (? in natural language mode) order by `id` asc", sql, "the query sql not equal")
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, int64(1), rows[0].Get("id"), "the id of 1st row should be 1")
+		assert.Equal(t, int64(3), rows[1].Get("id"), "the id of 2nd row should be 3")
+	}
+}
+
+func TestFullTextOrWhereFullTextBoolean(t *testing.T) {
+	if unit.DriverIs("sqlite3") {
+		return
+	}
+
+	NewTableForFullTextTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_fulltext").
+		Select("id").
+		Where("id", 2).
+		OrWhereFullText([]string{"title", "content"}, `+database -install`, dbal.FullTextOptions{Mode: "boolean"}).
+		OrderBy("id")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "id" from "table_test_fulltext" where "id" = $1 or to_tsvector('english', coalesce("title", '') || ' ' || coalesce("content", '')) @@ websearch_to_tsquery('english', $2) order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `id` from `table_test_fulltext` where `id` = ? or match (`title`, `content`) against (? in boolean mode) order by `id` asc", sql, "the query sql not equal")
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, int64(2), rows[0].Get("id"), "the id of 1st row should be 2")
+		assert.Equal(t, int64(3), rows[1].Get("id"), "the id of 2nd row should be 3")
+	}
+}
+
+func TestFullTextSelectRankAndOrderByRelevance(t *testing.T) {
+	if unit.DriverIs("sqlite3") {
+		return
+	}
+
+	NewTableForFullTextTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_fulltext").
+		Select("id").
+		SelectFullTextRank("content", "database", "score").
+		WhereFullText("content", "database").
+		OrderByRelevance("content", "database")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "id", ts_rank(to_tsvector('english', coalesce("content", '')), plainto_tsquery('english', $1)) as "score" from "table_test_fulltext" where to_tsvector('english', coalesce("content", '')) @@ plainto_tsquery('english', $2) order by ts_rank(to_tsvector('english', coalesce("content", '')), plainto_tsquery('english', $3)) desc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `id`, match (`content`) against (? in natural language mode) as `score` from `table_test_fulltext` where match (`content`) against (? in natural language mode) order by match (`content`) against (? in natural language mode) desc", sql, "the query sql not equal")
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, int64(3), rows[0].Get("id"), "the most relevant row should be 3")
+		assert.NotNil(t, rows[0].Get("score"), "the score of the row should not be nil")
+	}
+}
+
+func TestFullTextUnsupported(t *testing.T) {
+	if !unit.DriverIs("sqlite3") {
+		return
+	}
+
+	qb := getTestBuilder()
+	version, err := qb.Builder().getVersion()
+	assert.Nil(t, err, "the version should be returned")
+	assert.PanicsWithError(t, "the full-text search is not supported by sqlite3 "+version.String(), func() {
+		qb.Table("table_test_fulltext").WhereFullText("content", "database")
+	})
+}
+
+func TestFullTextInvalid(t *testing.T) {
+	if unit.DriverIs("sqlite3") {
+		return
+	}
+
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, `the full-text mode must be "natural" or "boolean"`, func() {
+		qb.Table("table_test_fulltext").WhereFullText("content", "database", dbal.FullTextOptions{Mode: "phrase"})
+	})
+	assert.PanicsWithError(t, "the full-text columns must not be empty", func() {
+		qb.Table("table_test_fulltext").WhereFullText([]string{}, "database")
+	})
+}
+
+// clean the test data
+func TestFullTextClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_fulltext")
+}
+
+func NewTableForFullTextTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_fulltext")
+	builder.MustCreateTable("table_test_fulltext", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("title", 200)
+		table.Text("content")
+		table.AddFulltext("title_content", "title", "content")
+		table.AddFulltext("content", "content")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_fulltext").MustInsert([]xun.R{
+		{"title": "Getting started", "content": "How to install the database server"},
+		{"title": "Cooking", "content": "A recipe for a lemon cake"},
+		{"title": "Database tuning", "content": "The database cache and the database buffers"},
+		{"title": "Index", "content": "The index of the library"},
+	})
+}
@@ -0,0 +1,292 @@
+package query
+
+import (
+	dbsql "database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+var scannerType = reflect.TypeOf((*dbsql.Scanner)(nil)).Elem()
+var timeType = reflect.TypeOf(time.Time{})
+
+// GetAs Execute the query as a "select" statement and scan the rows into the values of T.
+// The struct fields are mapped by the "db" tag, then the "json" tag, then the snake case of the field name,
+// the fields of the embedded structs are mapped as well and the columns without a field are skipped.
+// The pointer fields are nil for the NULL values, and the fields implementing sql.Scanner scan themselves.
+// T could be a scalar type if the query selects one column.
+//
+//	type User struct {
+//		ID    int64   `db:"id"`
+//		Name  *string `json:"name"`
+//	}
+//	users, err := query.GetAs[User](qb.Table("users").Where("vote", ">", 10))
+func GetAs[T any](qb Query) ([]T, error) {
+	builder, err := qb.Builder().scoped()
+	if err != nil {
+		return nil, err
+	}
+
+	err = builder.checkWindowFunctions()
+	if err != nil {
+		return nil, err
+	}
+
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	sql := builder.ToSQL()
+	bindings := builder.GetBindings()
+	defer log.With(log.F{"bindings": bindings}).Trace("builder get sql:%s", sql)
+
+	rows, err := builder.executor().Query(sql, bindings...)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
+
+	res, err := scanAs[T](rows)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
+	return res, nil
+}
+
+// MustGetAs Execute the query as a "select" statement and scan the rows into the values of T.
+func MustGetAs[T any](qb Query) []T {
+	res, err := GetAs[T](qb)
+	utils.PanicIF(err)
+	return res
+}
+
+// FirstAs Execute the query and scan the first row into a value of T, the error matches dbal.ErrNotFound if there is no row.
+func FirstAs[T any](qb Query) (T, error) {
+	var res T
+	rows, err := GetAs[T](qb.Take(1))
+	if err != nil {
+		return res, err
+	}
+
+	if len(rows) == 0 {
+		return res, dbal.NewError(dbal.ErrNotFound, dbsql.ErrNoRows)
+	}
+	return rows[0], nil
+}
+
+// MustFirstAs Execute the query and scan the first row into a value of T.
+func MustFirstAs[T any](qb Query) T {
+	res, err := FirstAs[T](qb)
+	utils.PanicIF(err)
+	return res
+}
+
+// FindAs Execute a query for a single record by ID and scan it into a value of T, the key column is "id" if it's not given.
+//
+//	user, err := query.FindAs[User](qb.Table("users"), 1)
+//	user, err := query.FindAs[User](qb.Table("users"), "max@yao.run", "email")
+func FindAs[T any](qb Query, id interface{}, key ...string) (T, error) {
+	column := "id"
+	if len(key) > 0 {
+		column = key[0]
+	}
+	return FirstAs[T](qb.Where(column, id))
+}
+
+// MustFindAs Execute a query for a single record by ID and scan it into a value of T.
+func MustFindAs[T any](qb Query, id interface{}, key ...string) T {
+	res, err := FindAs[T](qb, id, key...)
+	utils.PanicIF(err)
+	return res
+}
+
+// PaginateAs Paginate the given query into a typed simple paginator.
+//
+//	page, err := query.PaginateAs[User](qb.Table("users").OrderBy("id"), 15, 2)
+func PaginateAs[T any](qb Query, pageSize int, page int) (Paginator[T], error) {
+	if page < 1 {
+		page = 1
+	}
+
+	if pageSize < 1 {
+		pageSize = 15
+	}
+
+	p := xun.MakePaginator(0, pageSize, page)
+	total, err := qb.Builder().getCountForPagination([]interface{}{"*"})
+	if err != nil {
+		return makePaginator[T](p, nil), err
+	}
+
+	items, err := GetAs[T](qb.Builder().forPage(page, pageSize))
+	if err != nil {
+		return makePaginator[T](p, nil), err
+	}
+	return makePaginator(xun.MakePaginator(total, pageSize, page), items), nil
+}
+
+// MustPaginateAs Paginate the given query into a typed simple paginator.
+func MustPaginateAs[T any](qb Query, pageSize int, page int) Paginator[T] {
+	res, err := PaginateAs[T](qb, pageSize, page)
+	utils.PanicIF(err)
+	return res
+}
+
+// PluckAs Get a slice of T with the values of the given column.
+//
+//	emails, err := query.PluckAs[string](qb.Table("users"), "email")
+func PluckAs[T any](qb Query, column string) ([]T, error) {
+	return GetAs[T](qb.Select(column))
+}
+
+// MustPluckAs Get a slice of T with the values of the given column.
+func MustPluckAs[T any](qb Query, column string) []T {
+	res, err := PluckAs[T](qb, column)
+	utils.PanicIF(err)
+	return res
+}
+
+// makePaginator Create a typed paginator with the pages of the given paginator
+func makePaginator[T any](p xun.P, items []T) Paginator[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Paginator[T]{
+		Items:        items,
+		Total:        p.Total,
+		TotalPages:   p.TotalPages,
+		PageSize:     p.PageSize,
+		CurrentPage:  p.CurrentPage,
+		NextPage:     p.NextPage,
+		PreviousPage: p.PreviousPage,
+		LastPage:     p.LastPage,
+	}
+}
+
+// scanAs Scan the rows into the values of T
+func scanAs[T any](rows *dbsql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	isStruct := typ.Kind() == reflect.Struct && typ != timeType && !reflect.PtrTo(typ).Implements(scannerType)
+	fields := map[string][]int{}
+	if isStruct {
+		typedFieldMap(typ, nil, fields)
+	} else if len(columns) != 1 {
+		return nil, fmt.Errorf("the %s type could only be scanned from one column, %d columns given", typ.String(), len(columns))
+	}
+
+	res := []T{}
+	for rows.Next() {
+		var dest T
+		values := []interface{}{&dest}
+		if isStruct {
+			values = makeTypedValues(reflect.ValueOf(&dest).Elem(), fields, columns)
+		}
+
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+		res = append(res, dest)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// typedFieldMap Map the columns to the index paths of the struct fields, the fields of the struct come first,
+// then the fields of the embedded structs.
+func typedFieldMap(typ reflect.Type, index []int, fields map[string][]int) {
+	embedded := []reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		field.Index = append(append([]int{}, index...), i)
+
+		name := typedFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && field.Tag.Get("db") == "" && field.Tag.Get("json") == "" {
+			// the nil pointers of the unexported embedded structs could not be allocated
+			if field.IsExported() || field.Type.Kind() != reflect.Ptr {
+				embedded = append(embedded, field)
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if _, has := fields[name]; !has {
+			fields[name] = field.Index
+		}
+	}
+
+	for _, field := range embedded {
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		typedFieldMap(fieldType, field.Index, fields)
+	}
+}
+
+// typedFieldName Get the column name of the struct field, the "db" tag comes first, then the "json" tag
+func typedFieldName(field reflect.StructField) string {
+	tag := "json"
+	if field.Tag.Get("db") != "" {
+		tag = "db"
+	}
+	name := strings.Split(xun.GetTagName(field, tag), ",")[0]
+	if name == "" {
+		return xun.ToSnakeCase(field.Name)
+	}
+	return name
+}
+
+// makeTypedValues Get the scan destinations of the columns, the nil embedded struct pointers are allocated
+// and the columns without a field are discarded.
+func makeTypedValues(dest reflect.Value, fields map[string][]int, columns []string) []interface{} {
+	values := []interface{}{}
+	for _, column := range columns {
+		index, has := fields[column]
+		if !has {
+			values = append(values, new(interface{}))
+			continue
+		}
+
+		value := dest
+		for _, i := range index {
+			if value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					value.Set(reflect.New(value.Type().Elem()))
+				}
+				value = value.Elem()
+			}
+			value = value.Field(i)
+		}
+		values = append(values, value.Addr().Interface())
+	}
+	return values
+}
@@ -0,0 +1,134 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+type genericTestBase struct {
+	ID int64 `json:"id"`
+}
+
+type genericTestStatus string
+
+func (status *genericTestStatus) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case string:
+		*status = genericTestStatus(strings.ToUpper(value))
+	case []byte:
+		*status = genericTestStatus(strings.ToUpper(string(value)))
+	case nil:
+		*status = "UNKNOWN"
+	default:
+		return fmt.Errorf("the status %#v is invalid", src)
+	}
+	return nil
+}
+
+type genericTestUser struct {
+	genericTestBase
+	Email  string            `db:"email" json:"mail"`
+	Vote   *int              `json:"vote,omitempty"`
+	Status genericTestStatus `db:"status"`
+	Secret string            `json:"-"`
+}
+
+func TestGenericGetAs(t *testing.T) {
+	NewTableForGenericTest()
+	qb := getTestBuilder()
+	users, err := GetAs[genericTestUser](qb.Table("table_test_generic").OrderBy("id"))
+	assert.Nil(t, err, "the query should be executed")
+	assert.Equal(t, 3, len(users), "the return value should has 3 rows")
+	if len(users) == 3 {
+		assert.Equal(t, int64(1), users[0].ID, "the id of the embedded struct should be scanned")
+		assert.Equal(t, "max@yao.run", users[0].Email, "the email should be mapped by the db tag")
+		assert.Equal(t, 10, *users[0].Vote, "the vote should be 10")
+		assert.Equal(t, genericTestStatus("ACTIVE"), users[0].Status, "the status should be scanned by the scanner")
+		assert.Nil(t, users[2].Vote, "the vote of the NULL value should be nil")
+		assert.Equal(t, genericTestStatus("UNKNOWN"), users[2].Status, "the scanner should get the NULL value")
+		assert.Equal(t, "", users[0].Secret, "the ignored field should be empty")
+	}
+}
+
+func TestGenericFirstAsAndFindAs(t *testing.T) {
+	NewTableForGenericTest()
+	qb := getTestBuilder()
+	user, err := FirstAs[genericTestUser](qb.Table("table_test_generic").Where("vote", ">", 10))
+	assert.Nil(t, err, "the query should be executed")
+	assert.Equal(t, "ken@yao.run", user.Email, "the email of the first row should be ken@yao.run")
+
+	user, err = FindAs[genericTestUser](qb.Table("table_test_generic"), 3)
+	assert.Nil(t, err, "the query should be executed")
+	assert.Equal(t, "ada@yao.run", user.Email, "the email of the row 3 should be ada@yao.run")
+
+	user, err = FindAs[genericTestUser](qb.Table("table_test_generic"), "max@yao.run", "email")
+	assert.Nil(t, err, "the query should be executed")
+	assert.Equal(t, int64(1), user.ID, "the id of max@yao.run should be 1")
+
+	_, err = FindAs[genericTestUser](qb.Table("table_test_generic"), 99)
+	assert.True(t, errors.Is(err, dbal.ErrNotFound), "the error should be not found")
+}
+
+func TestGenericPaginateAs(t *testing.T) {
+	NewTableForGenericTest()
+	qb := getTestBuilder()
+	page, err := PaginateAs[genericTestUser](qb.Table("table_test_generic").OrderBy("id"), 2, 2)
+	assert.Nil(t, err, "the query should be executed")
+	assert.Equal(t, 3, page.Total, "the total should be 3")
+	assert.Equal(t, 2, page.TotalPages, "the total pages should be 2")
+	assert.Equal(t, 2, page.CurrentPage, "the current page should be 2")
+	assert.Equal(t, -1, page.NextPage, "the next page should be -1")
+	if assert.Equal(t, 1, len(page.Items), "the page should has 1 item") {
+		assert.Equal(t, "ada@yao.run", page.Items[0].Email, "the email of the item should be ada@yao.run")
+	}
+}
+
+func TestGenericPluckAs(t *testing.T) {
+	NewTableForGenericTest()
+	qb := getTestBuilder()
+	emails, err := PluckAs[string](qb.Table("table_test_generic").OrderBy("id"), "email")
+	assert.Nil(t, err, "the query should be executed")
+	assert.Equal(t, []string{"max@yao.run", "ken@yao.run", "ada@yao.run"}, emails)
+
+	votes := MustPluckAs[*int](qb.Table("table_test_generic").OrderBy("id"), "vote")
+	if assert.Equal(t, 3, len(votes), "the return value should has 3 values") {
+		assert.Equal(t, 20, *votes[1], "the vote of the 2nd row should be 20")
+		assert.Nil(t, votes[2], "the vote of the 3rd row should be nil")
+	}
+
+	_, err = GetAs[string](qb.Table("table_test_generic"))
+	assert.Equal(t, "the string type could only be scanned from one column, 4 columns given", err.Error())
+}
+
+// clean the test data
+func TestGenericClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_generic")
+}
+
+func NewTableForGenericTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_generic")
+	builder.MustCreateTable("table_test_generic", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.Integer("vote").Null()
+		table.String("status").Null()
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_generic").MustInsert([]xun.R{
+		{"email": "max@yao.run", "vote": 10, "status": "active"},
+		{"email": "ken@yao.run", "vote": 20, "status": "locked"},
+		{"email": "ada@yao.run", "vote": nil, "status": nil},
+	})
+}
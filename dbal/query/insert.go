@@ -8,20 +8,20 @@ import (
 )
 
 // Insert Insert new records into the database.
+// The rows are split into batches under the bindings limit and the packet size (max_allowed_packet of MySQL) of the database server,
+// the batches are inserted in one transaction. Pass a BatchSize option to set the number of rows of each batch.
+//
+//	Insert(rows, query.BatchSize(500))
 func (builder *Builder) Insert(v interface{}, columns ...interface{}) error {
-
+	columns, size := splitBatchSize(columns)
 	columns, values := builder.prepareInsertValues(v, columns...)
-	sql, bindings := builder.Grammar.CompileInsert(builder.Query, columns, values)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	batches, err := builder.chunkInsertValues(columns, values, size, 0)
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
-
-	_, err = utils.StmtExec(stmt, bindings)
-
+	_, err = builder.execInsertBatches(batches, func(values [][]interface{}) (string, []interface{}) {
+		return builder.Grammar.CompileInsert(builder.Query, columns, values)
+	})
 	return err
 }
 
@@ -32,24 +32,17 @@ func (builder *Builder) MustInsert(v interface{}, columns ...interface{}) {
 }
 
 // InsertOrIgnore Insert new records into the database while ignoring errors.
+// The rows are split into batches like the Insert method, it returns the sum of the affected rows of the batches.
 func (builder *Builder) InsertOrIgnore(v interface{}, columns ...interface{}) (int64, error) {
+	columns, size := splitBatchSize(columns)
 	columns, values := builder.prepareInsertValues(v, columns...)
-	sql, bindings := builder.Grammar.CompileInsertOrIgnore(builder.Query, columns, values)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	batches, err := builder.chunkInsertValues(columns, values, size, 0)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
-
-	// res, err := stmt.Exec(bindings...)
-	res, err := utils.StmtExec(stmt, bindings)
-
-	if err != nil {
-		return 0, err
-	}
-	return res.RowsAffected()
+	return builder.execInsertBatches(batches, func(values [][]interface{}) (string, []interface{}) {
+		return builder.Grammar.CompileInsertOrIgnore(builder.Query, columns, values)
+	})
 }
 
 // MustInsertOrIgnore Insert new records into the database while ignoring errors.
@@ -72,8 +65,18 @@ func (builder *Builder) InsertGetID(v interface{}, args ...interface{}) (int64,
 
 	columns, values := builder.prepareInsertValues(v, columns...)
 	sql, bindings := builder.Grammar.CompileInsertGetID(builder.Query, columns, values, seq)
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+
 	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-	return builder.Grammar.ProcessInsertGetID(sql, bindings, seq)
+	lastID, err := builder.Grammar.ProcessInsertGetID(sql, bindings, seq)
+	if err != nil {
+		return 0, builder.translateError(err)
+	}
+	return lastID, nil
 }
 
 // MustInsertGetID Insert a new record and get the value of the primary key.
@@ -87,19 +90,26 @@ func (builder *Builder) MustInsertGetID(v interface{}, args ...interface{}) int6
 func (builder *Builder) InsertUsing(qb interface{}, columns ...interface{}) (int64, error) {
 
 	columns = builder.prepareColumns(columns...)
-	sub, bindings, _ := builder.createSub(qb)
+	sub, subBindings, _ := builder.createSub(qb)
 	sql := builder.parseSub(sub)
 	sql = builder.Grammar.CompileInsertUsing(builder.Query, columns, sql)
+	bindings := append(builder.Query.GetBindings("with"), subBindings...)
 
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	builder.UseWrite()
+	builder, cancel, err := builder.withTimeout()
 	if err != nil {
 		return 0, err
 	}
+	defer cancel()
+	stmt, err := builder.executor().Prepare(sql)
+	if err != nil {
+		return 0, builder.translateError(err)
+	}
 	defer stmt.Close()
-	res, err := utils.StmtExec(stmt, bindings)
+	res, err := utils.StmtExecContext(builder.Context(), stmt, bindings)
 	// res, err := stmt.Exec(bindings...)
 	if err != nil {
-		return 0, err
+		return 0, builder.translateError(err)
 	}
 
 	return res.RowsAffected()
@@ -111,3 +121,147 @@ func (builder *Builder) MustInsertUsing(qb interface{}, columns ...interface{})
 	utils.PanicIF(err)
 	return affected
 }
+
+// splitBatchSize Remove the BatchSize option from the given arguments, the batch size is 0 if the option is not given.
+func splitBatchSize(args []interface{}) ([]interface{}, int) {
+	size := 0
+	res := []interface{}{}
+	for _, arg := range args {
+		if batchSize, ok := arg.(BatchSize); ok {
+			size = int(batchSize)
+			continue
+		}
+		res = append(res, arg)
+	}
+	return res, size
+}
+
+// chunkInsertValues Split the rows of a bulk insert into batches, the bindings of each batch stay under the limit of the database server,
+// and the estimated size of each statement stays under the packet size of the connection if it's limited.
+// The given size is used if it's positive and under the limit, the reserved bindings are bound to the other clauses of the statement.
+func (builder *Builder) chunkInsertValues(columns []interface{}, values [][]interface{}, size int, reserved int) ([][][]interface{}, error) {
+	limit := builder.Grammar.GetMaxBindings() - reserved
+	if len(columns) > 0 {
+		limit = limit / len(columns)
+	}
+	if limit < 1 {
+		limit = 1
+	}
+	if size <= 0 || size > limit {
+		size = limit
+	}
+
+	packetSize, err := builder.getPacketSize()
+	if err != nil {
+		return nil, err
+	}
+
+	// the statement without the values is counted in the packet overhead
+	budget := packetSize - insertPacketOverhead
+	chunks := [][][]interface{}{}
+	chunk := [][]interface{}{}
+	bytes := 0
+	for _, row := range values {
+		rowBytes := estimateRowSize(row)
+		if len(chunk) > 0 && (len(chunk) >= size || (packetSize > 0 && bytes+rowBytes > budget)) {
+			chunks = append(chunks, chunk)
+			chunk = [][]interface{}{}
+			bytes = 0
+		}
+		chunk = append(chunk, row)
+		bytes = bytes + rowBytes
+	}
+
+	if len(chunk) > 0 || len(chunks) == 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+// insertPacketOverhead the estimated size in bytes of the insert statement without the values
+const insertPacketOverhead = 4096
+
+// estimateRowSize Estimate the size in bytes of the values of a row in the statement packet
+func estimateRowSize(row []interface{}) int {
+	size := 0
+	for _, value := range row {
+		// the placeholder, the separator and the type of the binding
+		size = size + 4
+		switch v := value.(type) {
+		case string:
+			size = size + len(v)
+		case []byte:
+			size = size + len(v)
+		case nil:
+		default:
+			size = size + len(fmt.Sprintf("%v", v))
+		}
+	}
+	return size
+}
+
+// execInsertBatches Execute the statement compiled for each batch and return the sum of the affected rows,
+// the batches are executed in one transaction if there are more than one.
+func (builder *Builder) execInsertBatches(batches [][][]interface{}, compile func(values [][]interface{}) (string, []interface{})) (int64, error) {
+	if len(batches) == 1 {
+		sql, bindings := compile(batches[0])
+		return builder.execInsertBatch(sql, bindings)
+	}
+
+	var affected int64 = 0
+	err := builder.transaction(func(qb Query) error {
+		qb.Timeout(builder.Query.Timeout)
+		for _, batch := range batches {
+			sql, bindings := compile(batch)
+			batchAffected, err := qb.Builder().execInsertBatch(sql, bindings)
+			if err != nil {
+				return err
+			}
+			affected = affected + batchAffected
+		}
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return affected, nil
+}
+
+// execInsertBatch Execute the statement of a batch, the statement is executed for each row when the bindings are grouped by rows.
+func (builder *Builder) execInsertBatch(sql string, bindings []interface{}) (int64, error) {
+	defer log.With(log.F{"bindings": bindings}).Debug(sql)
+
+	builder.UseWrite()
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+	stmt, err := builder.executor().Prepare(sql)
+	if err != nil {
+		return 0, builder.translateError(err)
+	}
+	defer stmt.Close()
+
+	groups := [][]interface{}{bindings}
+	if len(bindings) > 0 {
+		if _, ok := bindings[0].([]interface{}); ok {
+			groups = [][]interface{}{}
+			for _, row := range bindings {
+				groups = append(groups, row.([]interface{}))
+			}
+		}
+	}
+
+	var affected int64 = 0
+	for _, group := range groups {
+		res, err := stmt.ExecContext(builder.Context(), group...)
+		if err != nil {
+			return 0, builder.translateError(err)
+		}
+		rowsAffected, _ := res.RowsAffected()
+		affected = affected + rowsAffected
+	}
+	return affected, nil
+}
@@ -1,6 +1,8 @@
 package query
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -202,6 +204,85 @@ func TestInsertMustInsertOrIgnoreWithColumns(t *testing.T) {
 	checkInsertWithColumns(t, qb)
 }
 
+func TestInsertMustInsertBatches(t *testing.T) {
+	NewTableForInsertTest()
+	qb := getTestBuilder()
+
+	// the rows are over the bindings limit of the driver, they should be split into batches
+	count := qb.Builder().Grammar.GetMaxBindings()/2 + 10
+	rows := []xun.R{}
+	for i := 0; i < count; i++ {
+		rows = append(rows, xun.R{"email": fmt.Sprintf("user%d@example.com", i), "vote": i})
+	}
+	qb.Table("table_test_insert").MustInsert(rows)
+	assert.Equal(t, int64(count), qb.Table("table_test_insert").MustCount(), "All the rows should be inserted")
+}
+
+func TestInsertMustInsertBatchesByPacketSize(t *testing.T) {
+	NewTableForInsertTest()
+	qb := getTestBuilder()
+	option := qb.Builder().Conn.Option
+	option.MaxPacketSize = insertPacketOverhead + 1000
+	defer func() { option.MaxPacketSize = 0 }()
+
+	// the rows are under the bindings limit, they should be split by the size of the statement
+	rows := [][]interface{}{}
+	for i := 0; i < 30; i++ {
+		rows = append(rows, []interface{}{fmt.Sprintf("%s%03d@example.com", strings.Repeat("u", 80), i), i})
+	}
+	batches, err := qb.Builder().chunkInsertValues([]interface{}{"email", "vote"}, rows, 0, 0)
+	assert.Nil(t, err, "The rows should be split")
+	assert.Greater(t, len(batches), 1, "The rows should be split into batches")
+	for _, batch := range batches {
+		size := 0
+		for _, row := range batch {
+			size = size + estimateRowSize(row)
+		}
+		assert.LessOrEqual(t, size, 1000, "The size of each batch should be under the packet size")
+	}
+
+	qb.Table("table_test_insert").MustInsert(rows, "email,vote")
+	assert.Equal(t, int64(30), qb.Table("table_test_insert").MustCount(), "All the rows should be inserted")
+}
+
+func TestInsertPacketSize(t *testing.T) {
+	qb := getTestBuilder()
+	size, err := qb.Builder().getPacketSize()
+	assert.Nil(t, err, "The packet size should be returned")
+	if unit.DriverIs("mysql") {
+		assert.Greater(t, size, 0, "The packet size of MySQL should be the max_allowed_packet")
+		return
+	}
+	assert.Equal(t, 0, size, "The packet size should not be limited")
+}
+
+func TestInsertMustInsertBatchSize(t *testing.T) {
+	NewTableForInsertTest()
+	qb := getTestBuilder()
+	rows := []xun.R{}
+	for i := 0; i < 10; i++ {
+		rows = append(rows, xun.R{"email": fmt.Sprintf("user%d@example.com", i), "vote": i})
+	}
+	qb.Table("table_test_insert").MustInsert(rows[:5], BatchSize(2))
+	assert.Equal(t, int64(5), qb.Table("table_test_insert").MustCount(), "The rows should be inserted")
+
+	affected := qb.Table("table_test_insert").MustInsertOrIgnore(rows, BatchSize(3))
+	assert.Equal(t, int64(5), affected, "The affected rows should be 5")
+	assert.Equal(t, int64(10), qb.Table("table_test_insert").MustCount(), "The rows should be inserted")
+}
+
+func TestInsertInsertBatchesRollback(t *testing.T) {
+	NewTableForInsertTest()
+	qb := getTestBuilder()
+	err := qb.Table("table_test_insert").Insert([]xun.R{
+		{"email": "picard@example.com", "vote": 1},
+		{"email": "janeway@example.com", "vote": 2},
+		{"email": "picard@example.com", "vote": 3},
+	}, BatchSize(2))
+	assert.NotNil(t, err, "The duplicate email should return an error")
+	assert.Equal(t, int64(0), qb.Table("table_test_insert").MustCount(), "The inserted batches should be rolled back")
+}
+
 func TestInsertMustInsertGetID(t *testing.T) {
 	NewTableForInsertTest()
 	qb := getTestBuilder()
@@ -1,10 +1,13 @@
 package query
 
 import (
+	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
 )
 
 // Query The database Query interface
@@ -34,11 +37,23 @@ type Query interface {
 	ToSQL() string
 	GetBindings() []interface{}
 
+	// defined in the cursor.go file
+	Cursor() (*Cursor, error)
+	MustCursor() *Cursor
+	Each(callback func(row xun.R) error) error
+	MustEach(callback func(row xun.R) error)
+
 	// defined in the paginate.go file
 	Paginate(perpage int, page int, v ...interface{}) (xun.P, error)
 	MustPaginate(perpage int, page int, v ...interface{}) xun.P
+	CursorPaginate(perpage int, cursor string, v ...interface{}) (xun.CP, error)
+	MustCursorPaginate(perpage int, cursor string, v ...interface{}) xun.CP
 	Chunk(size int, callback func(items []interface{}, page int) error, v ...interface{}) error
 	MustChunk(size int, callback func(items []interface{}, page int) error, v ...interface{})
+	ChunkByID(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{}) error
+	MustChunkByID(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{})
+	ChunkByIDDesc(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{}) error
+	MustChunkByIDDesc(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{})
 
 	// defined in the connection.go file
 	DB(usewrite ...bool) *sqlx.DB
@@ -47,7 +62,32 @@ type Query interface {
 	UseWrite() Query
 	IsWrite() bool
 
+	// defined in the with.go file
+	With(name string, qb interface{}, columns ...interface{}) Query
+	WithRecursive(name string, qb interface{}, columns ...interface{}) Query
+	WithMaterialized(name string, qb interface{}, columns ...interface{}) Query
+	WithNotMaterialized(name string, qb interface{}, columns ...interface{}) Query
+
+	// defined in the transaction.go file
+	Transaction(callback func(qb Query) error) error
+	Begin() (Query, error)
+	Commit() error
+	Rollback() error
+	InTransaction() bool
+	Retry(policy dbal.RetryPolicy) Query
+
+	// defined in the context.go file
+	WithContext(ctx context.Context) Query
+	Context() context.Context
+
+	// defined in the timeout.go file
+	Timeout(timeout time.Duration) Query
+
+	// defined in the decode.go file
+	KeepRawValues() Query
+
 	// defined in the aggregate.go file
+	SelectAggregate(fn string, column interface{}, alias string, distinct ...bool) Query
 	Count(columns ...interface{}) (int64, error)
 	MustCount(columns ...interface{}) int64
 	Min(columns ...interface{}) (xun.N, error)
@@ -67,6 +107,16 @@ type Query interface {
 	SelectSub(qb interface{}, alias string) Query
 	Distinct(args ...interface{}) Query
 
+	// defined in the window.go file
+	SelectWindow(fn string, args interface{}, over interface{}, alias string) Query
+	Window(name string, window *dbal.Window) Query
+
+	// defined in the fulltext.go file
+	WhereFullText(columns interface{}, term string, options ...dbal.FullTextOptions) Query
+	OrWhereFullText(columns interface{}, term string, options ...dbal.FullTextOptions) Query
+	SelectFullTextRank(columns interface{}, term string, alias string, options ...dbal.FullTextOptions) Query
+	OrderByRelevance(columns interface{}, term string, options ...dbal.FullTextOptions) Query
+
 	// defined in the from.go file
 	From(name string) Query
 	FromRaw(sql string, bindings ...interface{}) Query
@@ -124,6 +174,16 @@ type Query interface {
 	OrWhereDay(column interface{}, args ...interface{}) Query
 	When(value bool, callback func(qb Query, value bool), defaults ...func(qb Query, value bool)) Query
 	Unless(value bool, callback func(qb Query, value bool), defaults ...func(qb Query, value bool)) Query
+	WhereJSONContains(column string, value interface{}) Query
+	OrWhereJSONContains(column string, value interface{}) Query
+	WhereJSONDoesntContain(column string, value interface{}) Query
+	OrWhereJSONDoesntContain(column string, value interface{}) Query
+	WhereJSONLength(column string, args ...interface{}) Query
+	OrWhereJSONLength(column string, args ...interface{}) Query
+	WhereJSONContainsKey(column string) Query
+	OrWhereJSONContainsKey(column string) Query
+	WhereJSONDoesntContainKey(column string) Query
+	OrWhereJSONDoesntContainKey(column string) Query
 
 	// defined in the group.go file
 	GroupBy(groups ...interface{}) Query
@@ -167,6 +227,8 @@ type Query interface {
 	MustUpdateOrInsert(attributes interface{}, values ...interface{}) bool
 	Update(v interface{}) (int64, error)
 	MustUpdate(v interface{}) int64
+	UpdateBatch(rows []xun.R, keyColumn string, strategy ...string) (int64, error)
+	MustUpdateBatch(rows []xun.R, keyColumn string, strategy ...string) int64
 	Increment(column interface{}, amount interface{}, extra ...interface{}) (int64, error)
 	MustIncrement(column interface{}, amount interface{}, extra ...interface{}) int64
 	Decrement(column interface{}, amount interface{}, extra ...interface{}) (int64, error)
@@ -178,10 +240,45 @@ type Query interface {
 	Truncate() error
 	MustTruncate()
 
+	// defined in the bulk.go file
+	BulkLoad(columns interface{}, source interface{}) (int64, error)
+	MustBulkLoad(columns interface{}, source interface{}) int64
+
+	// defined in the returning.go file
+	InsertReturning(v interface{}, columns ...interface{}) ([]xun.R, error)
+	MustInsertReturning(v interface{}, columns ...interface{}) []xun.R
+	UpdateReturning(v interface{}, columns ...interface{}) ([]xun.R, error)
+	MustUpdateReturning(v interface{}, columns ...interface{}) []xun.R
+	DeleteReturning(columns ...interface{}) ([]xun.R, error)
+	MustDeleteReturning(columns ...interface{}) []xun.R
+	UpsertReturning(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) ([]xun.R, error)
+	MustUpsertReturning(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) []xun.R
+
 	// defined in the exec.go file
 	Exec(sql string, bindings ...interface{}) (sql.Result, error)
 	ExecWrite(sql string, bindings ...interface{}) (sql.Result, error)
 
+	// defined in the offline.go file
+	ToInsertSQL(v interface{}, columns ...interface{}) (string, []interface{})
+	ToUpdateSQL(v interface{}) (string, []interface{})
+	ToUpsertSQL(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) (string, []interface{})
+	ToDeleteSQL() (string, []interface{})
+
+	// defined in the dialect.go file
+	ToSQLFor(driver string) (string, []interface{})
+	Dialect(driver string) Query
+
+	// defined in the dsl.go file
+	LoadDSL(doc interface{}) (Query, error)
+	ToDSL() (*DSL, error)
+
+	// defined in the walk.go file
+	Walk(visitor dbal.Visitor) error
+
+	// defined in the scope.go file
+	WithoutScope(table string) Query
+	WithoutScopes() Query
+
 	// defined in the debug.go file
 	DD()
 	Dump()
@@ -267,6 +364,7 @@ type Query interface {
 // 		table("users").whereJsonContains(`options->languages`, [`en`, `de`])
 // 		table("users").whereJsonLength(`options->languages`, 0)
 // 		table("users").whereJsonLength(`options->languages`, `>`, 1)
+// 		table("users").whereJsonContainsKey(`options->address->city`)
 // Additional Where Clauses:
 // 		whereBetween / orWhereBetween
 // 		whereNotBetween / orWhereNotBetween
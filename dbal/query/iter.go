@@ -0,0 +1,68 @@
+//go:build go1.23
+
+package query
+
+import (
+	"iter"
+
+	"github.com/yaoapp/xun"
+)
+
+// Rows Execute the query and get an iterator of the results, the rows are read one at a time
+// and the connection will be released when the loop is finished or broken.
+//
+//	for row, err := range qb.Table("users").Builder().Rows() {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (builder *Builder) Rows() iter.Seq2[xun.R, error] {
+	return func(yield func(xun.R, error) bool) {
+		cur, err := builder.Cursor()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer cur.Close()
+
+		for cur.Next() {
+			row, err := cur.Row()
+			if !yield(row, err) || err != nil {
+				return
+			}
+		}
+
+		if err := cur.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// ScanRows Execute the query and get an iterator which scans each row into the given struct pointer,
+// the value is reused for each row, copy it if it should be kept after the iteration step.
+//
+//	user := User{}
+//	for _, err := range qb.Table("users").Builder().ScanRows(&user) {
+//		fmt.Println(user.Name)
+//	}
+func (builder *Builder) ScanRows(v interface{}) iter.Seq2[int, error] {
+	return func(yield func(int, error) bool) {
+		cur, err := builder.Cursor()
+		if err != nil {
+			yield(0, err)
+			return
+		}
+		defer cur.Close()
+
+		for i := 0; cur.Next(); i++ {
+			err := cur.Scan(v)
+			if !yield(i, err) || err != nil {
+				return
+			}
+		}
+
+		if err := cur.Err(); err != nil {
+			yield(-1, err)
+		}
+	}
+}
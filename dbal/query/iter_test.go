@@ -0,0 +1,56 @@
+//go:build go1.23
+
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterRows(t *testing.T) {
+	NewTableForCursorTest()
+	qb := getTestBuilder()
+	IDs := []int64{}
+	for row, err := range qb.Table("table_test_cursor").OrderBy("id").Builder().Rows() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		IDs = append(IDs, row.Get("id").(int64))
+		if len(IDs) == 3 {
+			break
+		}
+	}
+	assert.Equal(t, []int64{1, 2, 3}, IDs, "the ids should be []int64{1,2,3}")
+	assert.Equal(t, int64(4), qb.Table("table_test_cursor").MustCount(), "the rows count should be 4")
+}
+
+func TestIterScanRows(t *testing.T) {
+
+	type Item struct {
+		ID    int64
+		Email string
+		Vote  int
+	}
+
+	NewTableForCursorTest()
+	qb := getTestBuilder()
+	item := Item{}
+	votes := []int{}
+	for i, err := range qb.Table("table_test_cursor").Select("id", "email", "vote").OrderBy("id").Builder().ScanRows(&item) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, int64(i+1), item.ID)
+		votes = append(votes, item.Vote)
+	}
+	assert.Equal(t, []int{10, 5, 125, 6}, votes, "the votes should be []int{10,5,125,6}")
+}
+
+func TestIterError(t *testing.T) {
+	qb := getTestBuilder()
+	for row, err := range qb.Table("table_test_iter_not_exists").Builder().Rows() {
+		assert.Nil(t, row)
+		assert.NotNil(t, err, "the error should not be nil")
+	}
+}
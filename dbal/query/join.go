@@ -123,7 +123,7 @@ func (builder *Builder) join(table interface{}, alias string, first interface{},
 			join.SQL = table
 		}
 		builder.Query.Joins = append(builder.Query.Joins, join)
-		builder.Query.AddBinding("join", qb.GetBindings())
+		builder.Query.AddBinding("join", qb.Query.GetBindings())
 	}
 	return builder
 }
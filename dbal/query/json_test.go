@@ -0,0 +1,162 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestJSONWhereSelectOrder(t *testing.T) {
+	NewTableForJSONTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_json").
+		Select("name", "meta->address->city as city").
+		Where("meta->address->country", "CN").
+		OrderBy("meta->address->city", "desc")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "name", "meta"->'address'->>'city' as "city" from "table_test_json" where "meta"->'address'->>'country' = $1 order by "meta"->'address'->>'city' desc`, sql, "the query sql not equal")
+	} else if unit.DriverIs("sqlite3") {
+		assert.Equal(t, "select `name`, json_extract(`meta`, '$.\"address\".\"city\"') as `city` from `table_test_json` where json_extract(`meta`, '$.\"address\".\"country\"') = ? order by json_extract(`meta`, '$.\"address\".\"city\"') desc", sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `name`, json_unquote(json_extract(`meta`, '$.\"address\".\"city\"')) as `city` from `table_test_json` where json_unquote(json_extract(`meta`, '$.\"address\".\"country\"')) = ? order by json_unquote(json_extract(`meta`, '$.\"address\".\"city\"')) desc", sql, "the query sql not equal")
+	}
+
+	// checking result
+	skipIfJSONNotSupported(t)
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "Shanghai", fmt.Sprintf("%s", rows[0]["city"]), "the city of 1st row should be Shanghai")
+		assert.Equal(t, "Beijing", fmt.Sprintf("%s", rows[1]["city"]), "the city of 2nd row should be Beijing")
+	}
+}
+
+func TestJSONWhereJSONContains(t *testing.T) {
+	skipIfJSONNotSupported(t)
+	NewTableForJSONTest()
+	qb := getTestBuilder()
+	rows := qb.Table("table_test_json").WhereJSONContains("meta->languages", "en").OrderBy("id").MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "John", rows[0]["name"], "the name of 1st row should be John")
+		assert.Equal(t, "Lee", rows[1]["name"], "the name of 2nd row should be Lee")
+	}
+
+	rows = qb.Table("table_test_json").WhereJSONContains("meta->languages", []string{"en", "zh"}).MustGet()
+	assert.Equal(t, 1, len(rows), "the return value should has 1 row")
+	if len(rows) == 1 {
+		assert.Equal(t, "Lee", rows[0]["name"], "the name should be Lee")
+	}
+
+	rows = qb.Table("table_test_json").
+		WhereJSONDoesntContain("meta->languages", "en").
+		OrWhereJSONContains("meta->languages", []string{"en", "zh"}).
+		OrderBy("id").
+		MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "Lee", rows[0]["name"], "the name of 1st row should be Lee")
+		assert.Equal(t, "Ken", rows[1]["name"], "the name of 2nd row should be Ken")
+	}
+}
+
+func TestJSONWhereJSONLength(t *testing.T) {
+	skipIfJSONNotSupported(t)
+	NewTableForJSONTest()
+	qb := getTestBuilder()
+	assert.Equal(t, int64(1), qb.Table("table_test_json").WhereJSONLength("meta->languages", 2).MustCount(), "the rows count should be 1")
+	assert.Equal(t, int64(2), qb.Table("table_test_json").WhereJSONLength("meta->languages", "<", 2).MustCount(), "the rows count should be 2")
+	assert.Equal(t, int64(3), qb.Table("table_test_json").
+		WhereJSONLength("meta->languages", ">", 1).
+		OrWhereJSONLength("meta->languages", 1).
+		MustCount(), "the rows count should be 3")
+}
+
+func TestJSONWhereJSONContainsKey(t *testing.T) {
+	skipIfJSONNotSupported(t)
+	NewTableForJSONTest()
+	qb := getTestBuilder()
+	assert.Equal(t, int64(2), qb.Table("table_test_json").WhereJSONContainsKey("meta->address->city").MustCount(), "the rows count should be 2")
+	assert.Equal(t, int64(1), qb.Table("table_test_json").WhereJSONDoesntContainKey("meta->address->city").MustCount(), "the rows count should be 1")
+	assert.Equal(t, int64(1), qb.Table("table_test_json").WhereJSONContainsKey("meta->languages->1").MustCount(), "the rows count should be 1")
+	assert.Panics(t, func() {
+		qb.Table("table_test_json").WhereJSONContainsKey("meta")
+	})
+	assert.Panics(t, func() {
+		qb.Table("table_test_json").WhereJSONContainsKey("meta->")
+	})
+}
+
+func TestJSONWhereJSONContainsKeySelector(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+	qb.Table("users").WhereJSONContainsKey("meta->address->city")
+	assert.Equal(t, `select * from "users" where coalesce(jsonb_exists(("meta"->'address')::jsonb, 'city'), false)`, qb.ToSQL(), "the query sql not equal")
+
+	// the selector without path segments
+	assert.PanicsWithError(t, `the column "meta->" must be a JSON selector, e.g. options->address->city`, func() {
+		NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")}).Table("users").WhereJSONContainsKey("meta->")
+	})
+}
+
+func TestJSONUpdate(t *testing.T) {
+	skipIfJSONNotSupported(t)
+	NewTableForJSONTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_json").
+		Where("name", "Ken").
+		MustUpdate(xun.R{"meta->address->city": "Paris", "meta->address->country": "FR", "name": "Kenny"})
+	assert.Equal(t, int64(1), affected, "the affected rows should be 1")
+
+	row := qb.Table("table_test_json").
+		Select("name", "meta->address->city as city", "meta->address->country as country").
+		Where("id", 3).
+		MustFirst()
+	assert.Equal(t, "Kenny", row["name"], "the name should be Kenny")
+	assert.Equal(t, "Paris", fmt.Sprintf("%s", row["city"]), "the city should be Paris")
+	assert.Equal(t, "FR", fmt.Sprintf("%s", row["country"]), "the country should be FR")
+	assert.Equal(t, int64(1), qb.Table("table_test_json").WhereJSONContains("meta->languages", "fr").MustCount(), "the languages should be kept")
+}
+
+// skipIfJSONNotSupported the JSON functions of sqlite3 are available with the "sqlite_json" build tag
+func skipIfJSONNotSupported(t *testing.T) {
+	if !unit.DriverIs("sqlite3") {
+		return
+	}
+	_, err := getTestBuilder().SelectRaw("json('{}') as doc").Get()
+	if err != nil {
+		t.Skip("the sqlite3 driver is built without the sqlite_json tag")
+	}
+}
+
+// clean the test data
+func TestJSONClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_json")
+}
+
+func NewTableForJSONTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_json")
+	builder.MustCreateTable("table_test_json", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name")
+		table.JSON("meta").Null()
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_json").MustInsert([]xun.R{
+		{"name": "John", "meta": `{"address":{"city":"Beijing","country":"CN"},"languages":["en"]}`},
+		{"name": "Lee", "meta": `{"address":{"city":"Shanghai","country":"CN"},"languages":["en","zh"]}`},
+		{"name": "Ken", "meta": `{"address":{"country":"US"},"languages":["fr"]}`},
+	})
+}
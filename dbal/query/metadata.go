@@ -0,0 +1,50 @@
+package query
+
+import "sync"
+
+// Metadata the metadata of the database server read by the builders, it's cached by the connection
+// and could be shared by the connections of the same database, e.g. the connections of the capsule manager.
+type Metadata struct {
+	mutex      sync.RWMutex
+	packetSize *int
+	columns    map[string]map[string]string // the decoding kinds of the columns of the tables
+}
+
+// NewMetadata create a new empty metadata cache
+func NewMetadata() *Metadata {
+	return &Metadata{}
+}
+
+// metadata Get the metadata cache of the connection
+func (builder *Builder) metadata() *Metadata {
+	if builder.Conn.Metadata == nil {
+		builder.Conn.Metadata = NewMetadata()
+	}
+	return builder.Conn.Metadata
+}
+
+// getPacketSize get the maximum size in bytes of one statement, the size given by the connection option comes first,
+// then the limit of the database server, which is read once per connection. 0 if the size is not limited.
+func (builder *Builder) getPacketSize() (int, error) {
+	if builder.Conn.Option != nil && builder.Conn.Option.MaxPacketSize > 0 {
+		return builder.Conn.Option.MaxPacketSize, nil
+	}
+
+	metadata := builder.metadata()
+	metadata.mutex.RLock()
+	size := metadata.packetSize
+	metadata.mutex.RUnlock()
+	if size != nil {
+		return *size, nil
+	}
+
+	packetSize, err := builder.Grammar.GetMaxPacketSize()
+	if err != nil {
+		return 0, err
+	}
+
+	metadata.mutex.Lock()
+	metadata.packetSize = &packetSize
+	metadata.mutex.Unlock()
+	return packetSize, nil
+}
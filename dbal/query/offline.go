@@ -0,0 +1,95 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/utils"
+)
+
+// NewOffline create a new query interface of the given driver without connecting to a database server,
+// the version of the server is assumed. The statements are compiled by the registered grammar of the driver,
+// executing them returns the dbal.ErrOffline error.
+//
+//	qb := query.NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+//	sql, bindings := qb.Table("users").Where("id", 1).ToUpdateSQL(xun.R{"vote": 10})
+func NewOffline(driver string, version dbal.Version, option ...dbal.Option) Query {
+	if version.Driver == "" {
+		version.Driver = driver
+	}
+
+	opt := dbal.Option{}
+	if len(option) > 0 {
+		opt = option[0]
+	}
+	return newOfflineBuilder(driver, &version, &opt)
+}
+
+// newOfflineBuilder create a new query builder instance on an offline connection,
+// the version is not assumed if it's nil, getting the version returns the dbal.ErrOffline error then.
+func newOfflineBuilder(driver string, version *dbal.Version, option *dbal.Option) *Builder {
+	grammar, has := dbal.Grammars[driver]
+	if !has {
+		panic(fmt.Errorf("The %s driver not import", driver))
+	}
+
+	db := dbal.NewOffline(false).Open(driver)
+	conn := &Connection{
+		Write: db,
+		WriteConfig: &dbal.Config{
+			Driver:  driver,
+			Name:    "offline",
+			Version: version,
+		},
+		Read: db,
+		ReadConfig: &dbal.Config{
+			Driver:   driver,
+			Name:     "offline",
+			ReadOnly: true,
+			Version:  version,
+		},
+		Option:   option,
+		Version:  version,
+		Metadata: NewMetadata(),
+	}
+
+	// the OnConnected event is not triggered, there is no database server to set up
+	grammar, err := grammar.NewWithRead(conn.Write, conn.WriteConfig, conn.Read, conn.ReadConfig, conn.Option)
+	if err != nil {
+		panic(fmt.Errorf("grammar setup error. (%s)", err))
+	}
+
+	return &Builder{
+		Mode:     "production",
+		Conn:     conn,
+		Grammar:  grammar,
+		Database: grammar.GetDatabase(),
+		Schema:   grammar.GetSchema(),
+		Query:    dbal.NewQuery(),
+	}
+}
+
+// ToInsertSQL Compile the insert statement of the given records without executing it, all of the rows are inserted by one statement.
+func (builder *Builder) ToInsertSQL(v interface{}, columns ...interface{}) (string, []interface{}) {
+	columns, values := builder.prepareInsertValues(v, columns...)
+	return builder.Grammar.CompileInsert(builder.Query, columns, values)
+}
+
+// ToUpdateSQL Compile the update statement of the given values without executing it.
+func (builder *Builder) ToUpdateSQL(v interface{}) (string, []interface{}) {
+	values := xun.MakeR(v).ToMap()
+	return builder.Grammar.CompileUpdate(builder.mustScoped().Query, values)
+}
+
+// ToUpsertSQL Compile the upsert statement of the given records without executing it, all of the rows are upserted by one statement.
+func (builder *Builder) ToUpsertSQL(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) (string, []interface{}) {
+	columns, values := builder.prepareInsertValues(v, columns...)
+	return builder.Grammar.CompileUpsert(builder.Query, columns, values, utils.Flatten(uniqueBy), update)
+}
+
+// ToDeleteSQL Compile the delete statement without executing it, it panics if the order or the limit of the joined delete is not supported.
+func (builder *Builder) ToDeleteSQL() (string, []interface{}) {
+	utils.PanicIF(builder.checkDelete())
+	return builder.Grammar.CompileDelete(builder.mustScoped().Query)
+}
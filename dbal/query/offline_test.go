@@ -0,0 +1,99 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestOfflineToSQL(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+	qb.Table("users").Select("id", "name").Where("vote", ">", 10).OrderBy("id").Limit(5)
+	assert.Equal(t, `select "id", "name" from "users" where "vote" > $1 order by "id" asc limit 5`, qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{10}, qb.GetBindings(), "the bindings not equal")
+
+	qb = NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")}, dbal.Option{Prefix: "xun_"})
+	qb.Table("users").Select("id").Where("vote", ">", 10)
+	assert.Equal(t, "select `id` from `xun_users` where `vote` > ?", qb.ToSQL(), "the query sql not equal")
+}
+
+func TestOfflineToInsertSQL(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+	sql, bindings := qb.Table("users").ToInsertSQL([][]interface{}{
+		{"ada@example.com", 10},
+		{"bob@example.com", 20},
+	}, "email", "vote")
+	assert.Equal(t, `insert into "users" ("email", "vote") values ($1,$2),($3,$4)`, sql, "the insert sql not equal")
+	assert.Equal(t, []interface{}{"ada@example.com", 10, "bob@example.com", 20}, bindings, "the bindings not equal")
+}
+
+func TestOfflineToUpdateSQL(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	sql, bindings := qb.Table("users").Where("id", 1).ToUpdateSQL(xun.R{"vote": 30})
+	assert.Equal(t, "update `users` set `vote`=? where `id` = ?", sql, "the update sql not equal")
+	assert.Equal(t, []interface{}{30, 1}, bindings, "the bindings not equal")
+}
+
+func TestOfflineToUpsertSQL(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+	sql, _ := qb.Table("users").ToUpsertSQL([][]interface{}{{"ada@example.com", 10}}, "email", []string{"vote"}, "email", "vote")
+	assert.Equal(t, `insert into "users" ("email", "vote") values ($1,$2) on conflict ("email") do update set "vote"=excluded."vote"`, sql, "the upsert sql not equal")
+
+	qb = NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	sql, _ = qb.Table("users").ToUpsertSQL([][]interface{}{{"ada@example.com", 10}}, "email", []string{"vote"}, "email", "vote")
+	assert.Equal(t, "insert into `users` (`email`, `vote`) values (?,?) on duplicate key update `vote`=values(`vote`)", sql, "the upsert sql not equal")
+}
+
+func TestOfflineToDeleteSQL(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	sql, bindings := qb.Table("users").Where("id", 1).ToDeleteSQL()
+	assert.Equal(t, "delete from `users` where `id` = ?", sql, "the delete sql not equal")
+	assert.Equal(t, []interface{}{1}, bindings, "the bindings not equal")
+
+	// the order of the joined delete is not supported by mysql
+	qb = NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	assert.PanicsWithError(t, "the joined delete with the order or the limit is not supported by mysql", func() {
+		qb.Table("users").Join("posts", "posts.user_id", "=", "users.id").OrderBy("users.id").ToDeleteSQL()
+	})
+}
+
+func TestOfflineVersion(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("5.7.30")})
+	version, err := qb.Builder().getVersion()
+	assert.Nil(t, err, "the version should be returned")
+	assert.Equal(t, "mysql", version.Driver, "the driver of the version should be mysql")
+	assert.Equal(t, "5.7.30", version.String(), "the version should be the assumed one")
+
+	version, err = qb.Builder().Grammar.GetVersion()
+	assert.Nil(t, err, "the grammar should return the assumed version")
+	assert.Equal(t, "5.7.30", version.String(), "the version should be the assumed one")
+}
+
+func TestOfflineExecute(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+
+	_, err := qb.Table("users").Get()
+	assert.True(t, errors.Is(err, dbal.ErrOffline), "the Get method should return the ErrOffline error")
+
+	err = qb.Table("users").Insert(xun.R{"email": "ada@example.com"})
+	assert.True(t, errors.Is(err, dbal.ErrOffline), "the Insert method should return the ErrOffline error")
+
+	_, err = qb.Table("users").Where("id", 1).Update(xun.R{"vote": 1})
+	assert.True(t, errors.Is(err, dbal.ErrOffline), "the Update method should return the ErrOffline error")
+
+	_, err = qb.Table("users").Where("id", 1).Delete()
+	assert.True(t, errors.Is(err, dbal.ErrOffline), "the Delete method should return the ErrOffline error")
+
+	err = qb.Transaction(func(qb Query) error { return nil })
+	assert.True(t, errors.Is(err, dbal.ErrOffline), "the Transaction method should return the ErrOffline error")
+}
+
+func TestOfflineNotImported(t *testing.T) {
+	assert.PanicsWithError(t, "The oracle driver not import", func() {
+		NewOffline("oracle", dbal.Version{Version: semver.MustParse("19.0.0")})
+	})
+}
@@ -25,11 +25,13 @@ func (builder *Builder) OrderBy(column interface{}, args ...string) Query {
 		panic(fmt.Errorf(`Order direction must be "asc" or "desc`))
 	}
 
+	var query *dbal.Query
 	if builder.isQueryable(column) {
 		sub, bindings, subQueryOffset := builder.createSub(column)
 		sql := builder.parseSub(sub)
 		offset = subQueryOffset
 		column = dbal.Raw(fmt.Sprintf("(%s)", sql))
+		query = subQuery(sub)
 		builder.Query.AddBinding(orderName, bindings)
 	}
 
@@ -38,6 +40,7 @@ func (builder *Builder) OrderBy(column interface{}, args ...string) Query {
 		Column:    column,
 		Direction: direction,
 		Offset:    offset,
+		Query:     query,
 	}
 
 	if orderName == "unionOrder" {
@@ -1,8 +1,15 @@
 package query
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/yaoapp/xun"
 	"github.com/yaoapp/xun/dbal"
@@ -80,11 +87,152 @@ func (builder *Builder) MustChunk(size int, callback func(items []interface{}, p
 	utils.PanicIF(err)
 }
 
-// ChunkByID chunk the results of a query by comparing IDs.
-func (builder *Builder) ChunkByID() {}
+// ChunkByID chunk the results of a query by comparing IDs (where id > lastID order by id limit size),
+// the column is the ID column used in the where clause, the alias is the key of the ID in the results (same as column if empty).
+func (builder *Builder) ChunkByID(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{}) error {
+	return builder.chunkByID(size, column, alias, false, callback, v...)
+}
 
 // MustChunkByID chunk the results of a query by comparing IDs.
-func (builder *Builder) MustChunkByID() {}
+func (builder *Builder) MustChunkByID(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{}) {
+	err := builder.ChunkByID(size, column, alias, callback, v...)
+	utils.PanicIF(err)
+}
+
+// ChunkByIDDesc chunk the results of a query by comparing IDs in descending order (where id < lastID order by id desc limit size).
+func (builder *Builder) ChunkByIDDesc(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{}) error {
+	return builder.chunkByID(size, column, alias, true, callback, v...)
+}
+
+// MustChunkByIDDesc chunk the results of a query by comparing IDs in descending order.
+func (builder *Builder) MustChunkByIDDesc(size int, column string, alias string, callback func(items []interface{}, page int) error, v ...interface{}) {
+	err := builder.ChunkByIDDesc(size, column, alias, callback, v...)
+	utils.PanicIF(err)
+}
+
+// chunkByID chunk the results of a query by comparing IDs.
+func (builder *Builder) chunkByID(size int, column string, alias string, desc bool, callback func(items []interface{}, page int) error, v ...interface{}) error {
+
+	if column == "" {
+		column = "id"
+	}
+
+	if alias == "" {
+		alias = column
+	}
+
+	if size < 1 {
+		size = 50
+	}
+
+	var lastID interface{} = nil
+	page := 1
+	for {
+
+		var results []interface{} = nil
+		var countResults int
+
+		// We'll execute the query for the given page constrained by the last ID of the
+		// previous chunk, so the database can seek with the index rather than scanning
+		// the skipped rows. The builder is cloned to keep the original query untouched.
+		clone := builder.clone()
+		if desc {
+			clone.forPageBeforeID(size, lastID, column)
+		} else {
+			clone.forPageAfterID(size, lastID, column)
+		}
+
+		if len(v) > 0 {
+
+			reflectValuesPtr := reflect.ValueOf(v[0])
+			reflectValues := reflect.Indirect(reflectValuesPtr)
+			if reflectValues.Kind() != reflect.Slice {
+				return fmt.Errorf("The given binding var shoule be a slice pointer")
+			}
+
+			reflectValuesType := reflectValues.Type()
+			reflectValuesPtr.Elem().Set(reflect.New(reflectValuesType).Elem())
+
+			_, err := clone.Get(v...)
+			if err != nil {
+				return err
+			}
+
+			countResults = reflectValues.Len()
+			for i := 0; i < countResults; i++ {
+				results = append(results, reflectValues.Index(i).Interface())
+			}
+		} else {
+			rows, err := clone.Get()
+			if err != nil {
+				return err
+			}
+
+			countResults = len(rows)
+			for _, row := range rows {
+				results = append(results, row)
+			}
+		}
+
+		if countResults == 0 {
+			break
+		}
+
+		if err := callback(results, page); err != nil {
+			return err
+		}
+
+		// Get the ID of the last record of the chunk, the next chunk will start from it.
+		id, err := builder.getLastID(results[countResults-1], alias)
+		if err != nil {
+			return err
+		}
+		lastID = id
+
+		if countResults != size {
+			break
+		}
+
+		page++
+	}
+
+	return nil
+}
+
+// getLastID get the ID value of the given row (xun.R or struct) by the alias
+func (builder *Builder) getLastID(row interface{}, alias string) (interface{}, error) {
+	id, err := builder.getRowValue(row, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	if id == nil {
+		return nil, fmt.Errorf("The chunkByID operation was aborted because the [%s] column is not present in the query result", alias)
+	}
+	return id, nil
+}
+
+// getRowValue get the value of the given row (xun.R or struct) by the key, returns nil if the key does not exist
+func (builder *Builder) getRowValue(row interface{}, key string) (interface{}, error) {
+	if r, ok := row.(xun.R); ok {
+		return r.Get(key), nil
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(row))
+	if value.Kind() != reflect.Struct {
+		return nil, nil
+	}
+
+	fieldMap, err := builder.getFieldMap(value.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	if field, has := fieldMap[key]; has {
+		return value.FieldByName(field.Name).Interface(), nil
+	}
+	return nil, nil
+}
 
 // Paginate paginate the given query into a simple paginator.
 func (builder *Builder) Paginate(pageSize int, page int, v ...interface{}) (xun.P, error) {
@@ -132,35 +280,146 @@ func (builder *Builder) MustPaginate(pageSize int, page int, v ...interface{}) x
 	return res
 }
 
+// CursorPaginate paginate the given query into a cursor paginator. The query must be ordered by
+// one or more columns (the last one should be unique, e.g. the primary key), the values of the
+// ordering columns of the last (or first) row are encoded into an opaque and signed cursor.
+// The cursors are signed with the CursorKey of the connection option, which is required.
+func (builder *Builder) CursorPaginate(pageSize int, cursor string, v ...interface{}) (xun.CP, error) {
+
+	if pageSize < 1 {
+		pageSize = 15
+	}
+
+	key, err := builder.cursorKey()
+	if err != nil {
+		return xun.MakeCP(pageSize, cursor, "", ""), err
+	}
+
+	orders, err := builder.getCursorOrders()
+	if err != nil {
+		return xun.MakeCP(pageSize, cursor, "", ""), err
+	}
+
+	var current *paginationCursor = nil
+	if cursor != "" {
+		current, err = builder.decodeCursor(key, cursor, orders)
+		if err != nil {
+			return xun.MakeCP(pageSize, cursor, "", ""), err
+		}
+	}
+
+	// Fetch one more row than the page size to determine if there are more rows in the
+	// scanning direction. When paging backwards, the order directions will be reversed
+	// and the rows will be reversed back after the query was executed.
+	clone := builder.clone()
+	clone.Query.Orders = []dbal.Order{}
+	clone.Query.Bindings["order"] = []interface{}{}
+	backward := current != nil && !current.Next
+	for _, order := range orders {
+		direction := order.Direction
+		if backward {
+			direction = builder.reverseDirection(direction)
+		}
+		clone.OrderBy(order.Column, direction)
+	}
+
+	if current != nil {
+		clone.whereCursor(orders, current.Values, 0, backward)
+	}
+
+	items := []interface{}{}
+	rows, err := clone.Limit(pageSize + 1).Get(v...)
+	if err != nil {
+		return xun.MakeCP(pageSize, cursor, "", ""), err
+	}
+
+	if rows != nil {
+		for _, row := range rows {
+			items = append(items, row)
+		}
+	} else if len(v) > 0 && reflect.TypeOf(v[0]).Kind() == reflect.Ptr {
+		reflectRows := reflect.Indirect(reflect.ValueOf(v[0]))
+		if reflectRows.Kind() != reflect.Slice {
+			return xun.MakeCP(pageSize, cursor, "", ""), fmt.Errorf("The given binding var shoule be a slice pointer")
+		}
+		for i := 0; i < reflectRows.Len(); i++ {
+			items = append(items, reflectRows.Index(i).Interface())
+		}
+	}
+
+	hasMore := len(items) > pageSize
+	if hasMore {
+		items = items[:pageSize]
+	}
+
+	if backward {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	if len(items) == 0 {
+		return xun.MakeCP(pageSize, cursor, "", ""), nil
+	}
+
+	next := ""
+	if hasMore || backward {
+		next, err = builder.encodeCursor(key, items[len(items)-1], orders, true)
+		if err != nil {
+			return xun.MakeCP(pageSize, cursor, "", ""), err
+		}
+	}
+
+	prev := ""
+	if current != nil && (hasMore || !backward) {
+		prev, err = builder.encodeCursor(key, items[0], orders, false)
+		if err != nil {
+			return xun.MakeCP(pageSize, cursor, "", ""), err
+		}
+	}
+
+	return xun.MakeCP(pageSize, cursor, next, prev, items...), nil
+}
+
+// MustCursorPaginate paginate the given query into a cursor paginator.
+func (builder *Builder) MustCursorPaginate(pageSize int, cursor string, v ...interface{}) xun.CP {
+	res, err := builder.CursorPaginate(pageSize, cursor, v...)
+	utils.PanicIF(err)
+	return res
+}
+
 // Set the limit and offset for a given page.
 func (builder *Builder) forPage(page int, pageSize int) Query {
 	return builder.Offset((page - 1) * pageSize).Limit(pageSize)
 }
 
-// @todo: forPageBeforeID  Constrain the query to the previous "page" of results before a given ID.
-// func (builder *Builder) forPageBeforeID(pageSize int, lastID int, column string) Query {
-// 	builder.Query.Orders = builder.removeExistingOrdersFor(column)
-// 	if lastID != 0 {
-// 		builder.Where(column, "<", lastID)
-// 	}
-// 	return builder.OrderBy(column, "desc").Limit(pageSize)
-// }
-
-// @todo: forPageAfterID  Constrain the query to the next "page" of results after a given ID.
-// func (builder *Builder) forPageAfterID(pageSize int, lastID int, column string) Query {
-// 	builder.Query.Orders = builder.removeExistingOrdersFor(column)
-// 	if lastID != 0 {
-// 		builder.Where(column, ">", lastID)
-// 	}
-// 	return builder.OrderBy(column, "asc").Limit(pageSize)
-// }
+// forPageBeforeID  Constrain the query to the previous "page" of results before a given ID.
+func (builder *Builder) forPageBeforeID(pageSize int, lastID interface{}, column string) Query {
+	builder.Query.Orders = builder.removeExistingOrdersFor(column)
+	if lastID != nil {
+		builder.Where(column, "<", lastID)
+	}
+	return builder.OrderBy(column, "desc").Limit(pageSize)
+}
+
+// forPageAfterID  Constrain the query to the next "page" of results after a given ID.
+func (builder *Builder) forPageAfterID(pageSize int, lastID interface{}, column string) Query {
+	builder.Query.Orders = builder.removeExistingOrdersFor(column)
+	if lastID != nil {
+		builder.Where(column, ">", lastID)
+	}
+	return builder.OrderBy(column, "asc").Limit(pageSize)
+}
 
 // getCountForPagination  Get the count of the total records for the paginator.
 func (builder *Builder) getCountForPagination(columns []interface{}) (int, error) {
 
 	if len(builder.Query.Groups) > 0 || len(builder.Query.Havings) > 0 {
 		aggregate := 0
-		clone := builder.cloneForPaginationCount()
+		clone, err := builder.cloneForPaginationCount().scoped()
+		if err != nil {
+			return 0, err
+		}
 		if len(clone.Query.Columns) == 0 && len(builder.Query.Joins) > 0 {
 			if len(builder.Query.Groups) > 0 {
 				clone.Select(builder.Query.Groups)
@@ -171,7 +430,7 @@ func (builder *Builder) getCountForPagination(columns []interface{}) (int, error
 			}
 		}
 
-		_, err := builder.new().
+		_, err = builder.new().
 			mergeBindings(clone).
 			setAggregate("count", builder.withoutSelectAliases(columns)).
 			FromRaw(fmt.Sprintf("(%s) as %s", clone.ToSQL(), builder.Grammar.Wrap("aggregate_table"))).
@@ -207,3 +466,256 @@ func (builder *Builder) cloneForPaginationCount() *Builder {
 	new.Query.Bindings["order"] = []interface{}{}
 	return new
 }
+
+// paginationCursor the decoded pagination cursor
+type paginationCursor struct {
+	Columns []string      `json:"c"`
+	Values  []interface{} `json:"-"`
+	Raw     []cursorValue `json:"v"`
+	Next    bool          `json:"n"`
+}
+
+// cursorValue the typed value of the ordering column
+type cursorValue struct {
+	Type  string `json:"t"`
+	Value string `json:"v,omitempty"`
+}
+
+// cursorKey get the key for signing the cursors from the connection option, the cursors should be
+// verifiable by all of the processes sharing the database, so there is no default key.
+func (builder *Builder) cursorKey() ([]byte, error) {
+	if builder.Conn == nil || builder.Conn.Option == nil || builder.Conn.Option.CursorKey == "" {
+		return nil, fmt.Errorf("The cursor pagination requires a cursor key, set the CursorKey of the connection option")
+	}
+	return []byte(builder.Conn.Option.CursorKey), nil
+}
+
+// getCursorOrders get the orders for the cursor pagination, all of the orders should be the basic orders by column names.
+func (builder *Builder) getCursorOrders() ([]dbal.Order, error) {
+	builder.enforceOrderBy()
+	if len(builder.Query.Unions) > 0 {
+		return nil, fmt.Errorf("The cursor pagination does not support union queries")
+	}
+
+	for _, order := range builder.Query.Orders {
+		if _, ok := order.Column.(string); !ok || order.Type != "basic" {
+			return nil, fmt.Errorf("The cursor pagination only supports ordering by columns")
+		}
+	}
+	return builder.Query.Orders, nil
+}
+
+// getCursorColumnKey get the key of the ordering column in the query results. e.g. "t1.id" -> "id"
+func (builder *Builder) getCursorColumnKey(column string) string {
+	if i := strings.LastIndex(column, "."); i >= 0 {
+		return column[i+1:]
+	}
+	return column
+}
+
+// reverseDirection reverse the order direction
+func (builder *Builder) reverseDirection(direction string) string {
+	if direction == "desc" {
+		return "asc"
+	}
+	return "desc"
+}
+
+// cursorNullsFirst Determine if the null values are sorted first in the given direction,
+// postgres treats null as the largest value, mysql, sqlite3 and hdb treat null as the smallest.
+func (builder *Builder) cursorNullsFirst(direction string) bool {
+	driver, _ := builder.Driver()
+	if strings.HasPrefix(driver, "postgres") {
+		return direction == "desc"
+	}
+	return direction == "asc"
+}
+
+// whereCursor Add the where clauses to seek the rows after the cursor values in the scanning direction.
+// For the orders (c1, c2) it produces: (c1 > v1) or (c1 = v1 and (c2 > v2)), the operator follows the
+// direction of each order and the null values are handled according to the null ordering of the driver.
+func (builder *Builder) whereCursor(orders []dbal.Order, values []interface{}, index int, backward bool) {
+	builder.Where(func(qb Query) {
+		column := orders[index].Column.(string)
+		value := values[index]
+		direction := orders[index].Direction
+		if backward {
+			direction = builder.reverseDirection(direction)
+		}
+
+		operator := ">"
+		if direction == "desc" {
+			operator = "<"
+		}
+
+		// the rows after the value
+		hasAfter := true
+		nullsFirst := builder.cursorNullsFirst(direction)
+		if value == nil && nullsFirst {
+			qb.OrWhereNotNull(column)
+		} else if value == nil {
+			hasAfter = false
+		} else if nullsFirst {
+			qb.OrWhere(column, operator, value)
+		} else {
+			qb.OrWhere(column, operator, value).OrWhereNull(column)
+		}
+
+		// the rows equal to the value, comparing with the next ordering column
+		if index < len(orders)-1 {
+			qb.OrWhere(func(qb Query) {
+				if value == nil {
+					qb.WhereNull(column)
+				} else {
+					qb.Where(column, value)
+				}
+				qb.Builder().whereCursor(orders, values, index+1, backward)
+			})
+		} else if !hasAfter {
+			qb.WhereRaw("1 = 0")
+		}
+	})
+}
+
+// encodeCursor encode the values of the ordering columns of the given row into a signed cursor
+func (builder *Builder) encodeCursor(key []byte, row interface{}, orders []dbal.Order, next bool) (string, error) {
+	cursor := paginationCursor{Columns: []string{}, Raw: []cursorValue{}, Next: next}
+	for _, order := range orders {
+		column := order.Column.(string)
+		key := builder.getCursorColumnKey(column)
+		value, err := builder.getRowValue(row, key)
+		if err != nil {
+			return "", err
+		}
+
+		if r, ok := row.(xun.R); ok && !r.Has(key) {
+			return "", fmt.Errorf("The cursor pagination was aborted because the [%s] column is not present in the query result", key)
+		}
+
+		cursor.Columns = append(cursor.Columns, column)
+		cursor.Raw = append(cursor.Raw, builder.makeCursorValue(value))
+	}
+
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.%s",
+		base64.RawURLEncoding.EncodeToString(payload),
+		base64.RawURLEncoding.EncodeToString(builder.signCursor(key, payload)),
+	), nil
+}
+
+// decodeCursor decode and verify the given cursor, the cursor should be created by the query with the same orders
+func (builder *Builder) decodeCursor(key []byte, cursor string, orders []dbal.Order) (*paginationCursor, error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("The cursor is invalid")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("The cursor is invalid")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, builder.signCursor(key, payload)) {
+		return nil, fmt.Errorf("The cursor is invalid")
+	}
+
+	res := &paginationCursor{}
+	err = json.Unmarshal(payload, res)
+	if err != nil {
+		return nil, fmt.Errorf("The cursor is invalid")
+	}
+
+	if len(res.Columns) != len(orders) || len(res.Raw) != len(orders) {
+		return nil, fmt.Errorf("The cursor does not match the orders of the query")
+	}
+
+	res.Values = []interface{}{}
+	for i, order := range orders {
+		if res.Columns[i] != order.Column.(string) {
+			return nil, fmt.Errorf("The cursor does not match the orders of the query")
+		}
+		value, err := builder.parseCursorValue(res.Raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("The cursor is invalid")
+		}
+		res.Values = append(res.Values, value)
+	}
+	return res, nil
+}
+
+// signCursor sign the cursor payload with HMAC-SHA256
+func (builder *Builder) signCursor(key []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// makeCursorValue make a typed cursor value
+func (builder *Builder) makeCursorValue(value interface{}) cursorValue {
+	switch v := value.(type) {
+	case nil:
+		return cursorValue{Type: "null"}
+	case xun.N:
+		return builder.makeCursorValue(v.Number)
+	case xun.T:
+		return builder.makeCursorValue(v.Time)
+	case *xun.N:
+		if v == nil {
+			return cursorValue{Type: "null"}
+		}
+		return builder.makeCursorValue(v.Number)
+	case *xun.T:
+		if v == nil {
+			return cursorValue{Type: "null"}
+		}
+		return builder.makeCursorValue(v.Time)
+	case time.Time:
+		return cursorValue{Type: "time", Value: v.Format(time.RFC3339Nano)}
+	case bool:
+		return cursorValue{Type: "bool", Value: strconv.FormatBool(v)}
+	case []byte:
+		return cursorValue{Type: "string", Value: string(v)}
+	case string:
+		return cursorValue{Type: "string", Value: v}
+	case float32, float64:
+		return cursorValue{Type: "float", Value: fmt.Sprintf("%v", v)}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return cursorValue{Type: "int", Value: fmt.Sprintf("%d", v)}
+	}
+
+	reflectValue := reflect.ValueOf(value)
+	if reflectValue.Kind() == reflect.Ptr {
+		if reflectValue.IsNil() {
+			return cursorValue{Type: "null"}
+		}
+		return builder.makeCursorValue(reflectValue.Elem().Interface())
+	}
+	return cursorValue{Type: "string", Value: fmt.Sprintf("%v", value)}
+}
+
+// parseCursorValue parse the typed cursor value
+func (builder *Builder) parseCursorValue(value cursorValue) (interface{}, error) {
+	switch value.Type {
+	case "null":
+		return nil, nil
+	case "time":
+		return time.Parse(time.RFC3339Nano, value.Value)
+	case "bool":
+		return strconv.ParseBool(value.Value)
+	case "string":
+		return value.Value, nil
+	case "float":
+		return strconv.ParseFloat(value.Value, 64)
+	case "int":
+		if v, err := strconv.ParseInt(value.Value, 10, 64); err == nil {
+			return v, nil
+		}
+		return strconv.ParseUint(value.Value, 10, 64)
+	}
+	return nil, fmt.Errorf("the cursor value type %s is invalid", value.Type)
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -293,11 +294,279 @@ func TestPaginateChunkWithBindError(t *testing.T) {
 	})
 }
 
+func TestPaginateChunkByID(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_paginate").
+		Where("email", "like", "%@yao.run").
+		Select("id", "name", "email", "vote", "score", "status")
+	hits := 0
+	pages := []int{}
+	IDs := []int64{}
+	qb.MustChunkByID(3, "id", "", func(items []interface{}, page int) error {
+		hits = hits + len(items)
+		pages = append(pages, page)
+		for _, item := range items {
+			IDs = append(IDs, item.(xun.R).Get("id").(int64))
+		}
+		return nil
+	})
+	assert.Equal(t, 4, hits, "The chunk items hits should be 4")
+	assert.Equal(t, []int{1, 2}, pages, "The chunk pages should be []int{1, 2}")
+	assert.Equal(t, []int64{1, 2, 3, 4}, IDs, "The chunk id of items ids should be []int64{1,2,3,4}")
+	assert.Equal(t, 1, len(qb.Builder().Query.Wheres), "The original query should not be changed")
+}
+
+func TestPaginateChunkByIDDesc(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_paginate as t1").
+		Where("t1.email", "like", "%@yao.run").
+		Select("t1.id as t1_id", "t1.name")
+	IDs := []int64{}
+	qb.MustChunkByIDDesc(2, "t1.id", "t1_id", func(items []interface{}, page int) error {
+		for _, item := range items {
+			IDs = append(IDs, item.(xun.R).Get("t1_id").(int64))
+		}
+		return nil
+	})
+	assert.Equal(t, []int64{4, 3, 2, 1}, IDs, "The chunk id of items ids should be []int64{4,3,2,1}")
+}
+
+func TestPaginateChunkByIDUpdate(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	IDs := []int64{}
+	qb.Table("table_test_paginate").Where("vote", "<", 100).MustChunkByID(1, "id", "", func(items []interface{}, page int) error {
+		for _, item := range items {
+			id := item.(xun.R).Get("id").(int64)
+			IDs = append(IDs, id)
+			qb.New().Table("table_test_paginate").Where("id", id).MustUpdate(xun.R{"vote": 200})
+		}
+		return nil
+	})
+	assert.Equal(t, []int64{1, 2, 4}, IDs, "The chunk id of items ids should be []int64{1,2,4}")
+}
+
+func TestPaginateChunkByIDWithBind(t *testing.T) {
+
+	type Item struct {
+		ID            int64
+		Email         string
+		Score         float64
+		Vote          int
+		PaymentStatus string `json:"status"`
+	}
+
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_paginate").
+		Where("email", "like", "%@yao.run").
+		Select("id", "email", "vote", "score", "status")
+	IDs := []int64{}
+	qb.MustChunkByID(2, "id", "", func(items []interface{}, page int) error {
+		for _, item := range items {
+			IDs = append(IDs, item.(Item).ID)
+		}
+		return nil
+	}, &[]Item{})
+	assert.Equal(t, []int64{1, 2, 3, 4}, IDs, "The chunk id of items ids should be []int64{1,2,3,4}")
+}
+
+func TestPaginateChunkByIDError(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_paginate").Select("name")
+	assert.PanicsWithError(t, "The chunkByID operation was aborted because the [id] column is not present in the query result", func() {
+		qb.MustChunkByID(2, "id", "", func(items []interface{}, page int) error {
+			return nil
+		})
+	})
+}
+
+func TestPaginateCursorPaginate(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	defer setTestCursorKey(qb)()
+	qb.Table("table_test_paginate").
+		Where("email", "like", "%@yao.run").
+		Select("id", "name", "email", "vote").
+		OrderBy("id")
+
+	first := qb.MustCursorPaginate(2, "")
+	assert.Equal(t, []int64{1, 2}, getCursorPaginateIDs(first), "The items ids should be []int64{1,2}")
+	assert.NotEqual(t, "", first.NextCursor, "The next cursor should not be empty")
+	assert.Equal(t, "", first.PreviousCursor, "The previous cursor should be empty")
+
+	second := qb.MustCursorPaginate(2, first.NextCursor)
+	assert.Equal(t, []int64{3, 4}, getCursorPaginateIDs(second), "The items ids should be []int64{3,4}")
+	assert.Equal(t, "", second.NextCursor, "The next cursor should be empty")
+	assert.NotEqual(t, "", second.PreviousCursor, "The previous cursor should not be empty")
+
+	prev := qb.MustCursorPaginate(2, second.PreviousCursor)
+	assert.Equal(t, []int64{1, 2}, getCursorPaginateIDs(prev), "The items ids should be []int64{1,2}")
+	assert.NotEqual(t, "", prev.NextCursor, "The next cursor should not be empty")
+	assert.Equal(t, "", prev.PreviousCursor, "The previous cursor should be empty")
+}
+
+func TestPaginateCursorPaginateWithBind(t *testing.T) {
+
+	type Item struct {
+		ID    int64
+		Email string
+		Vote  int
+	}
+
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	defer setTestCursorKey(qb)()
+	qb.Table("table_test_paginate").
+		Select("id", "email", "vote").
+		OrderByDesc("vote").
+		OrderBy("id")
+
+	IDs := []int64{}
+	cursor := ""
+	for {
+		paginator := qb.MustCursorPaginate(3, cursor, &[]Item{})
+		for _, item := range paginator.Items {
+			IDs = append(IDs, item.(Item).ID)
+		}
+		if paginator.NextCursor == "" {
+			break
+		}
+		cursor = paginator.NextCursor
+	}
+	assert.Equal(t, []int64{3, 1, 4, 2}, IDs, "The items ids should be []int64{3,1,4,2}")
+}
+
+func TestPaginateCursorPaginateNullable(t *testing.T) {
+	NewTableForCursorPaginateTest()
+	qb := getTestBuilder()
+	defer setTestCursorKey(qb)()
+	for _, direction := range []string{"asc", "desc"} {
+		qb.Table("table_test_paginate_cursor").
+			Select("id", "rank", "vote").
+			OrderBy("rank", direction).
+			OrderByDesc("vote").
+			OrderBy("id")
+
+		expected := []int64{}
+		for _, row := range qb.MustGet() {
+			expected = append(expected, row.Get("id").(int64))
+		}
+
+		// forward
+		IDs := []int64{}
+		cursors := []string{""}
+		paginator := qb.MustCursorPaginate(2, "")
+		for {
+			IDs = append(IDs, getCursorPaginateIDs(paginator)...)
+			if paginator.NextCursor == "" {
+				break
+			}
+			cursors = append(cursors, paginator.NextCursor)
+			paginator = qb.MustCursorPaginate(2, paginator.NextCursor)
+		}
+		assert.Equal(t, expected, IDs, "The items ids should be same as the query results (%s)", direction)
+		assert.Equal(t, 5, len(cursors), "The pages should be 5 (%s)", direction)
+
+		// backward
+		IDs = getCursorPaginateIDs(paginator)
+		for paginator.PreviousCursor != "" {
+			paginator = qb.MustCursorPaginate(2, paginator.PreviousCursor)
+			IDs = append(getCursorPaginateIDs(paginator), IDs...)
+		}
+		assert.Equal(t, expected, IDs, "The items ids should be same as the query results (%s)", direction)
+	}
+}
+
+func TestPaginateCursorPaginateError(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	defer setTestCursorKey(qb)()
+	qb.Table("table_test_paginate").OrderBy("id")
+	paginator := qb.MustCursorPaginate(2, "")
+
+	_, err := qb.CursorPaginate(2, paginator.NextCursor+"x")
+	assert.Equal(t, "The cursor is invalid", err.Error())
+
+	payload := strings.Split(paginator.NextCursor, ".")
+	_, err = qb.CursorPaginate(2, "eyJjIjpbImlkIl0sInYiOlt7InQiOiJpbnQiLCJ2IjoiMTAifV0sIm4iOnRydWV9."+payload[1])
+	assert.Equal(t, "The cursor is invalid", err.Error())
+
+	qb.Table("table_test_paginate").OrderBy("vote").OrderBy("id")
+	_, err = qb.CursorPaginate(2, paginator.NextCursor)
+	assert.Equal(t, "The cursor does not match the orders of the query", err.Error())
+
+	qb.Table("table_test_paginate").OrderByRaw("id desc")
+	_, err = qb.CursorPaginate(2, "")
+	assert.Equal(t, "The cursor pagination only supports ordering by columns", err.Error())
+}
+
+func TestPaginateCursorPaginateWithoutKey(t *testing.T) {
+	NewTableForPaginateTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_paginate").OrderBy("id")
+	_, err := qb.CursorPaginate(2, "")
+	assert.Equal(t, "The cursor pagination requires a cursor key, set the CursorKey of the connection option", err.Error())
+
+	// the cursors signed with the other key are invalid
+	reset := setTestCursorKey(qb)
+	paginator := qb.MustCursorPaginate(2, "")
+	reset()
+
+	qb.Builder().Conn.Option.CursorKey = "the-other-cursor-key"
+	defer reset()
+	_, err = qb.CursorPaginate(2, paginator.NextCursor)
+	assert.Equal(t, "The cursor is invalid", err.Error())
+}
+
+// setTestCursorKey set the cursor key of the test connection, returns the function to unset it
+func setTestCursorKey(qb Query) func() {
+	option := qb.Builder().Conn.Option
+	option.CursorKey = "the-test-cursor-key"
+	return func() { option.CursorKey = "" }
+}
+
+func getCursorPaginateIDs(paginator xun.CP) []int64 {
+	IDs := []int64{}
+	for _, item := range paginator.Items {
+		IDs = append(IDs, item.(xun.R).Get("id").(int64))
+	}
+	return IDs
+}
+
+func NewTableForCursorPaginateTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_paginate_cursor")
+	builder.MustCreateTable("table_test_paginate_cursor", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("rank").Null()
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_paginate_cursor").MustInsert([]xun.R{
+		{"rank": 2, "vote": 1},
+		{"rank": nil, "vote": 3},
+		{"rank": 1, "vote": 2},
+		{"rank": 2, "vote": 5},
+		{"rank": nil, "vote": 3},
+		{"rank": 3, "vote": 1},
+		{"rank": 1, "vote": 2},
+		{"rank": nil, "vote": 9},
+		{"rank": 2, "vote": 1},
+	})
+}
+
 // clean the test data
 func TestPaginateClean(t *testing.T) {
 	builder := getTestSchemaBuilder()
 	builder.DropTableIfExists("table_test_paginate")
 	builder.DropTableIfExists("table_test_paginate_t2")
+	builder.DropTableIfExists("table_test_paginate_cursor")
 }
 
 func NewTableForPaginateTest() {
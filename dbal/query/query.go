@@ -19,19 +19,36 @@ func (builder *Builder) Table(name string) Query {
 
 // Get Execute the query as a "select" statement.
 func (builder *Builder) Get(v ...interface{}) ([]xun.R, error) {
-	db := builder.DB()
-	stmt, err := db.Prepare(builder.ToSQL())
+	builder, err := builder.scoped()
 	if err != nil {
-		defer log.With(log.F{"bindings": builder.GetBindings()}).Error("builder get sql:%s",builder.ToSQL())
 		return nil, err
 	}
-	defer log.With(log.F{"bindings": builder.GetBindings()}).Trace("builder get sql:%s",builder.ToSQL())
+
+	err = builder.checkWindowFunctions()
+	if err != nil {
+		return nil, err
+	}
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	sql := builder.ToSQL()
+	bindings := builder.GetBindings()
+	db := builder.executor()
+	stmt, err := db.Prepare(sql)
+	if err != nil {
+		defer log.With(log.F{"bindings": bindings}).Error("builder get sql:%s", sql)
+		return nil, builder.translateError(err)
+	}
+	defer log.With(log.F{"bindings": bindings}).Trace("builder get sql:%s", sql)
 
 	defer stmt.Close()
 
-	rows, err := stmt.Query(builder.GetBindings()...)
+	rows, err := stmt.QueryContext(builder.Context(), bindings...)
 	if err != nil {
-		return nil, err
+		return nil, builder.translateError(err)
 	}
 
 	if len(v) == 1 && v[0] != nil {
@@ -40,12 +57,16 @@ func (builder *Builder) Get(v ...interface{}) ([]xun.R, error) {
 		}
 		err := builder.structScan(rows, v[0])
 		if err != nil {
-			return nil, err
+			return nil, builder.translateError(err)
 		}
 		return nil, nil
 	}
 
-	return builder.mapScan(rows)
+	res, err := builder.mapScan(rows)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
+	return res, nil
 }
 
 // MustGet Execute the query as a "select" statement.
@@ -76,27 +97,41 @@ func (builder *Builder) MustFirst(v ...interface{}) xun.R {
 
 // ToSQL Get the SQL representation of the query.
 func (builder *Builder) ToSQL() string {
-	return builder.Grammar.CompileSelect(builder.Query)
+	return builder.Grammar.CompileSelect(builder.mustScoped().Query)
 }
 
 // GetBindings Get the current query value bindings in a flattened array.
 func (builder *Builder) GetBindings() []interface{} {
-	return builder.Query.GetBindings()
+	return builder.mustScoped().Query.GetBindings()
 }
 
 // Exists Determine if any rows exist for the current query.
 func (builder *Builder) Exists() (bool, error) {
+	builder, err := builder.scoped()
+	if err != nil {
+		return false, err
+	}
+
+	err = builder.checkWindowFunctions()
+	if err != nil {
+		return false, err
+	}
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return false, err
+	}
+	defer cancel()
 	sql := builder.Grammar.CompileExists(builder.Query)
 
-	db := builder.DB()
+	db := builder.executor()
 	rows, err := db.Query(sql, builder.GetBindings()...)
 	if err != nil {
-		return false, err
+		return false, builder.translateError(err)
 	}
 
 	res, err := builder.mapScan(rows)
 	if err != nil {
-		return false, err
+		return false, builder.translateError(err)
 	}
 
 	if len(res) == 1 {
@@ -0,0 +1,161 @@
+package query
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/utils"
+)
+
+// InsertReturning Insert new records into the database and return the given columns of the inserted rows,
+// all the columns are returned if no column given. The generated ids and defaults come back in one round trip.
+//
+//	InsertReturning([]xun.R{{"email": "a@example.com"}, {"email": "b@example.com"}}, "id", "created_at")
+func (builder *Builder) InsertReturning(v interface{}, columns ...interface{}) ([]xun.R, error) {
+	err := builder.checkReturning()
+	if err != nil {
+		return nil, err
+	}
+
+	insertColumns, values := builder.prepareInsertValues(v)
+	sql, bindings := builder.Grammar.CompileInsert(builder.Query, insertColumns, values)
+	return builder.runReturning(sql, bindings, columns)
+}
+
+// MustInsertReturning Insert new records into the database and return the given columns of the inserted rows.
+func (builder *Builder) MustInsertReturning(v interface{}, columns ...interface{}) []xun.R {
+	rows, err := builder.InsertReturning(v, columns...)
+	utils.PanicIF(err)
+	return rows
+}
+
+// UpdateReturning Update records in the database and return the given columns of the updated rows,
+// all the columns are returned if no column given.
+func (builder *Builder) UpdateReturning(v interface{}, columns ...interface{}) ([]xun.R, error) {
+	err := builder.checkReturning()
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = builder.scoped()
+	if err != nil {
+		return nil, err
+	}
+
+	values := xun.MakeR(v).ToMap()
+	sql, bindings := builder.Grammar.CompileUpdate(builder.Query, values)
+	return builder.runReturning(sql, bindings, columns)
+}
+
+// MustUpdateReturning Update records in the database and return the given columns of the updated rows.
+func (builder *Builder) MustUpdateReturning(v interface{}, columns ...interface{}) []xun.R {
+	rows, err := builder.UpdateReturning(v, columns...)
+	utils.PanicIF(err)
+	return rows
+}
+
+// DeleteReturning Delete records from the database and return the given columns of the deleted rows,
+// all the columns are returned if no column given.
+func (builder *Builder) DeleteReturning(columns ...interface{}) ([]xun.R, error) {
+	err := builder.checkReturning()
+	if err != nil {
+		return nil, err
+	}
+
+	builder, err = builder.scoped()
+	if err != nil {
+		return nil, err
+	}
+
+	err = builder.checkDelete()
+	if err != nil {
+		return nil, err
+	}
+
+	sql, bindings := builder.Grammar.CompileDelete(builder.Query)
+	return builder.runReturning(sql, bindings, columns)
+}
+
+// MustDeleteReturning Delete records from the database and return the given columns of the deleted rows.
+func (builder *Builder) MustDeleteReturning(columns ...interface{}) []xun.R {
+	rows, err := builder.DeleteReturning(columns...)
+	utils.PanicIF(err)
+	return rows
+}
+
+// UpsertReturning Upsert new records or update the existing ones, and return the given columns of the affected rows,
+// all the columns are returned if no column given.
+func (builder *Builder) UpsertReturning(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) ([]xun.R, error) {
+	err := builder.checkReturning()
+	if err != nil {
+		return nil, err
+	}
+
+	insertColumns, values := builder.prepareInsertValues(v)
+	sql, bindings := builder.Grammar.CompileUpsert(builder.Query, insertColumns, values, utils.Flatten(uniqueBy), update)
+	return builder.runReturning(sql, bindings, columns)
+}
+
+// MustUpsertReturning Upsert new records or update the existing ones, and return the given columns of the affected rows.
+func (builder *Builder) MustUpsertReturning(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) []xun.R {
+	rows, err := builder.UpsertReturning(v, uniqueBy, update, columns...)
+	utils.PanicIF(err)
+	return rows
+}
+
+// checkReturning Return an error if the database server does not support the "returning" clause
+func (builder *Builder) checkReturning() error {
+	version, err := builder.getVersion()
+	if err != nil {
+		return err
+	}
+
+	if !builder.Grammar.SupportsReturning(version) {
+		return fmt.Errorf("the returning clause is not supported by %s %s", version.Driver, version.String())
+	}
+	return nil
+}
+
+// runReturning Execute the statement with the "returning" clause and scan the returned rows
+func (builder *Builder) runReturning(sql string, bindings []interface{}, columns []interface{}) ([]xun.R, error) {
+	sql = fmt.Sprintf("%s %s", sql, builder.Grammar.CompileReturning(builder.prepareColumns(columns...)))
+	defer log.With(log.F{"bindings": bindings}).Debug(sql)
+
+	builder.UseWrite()
+	builder, cancel, err := builder.withTimeout()
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	stmt, err := builder.executor().Prepare(sql)
+	if err != nil {
+		return nil, builder.translateError(err)
+	}
+	defer stmt.Close()
+
+	// the statement is executed for each row when the bindings are grouped by rows
+	groups := [][]interface{}{bindings}
+	if len(bindings) > 0 && reflect.TypeOf(bindings[0]) == reflect.TypeOf([]interface{}{}) {
+		groups = [][]interface{}{}
+		for _, row := range bindings {
+			groups = append(groups, row.([]interface{}))
+		}
+	}
+
+	res := []xun.R{}
+	for _, group := range groups {
+		rows, err := stmt.QueryContext(builder.Context(), group...)
+		if err != nil {
+			return nil, builder.translateError(err)
+		}
+
+		returned, err := builder.mapScan(rows)
+		if err != nil {
+			return nil, builder.translateError(err)
+		}
+		res = append(res, returned...)
+	}
+	return res, nil
+}
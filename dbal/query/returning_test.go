@@ -0,0 +1,128 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestReturningInsertReturning(t *testing.T) {
+	NewTableForReturningTest()
+	qb := getTestBuilder()
+	rows, err := qb.Table("table_test_returning").InsertReturning([]xun.R{
+		{"email": "ada@example.com", "vote": 10},
+		{"email": "bob@example.com"},
+	}, "id", "email", "vote")
+	if !checkReturningSupported(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "1", fmt.Sprintf("%v", rows[0].Get("id")), "the id of 1st row should be 1")
+		assert.Equal(t, "ada@example.com", rows[0].Get("email"), "the email of 1st row should be ada@example.com")
+		assert.Equal(t, "2", fmt.Sprintf("%v", rows[1].Get("id")), "the id of 2nd row should be 2")
+		assert.Equal(t, "5", fmt.Sprintf("%v", rows[1].Get("vote")), "the vote of 2nd row should be the default 5")
+	}
+}
+
+func TestReturningUpdateReturning(t *testing.T) {
+	NewTableForReturningTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_returning").MustInsert([]xun.R{
+		{"email": "ada@example.com", "vote": 10},
+		{"email": "bob@example.com", "vote": 20},
+	})
+
+	rows, err := qb.Table("table_test_returning").Where("vote", ">", 15).UpdateReturning(xun.R{"vote": 30}, "email", "vote")
+	if !checkReturningSupported(t, err) {
+		return
+	}
+
+	assert.Equal(t, 1, len(rows), "the return value should has 1 row")
+	if len(rows) == 1 {
+		assert.Equal(t, "bob@example.com", rows[0].Get("email"), "the email of the row should be bob@example.com")
+		assert.Equal(t, "30", fmt.Sprintf("%v", rows[0].Get("vote")), "the vote of the row should be 30")
+	}
+}
+
+func TestReturningDeleteReturning(t *testing.T) {
+	NewTableForReturningTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_returning").MustInsert([]xun.R{
+		{"email": "ada@example.com", "vote": 10},
+		{"email": "bob@example.com", "vote": 20},
+	})
+
+	rows, err := qb.Table("table_test_returning").Where("email", "ada@example.com").DeleteReturning()
+	if !checkReturningSupported(t, err) {
+		return
+	}
+
+	assert.Equal(t, 1, len(rows), "the return value should has 1 row")
+	if len(rows) == 1 {
+		assert.Equal(t, "ada@example.com", rows[0].Get("email"), "the email of the row should be ada@example.com")
+		assert.Equal(t, "10", fmt.Sprintf("%v", rows[0].Get("vote")), "the vote of the row should be 10")
+	}
+	assert.Equal(t, int64(1), qb.Table("table_test_returning").MustCount(), "the table should has 1 row")
+}
+
+func TestReturningUpsertReturning(t *testing.T) {
+	NewTableForReturningTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_returning").MustInsert([]xun.R{
+		{"email": "ada@example.com", "vote": 10},
+	})
+
+	rows, err := qb.Table("table_test_returning").UpsertReturning([]xun.R{
+		{"email": "ada@example.com", "vote": 11},
+		{"email": "bob@example.com", "vote": 20},
+	}, "email", []string{"vote"}, "id", "vote")
+	if !checkReturningSupported(t, err) {
+		return
+	}
+
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "1", fmt.Sprintf("%v", rows[0].Get("id")), "the id of 1st row should be 1")
+		assert.Equal(t, "11", fmt.Sprintf("%v", rows[0].Get("vote")), "the vote of 1st row should be 11")
+		assert.Equal(t, "20", fmt.Sprintf("%v", rows[1].Get("vote")), "the vote of 2nd row should be 20")
+	}
+}
+
+// clean the test data
+func TestReturningClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_returning")
+}
+
+// checkReturningSupported check the error of the returning statements, return false if the returning clause is not supported
+func checkReturningSupported(t *testing.T, err error) bool {
+	qb := getTestBuilder()
+	version, verr := qb.Builder().getVersion()
+	assert.Nil(t, verr, "the version should be returned")
+	if qb.Builder().Grammar.SupportsReturning(version) {
+		assert.Nil(t, err, "the returning statement should be executed")
+		return err == nil
+	}
+
+	if assert.NotNil(t, err, "the returning statement should return an error") {
+		assert.Equal(t, fmt.Sprintf("the returning clause is not supported by %s %s", version.Driver, version.String()), err.Error())
+	}
+	return false
+}
+
+func NewTableForReturningTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_returning")
+	builder.MustCreateTable("table_test_returning", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email").Unique()
+		table.Integer("vote").SetDefault(5)
+	})
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// WithoutScope Remove the global scope of the given table from the query, the table name is given without the prefix.
+//
+//	rows, err := qb.Table("orders").WithoutScope("orders").Get()
+func (builder *Builder) WithoutScope(table string) Query {
+	builder.Query.Unscoped = append(builder.Query.Unscoped, table)
+	return builder
+}
+
+// WithoutScopes Remove all of the global scopes from the query.
+func (builder *Builder) WithoutScopes() Query {
+	builder.Query.Unscoped = append(builder.Query.Unscoped, "*")
+	return builder
+}
+
+// scopes Get the global scopes of the connection which are applied to the query
+func (builder *Builder) scopes() map[string]func(qb Query) {
+	if builder.Conn == nil || len(builder.Conn.Scopes) == 0 {
+		return nil
+	}
+
+	scopes := map[string]func(qb Query){}
+	for table, scope := range builder.Conn.Scopes {
+		scopes[table] = scope
+	}
+
+	for _, table := range builder.Query.Unscoped {
+		if table == "*" {
+			return nil
+		}
+		delete(scopes, table)
+	}
+	return scopes
+}
+
+// scoped Get a copy of the builder with the global scopes applied to the tables referenced by the query, its joins
+// and its subqueries. The conditions of a joined table are added to the join clause. The builder is returned
+// if there is no scope to apply.
+func (builder *Builder) scoped() (*Builder, error) {
+	scopes := builder.scopes()
+	if len(scopes) == 0 {
+		return builder, nil
+	}
+
+	// the queries built by the scopes are not scoped again
+	added := map[*dbal.Query]bool{}
+	new := *builder
+	err := new.Walk(func(node *dbal.Node) error {
+		if node.Type != dbal.NodeTable || added[node.Query] {
+			return nil
+		}
+
+		table, ok := node.Value.(dbal.Name)
+		if !ok {
+			return nil
+		}
+
+		scope, has := scopes[table.Name]
+		if !has {
+			return nil
+		}
+
+		group, err := builder.scopeQuery(table, scope)
+		if err != nil {
+			return err
+		}
+
+		dbal.Walk(group, func(node *dbal.Node) error {
+			added[node.Query] = true
+			return nil
+		})
+		node.WhereNested(group)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	new.Query.Unscoped = []string{"*"}
+	return &new, nil
+}
+
+// mustScoped Get a copy of the builder with the global scopes applied, it panics if the scopes could not be applied.
+func (builder *Builder) mustScoped() *Builder {
+	new, err := builder.scoped()
+	if err != nil {
+		panic(err)
+	}
+	return new
+}
+
+// scopeQuery Build the conditions of the global scope of the table, the columns are qualified
+// by the alias of the table or the table name with the prefix.
+func (builder *Builder) scopeQuery(table dbal.Name, scope func(qb Query)) (*dbal.Query, error) {
+	qb := builder.new()
+	qb.Query.From = dbal.From{Type: "basic", Alias: table.Alias, Name: table}
+	scope(qb)
+
+	reference := table.Reference()
+	err := dbal.Walk(qb.Query, func(node *dbal.Node) error {
+		if node.Type != dbal.NodeColumn || node.Depth > 0 || node.Clause != "where" {
+			return nil
+		}
+		if column, ok := node.Value.(string); ok && !strings.Contains(column, ".") {
+			node.Replace(reference + "." + column)
+		}
+		return nil
+	})
+	return qb.Query, err
+}
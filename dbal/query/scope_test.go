@@ -0,0 +1,165 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestScopeSelect(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.Builder().Conn.Scopes = map[string]func(qb Query){
+		"users": func(qb Query) { qb.Where("tenant_id", 7) },
+	}
+
+	qb.Table("users as u").Where("vote", ">", 10).OrWhere("status", "locked")
+	assert.Equal(t, "select * from `users` as `u` where (`vote` > ? or `status` = ?) and (`u`.`tenant_id` = ?)", qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{10, "locked", 7}, qb.GetBindings(), "the bindings not equal")
+
+	// the conditions of the joined table are added to the join clause
+	qb.Table("posts").
+		LeftJoin("users as u", "u.id", "=", "posts.user_id").
+		WhereIn("posts.user_id", func(sub Query) {
+			sub.From("users").Select("id").Where("vote", ">", 10)
+		}).
+		Where("posts.status", "published")
+	assert.Equal(t, "select * from `posts` left join `users` as `u` on `u`.`id` = `posts`.`user_id` and (`u`.`tenant_id` = ?) where `posts`.`user_id` in (select `id` from `users` where `vote` > ? and (`users`.`tenant_id` = ?)) and `posts`.`status` = ?", qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{7, 10, 7, "published"}, qb.GetBindings(), "the bindings not equal")
+
+	// the query is not changed by the scopes
+	assert.Equal(t, 0, len(qb.Builder().Query.Bindings["join"]), "the query should not be changed")
+}
+
+func TestScopePrefix(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")}, dbal.Option{Prefix: "xun_"})
+	qb.Builder().Conn.Scopes = map[string]func(qb Query){
+		"users": func(qb Query) { qb.Where("tenant_id", 7) },
+		"posts": func(qb Query) {
+			qb.WhereIn("user_id", func(sub Query) {
+				sub.From("users").Select("id").Where("active", true)
+			})
+		},
+	}
+
+	qb.Table("users").Where("vote", ">", 10)
+	assert.Equal(t, `select * from "xun_users" where "vote" > $1 and ("xun_users"."tenant_id" = $2)`, qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{10, 7}, qb.GetBindings(), "the bindings not equal")
+
+	// the scopes are not applied to the queries built by the scopes
+	qb.Table("posts as p").Where("p.vote", ">", 10)
+	assert.Equal(t, `select * from "xun_posts" as "p" where "p"."vote" > $1 and ("p"."user_id" in (select "id" from "xun_users" where "active" = $2))`, qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{10, true}, qb.GetBindings(), "the bindings not equal")
+}
+
+func TestScopeUpdateDelete(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.Builder().Conn.Scopes = map[string]func(qb Query){
+		"users": func(qb Query) { qb.Where("tenant_id", 7) },
+	}
+
+	sql, bindings := qb.Table("users").Where("id", 1).ToUpdateSQL(xun.R{"vote": 10})
+	assert.Equal(t, "update `users` set `vote`=? where `id` = ? and (`users`.`tenant_id` = ?)", sql, "the update sql not equal")
+	assert.Equal(t, []interface{}{10, 1, 7}, bindings, "the bindings not equal")
+
+	sql, bindings = qb.Table("users").Where("id", 1).ToDeleteSQL()
+	assert.Equal(t, "delete from `users` where `id` = ? and (`users`.`tenant_id` = ?)", sql, "the delete sql not equal")
+	assert.Equal(t, []interface{}{1, 7}, bindings, "the bindings not equal")
+}
+
+func TestScopeWithout(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.Builder().Conn.Scopes = map[string]func(qb Query){
+		"users": func(qb Query) { qb.Where("tenant_id", 7) },
+		"posts": func(qb Query) { qb.WhereNull("deleted_at") },
+	}
+
+	qb.Table("posts").Join("users", "users.id", "=", "posts.user_id").WithoutScope("posts")
+	assert.Equal(t, "select * from `posts` inner join `users` on `users`.`id` = `posts`.`user_id` and (`users`.`tenant_id` = ?)", qb.ToSQL(), "the query sql not equal")
+
+	qb.WithoutScopes()
+	assert.Equal(t, "select * from `posts` inner join `users` on `users`.`id` = `posts`.`user_id`", qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{}, qb.GetBindings(), "the bindings not equal")
+
+	// the Table method creates a new statement
+	qb.Table("posts")
+	assert.Equal(t, "select * from `posts` where (`posts`.`deleted_at` is null)", qb.ToSQL(), "the query sql not equal")
+}
+
+func TestScopeGet(t *testing.T) {
+	NewTableForScopeTest()
+	qb := getTestBuilder()
+	conn := qb.Builder().Conn
+	conn.Scopes = map[string]func(qb Query){
+		"table_test_scope": func(qb Query) { qb.Where("tenant_id", 1) },
+	}
+	defer func() { conn.Scopes = nil }()
+
+	rows := qb.Table("table_test_scope").OrderBy("id").MustGet()
+	if assert.Equal(t, 2, len(rows), "the return value should has 2 rows") {
+		assert.Equal(t, "John", rows[0]["name"], "the name of the first row should be John")
+		assert.Equal(t, "Ken", rows[1]["name"], "the name of the second row should be Ken")
+	}
+
+	affected := qb.Table("table_test_scope").Where("vote", ">", 0).MustUpdate(xun.R{"vote": 100})
+	assert.Equal(t, int64(2), affected, "the affected rows should be 2")
+
+	affected = qb.Table("table_test_scope").Where("name", "Lee").MustDelete()
+	assert.Equal(t, int64(0), affected, "the row of the other tenant should not be deleted")
+
+	rows = qb.Table("table_test_scope").WithoutScopes().OrderBy("id").MustGet()
+	if assert.Equal(t, 3, len(rows), "the return value should has 3 rows") {
+		assert.Equal(t, 100, rows[0].GetInt("vote"), "the vote of the first row should be updated")
+		assert.Equal(t, 5, rows[1].GetInt("vote"), "the vote of the second row should not be updated")
+	}
+}
+
+func TestScopeError(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	qb.Builder().Conn.Scopes = map[string]func(qb Query){
+		"posts": func(qb Query) { qb.Where("tenant_id", 7) },
+	}
+
+	// the bindings of two raw where clauses could not be located
+	qb.Table("users").WhereRaw("vote > ?", 1).WhereRaw("score > ?", 2).WhereExists(func(sub Query) {
+		sub.From("posts")
+	})
+
+	_, err := qb.Get()
+	assert.ErrorIs(t, err, dbal.ErrWalkBindings, "the scope error should be returned")
+
+	_, err = GetAs[xun.R](qb)
+	assert.ErrorIs(t, err, dbal.ErrWalkBindings, "the scope error should be returned")
+
+	_, err = qb.Cursor()
+	assert.ErrorIs(t, err, dbal.ErrWalkBindings, "the scope error should be returned")
+}
+
+// clean the test data
+func TestScopeClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_scope")
+}
+
+func NewTableForScopeTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_scope")
+	builder.MustCreateTable("table_test_scope", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("tenant_id")
+		table.String("name")
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_scope").MustInsert([]xun.R{
+		{"tenant_id": 1, "name": "John", "vote": 10},
+		{"tenant_id": 2, "name": "Lee", "vote": 5},
+		{"tenant_id": 1, "name": "Ken", "vote": 20},
+	})
+}
@@ -60,6 +60,7 @@ func (builder *Builder) SelectSub(qb interface{}, as string) Query {
 		Alias:  as,
 		SQL:    fmt.Sprintf("(%s)", sql),
 		Offset: selectOffset - 1,
+		Query:  subQuery(sub),
 	}
 	builder.addSelect(column)
 	builder.Query.AddBinding("select", bindings)
@@ -150,6 +150,14 @@ func (builder *Builder) parseSub(sub interface{}) string {
 	panic(fmt.Errorf("a subquery must be a query builder instance, a Closure, or a string"))
 }
 
+// subQuery Get the query of the subquery made by the makeSub method, it returns nil if the subquery is a raw SQL.
+func subQuery(sub interface{}) *dbal.Query {
+	if query, ok := sub.(*dbal.Query); ok {
+		return query
+	}
+	return nil
+}
+
 func (builder *Builder) isClosure(v interface{}) bool {
 	_, ok := v.(func(Query))
 	return ok
@@ -247,16 +255,17 @@ func (builder *Builder) mapScan(rows *sql.Rows) ([]xun.R, error) {
 		return nil, err
 	}
 
-	values := builder.makeMapValues(len(columns))
+	kinds, err := builder.getDecodeKinds(rows, len(columns))
+	if err != nil {
+		return nil, err
+	}
 
+	values := builder.makeMapValues(len(columns))
 	for rows.Next() {
-		if err := rows.Scan(values...); err != nil {
+		dest, err := builder.mapScanRow(rows, columns, kinds, values)
+		if err != nil {
 			return nil, err
 		}
-		dest := xun.R{}
-		for i, column := range columns {
-			dest[column] = builder.getValue(values[i])
-		}
 		res = append(res, dest)
 	}
 
@@ -267,6 +276,19 @@ func (builder *Builder) mapScan(rows *sql.Rows) ([]xun.R, error) {
 	return res, nil
 }
 
+// mapScanRow scan the current row of the sql.Rows into a new xun.R, the values are the reusable scan destinations
+// and decoded by the kinds of the column types
+func (builder *Builder) mapScanRow(rows *sql.Rows, columns []string, kinds []string, values []interface{}) (xun.R, error) {
+	if err := rows.Scan(values...); err != nil {
+		return nil, err
+	}
+	dest := xun.R{}
+	for i, column := range columns {
+		dest[column] = builder.decodeValue(kinds[i], values[i])
+	}
+	return dest, nil
+}
+
 // structScan scan the result from sql.Rows
 func (builder *Builder) structScan(rows *sql.Rows, v interface{}) error {
 	defer rows.Close()
@@ -356,19 +378,6 @@ func (builder *Builder) getFieldMap(structType reflect.Type) (map[string]reflect
 	return fieldMap, nil
 }
 
-func (builder *Builder) getValue(src interface{}) interface{} {
-	value := src
-	if reflect.TypeOf(src).Kind() == reflect.Ptr {
-		value = reflect.Indirect(reflect.ValueOf(src)).Interface()
-	}
-	switch value.(type) {
-	case []byte:
-		return string(value.([]byte))
-	default:
-		return value
-	}
-}
-
 func (builder *Builder) makeMapValues(length int) []interface{} {
 	values := make([]interface{}, length)
 	for i := range values {
@@ -0,0 +1,163 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// Timeout Set the timeout of the query, it overrides the default timeout of the connection.
+// The statement is canceled by the context when the timeout is exceeded, and the server-side limit is set
+// if the database server supports it (MAX_EXECUTION_TIME hint of the MySQL selects, "set local statement_timeout"
+// of PostgreSQL, which is restored after the statement in a transaction, the other statements are executed in an
+// implicit transaction). The error of a timeout statement matches dbal.ErrQueryTimeout.
+//
+//	rows, err := qb.Table("orders").Timeout(2 * time.Second).Get()
+//	if errors.Is(err, dbal.ErrQueryTimeout) { ... }
+func (builder *Builder) Timeout(timeout time.Duration) Query {
+	if timeout < 0 {
+		panic(fmt.Errorf("the timeout must not be negative"))
+	}
+	builder.Query.Timeout = timeout
+	return builder
+}
+
+// timeout Get the timeout of the statements, the timeout of the query comes first, then the default timeout of the connection
+func (builder *Builder) timeout() time.Duration {
+	if builder.Query.Timeout > 0 {
+		return builder.Query.Timeout
+	}
+
+	if builder.Conn == nil {
+		return 0
+	}
+
+	config := builder.Conn.WriteConfig
+	if builder.Tx == nil && !builder.IsWrite() && builder.Conn.ReadConfig != nil {
+		config = builder.Conn.ReadConfig
+	}
+
+	if config == nil {
+		return 0
+	}
+	return config.Timeout
+}
+
+// withTimeout Get a copy of the builder bound to a context with the deadline of the timeout, and the function
+// releasing the context. The builder is returned as it is if there is no timeout.
+//
+//	builder, cancel, err := builder.withTimeout()
+//	if err != nil {
+//		return err
+//	}
+//	defer cancel()
+func (builder *Builder) withTimeout() (*Builder, context.CancelFunc, error) {
+	timeout := builder.timeout()
+	if timeout <= 0 {
+		return builder, func() {}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(builder.Context(), timeout)
+	grammar, err := builder.Grammar.NewWithContext(ctx)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	// the timeout of the connection is compiled to the server-side limit of the statement (MAX_EXECUTION_TIME of MySQL)
+	new := *builder
+	new.Ctx = ctx
+	new.Grammar = grammar
+	if builder.Query.Timeout != timeout {
+		new.Query = builder.Query.Clone()
+		new.Query.Timeout = timeout
+	}
+
+	if grammar.CompileStatementTimeout(timeout) == "" {
+		return &new, cancel, nil
+	}
+
+	// the server-side limit of the transaction statements, the previous limit is restored after the statement
+	if builder.Tx != nil {
+		restore := builder.setStatementTimeout(grammar, timeout, true)
+		return &new, func() {
+			restore()
+			cancel()
+		}, nil
+	}
+
+	// the statement out of a transaction is executed in an implicit transaction limiting it,
+	// the transaction is not bound to the deadline so that it could be committed after the statement.
+	tx, err := dbal.BeginTransaction(builder.Context(), builder.DB())
+	if err != nil {
+		cancel()
+		return nil, nil, builder.translateError(err)
+	}
+
+	grammar, err = grammar.NewWithTx(tx.Tx)
+	if err != nil {
+		tx.Tx.Rollback()
+		cancel()
+		return nil, nil, err
+	}
+
+	implicit := *builder
+	implicit.Tx = tx
+	implicit.setStatementTimeout(grammar, timeout, false)
+
+	new.Tx = tx
+	new.Grammar = grammar
+	return &new, func() {
+		if err := tx.Tx.Commit(); err != nil {
+			log.Warn("the implicit transaction of the timeout statement is not committed: %s", err.Error())
+		}
+		cancel()
+	}, nil
+}
+
+// setStatementTimeout Set the server-side limit of the statements of the transaction (statement_timeout of PostgreSQL),
+// and get the function restoring the previous limit if restore is true. The statements are executed without the deadline
+// so that the limit could be restored after the timed statement.
+func (builder *Builder) setStatementTimeout(grammar dbal.Grammar, timeout time.Duration, restore bool) func() {
+	executor := builder.executor()
+	current := ""
+	if restore {
+		if err := executor.QueryRowx(grammar.CompileCurrentStatementTimeout()).Scan(&current); err != nil {
+			log.Warn("the statement timeout is not set: %s", err.Error())
+			return func() {}
+		}
+	}
+
+	if _, err := executor.Exec(grammar.CompileStatementTimeout(timeout)); err != nil {
+		log.Warn("the statement timeout is not set: %s", err.Error())
+		return func() {}
+	}
+
+	if !restore {
+		return func() {}
+	}
+
+	return func() {
+		if _, err := executor.Exec(grammar.CompileRestoreStatementTimeout(), current); err != nil {
+			log.Warn("the statement timeout is not restored: %s", err.Error())
+		}
+	}
+}
+
+// translateError Translate the error of the driver into a *dbal.Error, the errors of the statements
+// canceled by the deadline of the builder context are the dbal.ErrQueryTimeout errors.
+func (builder *Builder) translateError(err error) error {
+	err = builder.Grammar.TranslateError(err)
+	if err == nil || builder.Ctx == nil || errors.Is(err, dbal.ErrQueryTimeout) {
+		return err
+	}
+
+	if errors.Is(builder.Ctx.Err(), context.DeadlineExceeded) {
+		return dbal.NewError(dbal.ErrQueryTimeout, err)
+	}
+	return err
+}
@@ -0,0 +1,189 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestTimeoutGet(t *testing.T) {
+	NewTableForTimeoutTest()
+	qb := getTestBuilder()
+	rows, err := qb.Table("table_test_timeout").Timeout(time.Minute).Get()
+	assert.Nil(t, err, "the query should be executed before the timeout")
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+}
+
+func TestTimeoutExceeded(t *testing.T) {
+	NewTableForTimeoutTest()
+	qb := getTestBuilder()
+	_, err := qb.Table("table_test_timeout").Timeout(time.Nanosecond).Get()
+	assert.True(t, errors.Is(err, dbal.ErrQueryTimeout), "the error should be a timeout query")
+
+	_, err = qb.Table("table_test_timeout").Timeout(time.Nanosecond).Update(xun.R{"vote": 30})
+	assert.True(t, errors.Is(err, dbal.ErrQueryTimeout), "the error should be a timeout query")
+	assert.Equal(t, int64(0), qb.Table("table_test_timeout").Where("vote", 30).MustCount(), "the rows should not be updated")
+}
+
+func TestTimeoutLongRunning(t *testing.T) {
+	if unit.DriverIs("mysql") {
+		return
+	}
+
+	qb := getTestBuilder()
+	start := time.Now()
+	_, err := qb.Timeout(50 * time.Millisecond).Exec("with recursive cnt(x) as (select 1 union all select x+1 from cnt where x < 1000000000) select count(*) from cnt")
+	assert.True(t, errors.Is(err, dbal.ErrQueryTimeout), "the error should be a timeout query")
+	assert.Less(t, int64(time.Since(start)), int64(10*time.Second), "the query should be canceled")
+}
+
+func TestTimeoutTransaction(t *testing.T) {
+	NewTableForTimeoutTest()
+	qb := getTestBuilder()
+	err := qb.Transaction(func(qb Query) error {
+		current := ""
+		if unit.DriverIs("postgres") {
+			current = qb.Table("table_test_timeout").SelectRaw("current_setting('statement_timeout') as timeout").MustFirst().GetString("timeout")
+		}
+
+		rows, err := qb.Table("table_test_timeout").Timeout(200 * time.Millisecond).Get()
+		assert.Nil(t, err, "the timed query should be executed before the timeout")
+		assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+
+		// the limit of the timed query should not be applied to the following statements of the transaction
+		if unit.DriverIs("postgres") {
+			assert.Equal(t, current, qb.Table("table_test_timeout").SelectRaw("current_setting('statement_timeout') as timeout").MustFirst().GetString("timeout"), "the statement timeout should be restored")
+			_, err = qb.Exec("select pg_sleep(0.3)")
+			assert.Nil(t, err, "the untimed query should not be canceled")
+		}
+
+		rows, err = qb.Table("table_test_timeout").Get()
+		assert.Nil(t, err, "the untimed query should be executed")
+		assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+		return nil
+	})
+	assert.Nil(t, err, "the transaction should be committed")
+}
+
+func TestTimeoutConnectionDefault(t *testing.T) {
+	NewTableForTimeoutTest()
+	qb := getTestBuilder()
+	conn := qb.Builder().Conn
+	read, write := conn.ReadConfig.Timeout, conn.WriteConfig.Timeout
+	defer func() {
+		conn.ReadConfig.Timeout, conn.WriteConfig.Timeout = read, write
+	}()
+
+	conn.ReadConfig.Timeout = time.Nanosecond
+	conn.WriteConfig.Timeout = time.Nanosecond
+	_, err := qb.Table("table_test_timeout").Get()
+	assert.True(t, errors.Is(err, dbal.ErrQueryTimeout), "the error should be a timeout query")
+
+	// the timeout of the query overrides the default one
+	rows, err := qb.Table("table_test_timeout").Timeout(time.Minute).Get()
+	assert.Nil(t, err, "the query should be executed before the timeout")
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+}
+
+func TestTimeoutCanceled(t *testing.T) {
+	NewTableForTimeoutTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	qb := getTestBuilder()
+	_, err := qb.WithContext(ctx).Table("table_test_timeout").Timeout(time.Minute).Get()
+	assert.True(t, errors.Is(err, dbal.ErrQueryCanceled), "the error should be a canceled query")
+	assert.False(t, errors.Is(err, dbal.ErrQueryTimeout), "the error should not be a timeout query")
+}
+
+func TestTimeoutNegative(t *testing.T) {
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, "the timeout must not be negative", func() {
+		qb.Table("table_test_timeout").Timeout(-time.Second)
+	})
+}
+
+func TestTimeoutConnectionDefaultHint(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	conn := qb.Builder().Conn
+	conn.ReadConfig.Timeout = 2 * time.Second
+	conn.WriteConfig.Timeout = 2 * time.Second
+
+	// the default timeout of the connection is compiled to the optimizer hint of the statement
+	qb.Table("users").Where("id", 1)
+	builder, cancel, err := qb.Builder().withTimeout()
+	assert.Nil(t, err)
+	defer cancel()
+	assert.Equal(t, "select /*+ MAX_EXECUTION_TIME(2000) */ * from `users` where `id` = ?", builder.ToSQL(), "the query sql not equal")
+	assert.Equal(t, "select * from `users` where `id` = ?", qb.ToSQL(), "the query should not be changed")
+}
+
+func TestTimeoutImplicitTransaction(t *testing.T) {
+	qb := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")})
+	offline := dbal.NewOffline(true)
+	conn := qb.Builder().Conn
+	conn.Write = offline.Open("postgres")
+	conn.Read = conn.Write
+
+	// the statement out of a transaction is limited by the "set local" of an implicit transaction
+	_, err := qb.Table("users").Where("id", 1).Timeout(2 * time.Second).Update(xun.R{"vote": 10})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"set local statement_timeout = 2000",
+		`update "users" set "vote"=$1 where "id" = $2`,
+	}, offline.Statements())
+
+	// the default timeout of the connection is limited by the server too
+	offline.Reset()
+	conn.WriteConfig.Timeout = time.Second
+	_, err = qb.Table("users").Where("id", 1).Update(xun.R{"vote": 10})
+	assert.Nil(t, err)
+	assert.Equal(t, "set local statement_timeout = 1000", offline.Statements()[0])
+}
+
+func TestTimeoutGrammarError(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.Builder().Grammar = contextErrorGrammar{qb.Builder().Grammar}
+	assert.NotPanics(t, func() {
+		_, err := qb.Table("table_test_timeout").Timeout(time.Minute).Get()
+		assert.Equal(t, "the context is not supported", err.Error())
+	})
+}
+
+// contextErrorGrammar the grammar which could not be bound to a context
+type contextErrorGrammar struct{ dbal.Grammar }
+
+func (grammar contextErrorGrammar) NewWithContext(ctx context.Context) (dbal.Grammar, error) {
+	return nil, fmt.Errorf("the context is not supported")
+}
+
+// clean the test data
+func TestTimeoutClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_timeout")
+}
+
+func NewTableForTimeoutTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_timeout")
+	builder.MustCreateTable("table_test_timeout", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_timeout").MustInsert([]xun.R{
+		{"email": "max@yao.run", "vote": 10},
+		{"email": "ken@yao.run", "vote": 20},
+	})
+}
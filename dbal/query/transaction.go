@@ -0,0 +1,143 @@
+package query
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// Transaction Execute the callback within a transaction, the transaction will be committed if the callback returns nil,
+// otherwise it will be rolled back. If the builder is already in a transaction, a savepoint will be used.
+// The callback is re-run by the retry policy if the transaction fails with a deadlock or a serialization failure,
+// the nested transactions are not retried because the outer transaction is aborted.
+func (builder *Builder) Transaction(callback func(qb Query) error) error {
+	policy := builder.retryPolicy()
+	if policy == nil || builder.Tx != nil {
+		return builder.transaction(callback)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := builder.transaction(callback)
+		if attempt >= policy.Attempts() || !policy.ShouldRetry(builder.translateError(err)) {
+			return err
+		}
+
+		delay := policy.Delay(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-builder.Context().Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// Retry Set the retry policy of the transactions of the builder, it overrides the policy of the connection option.
+//
+//	qb.Retry(dbal.RetryPolicy{MaxAttempts: 5}).Transaction(func(qb query.Query) error { ... })
+func (builder *Builder) Retry(policy dbal.RetryPolicy) Query {
+	builder.RetryPolicy = &policy
+	return builder
+}
+
+// retryPolicy Get the retry policy of the builder or the connection option, nil if there is none.
+func (builder *Builder) retryPolicy() *dbal.RetryPolicy {
+	if builder.RetryPolicy != nil {
+		return builder.RetryPolicy
+	}
+	if builder.Conn != nil && builder.Conn.Option != nil {
+		return builder.Conn.Option.Retry
+	}
+	return nil
+}
+
+// transaction Execute the callback within a transaction once.
+func (builder *Builder) transaction(callback func(qb Query) error) (err error) {
+	qb, err := builder.begin()
+	if err != nil {
+		return err
+	}
+
+	// If the callback panics, we will rollback the transaction and re-panic
+	// so the caller gets the original error. Otherwise we commit or rollback
+	// the transaction according to the result of the callback.
+	defer func() {
+		if r := recover(); r != nil {
+			qb.Rollback()
+			panic(r)
+		}
+	}()
+
+	err = callback(qb)
+	if err != nil {
+		if errRollback := qb.Rollback(); errRollback != nil {
+			return fmt.Errorf("%s (rollback failed: %s)", err, errRollback)
+		}
+		return err
+	}
+
+	return qb.Commit()
+}
+
+// Begin Start a new transaction, or create a savepoint if the builder is already in a transaction.
+// All of the statements of the returned builder will be executed within the transaction.
+func (builder *Builder) Begin() (Query, error) {
+	return builder.begin()
+}
+
+// Commit Commit the transaction, or release the savepoint if the transaction is nested.
+func (builder *Builder) Commit() error {
+	if builder.Tx == nil {
+		return fmt.Errorf("the builder is not in a transaction")
+	}
+	return builder.translateError(builder.Tx.Commit(builder.Grammar))
+}
+
+// Rollback Rollback the transaction, or rollback to the savepoint if the transaction is nested.
+func (builder *Builder) Rollback() error {
+	if builder.Tx == nil {
+		return fmt.Errorf("the builder is not in a transaction")
+	}
+	return builder.Tx.Rollback(builder.Grammar)
+}
+
+// InTransaction Determine if the builder is in a transaction.
+func (builder *Builder) InTransaction() bool {
+	return builder.Tx != nil
+}
+
+// begin Start a new transaction and return a new builder instance bound to it.
+func (builder *Builder) begin() (*Builder, error) {
+
+	if builder.Tx != nil {
+		tx, err := builder.Tx.Nested(builder.Grammar)
+		if err != nil {
+			return nil, err
+		}
+		new := builder.new()
+		new.Tx = tx
+		return new, nil
+	}
+
+	tx, err := dbal.BeginTransaction(builder.Context(), builder.Conn.Write)
+	if err != nil {
+		return nil, err
+	}
+
+	grammar, err := builder.Grammar.NewWithTx(tx.Tx)
+	if err != nil {
+		tx.Tx.Rollback()
+		return nil, err
+	}
+
+	new := builder.new()
+	new.Tx = tx
+	new.Grammar = grammar
+	return new, nil
+}
@@ -0,0 +1,216 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestTransactionCommit(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	err := qb.Transaction(func(qb Query) error {
+		qb.Table("table_test_transaction").MustInsert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+		qb.Table("table_test_transaction").Where("email", "john@yao.run").MustUpdate(xun.R{"vote": 100})
+		assert.True(t, qb.InTransaction(), "the builder should be in a transaction")
+		assert.Equal(t, int64(3), qb.Table("table_test_transaction").MustCount(), "the read should use the transaction")
+		return nil
+	})
+
+	assert.Nil(t, err, "the error should be nil")
+	assert.False(t, qb.InTransaction(), "the builder should not be in a transaction")
+	assert.Equal(t, int64(3), qb.Table("table_test_transaction").MustCount(), "the rows count should be 3")
+	assert.Equal(t, int64(100), qb.Table("table_test_transaction").Where("email", "john@yao.run").MustFirst().Get("vote"), "the vote should be 100")
+}
+
+func TestTransactionRollback(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	err := qb.Transaction(func(qb Query) error {
+		qb.Table("table_test_transaction").MustInsert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+		qb.Table("table_test_transaction").Where("email", "john@yao.run").MustDelete()
+		return fmt.Errorf("something wrong")
+	})
+
+	assert.Equal(t, "something wrong", err.Error())
+	assert.Equal(t, int64(2), qb.Table("table_test_transaction").MustCount(), "the rows count should be 2")
+	assert.Equal(t, int64(1), qb.Table("table_test_transaction").Where("email", "john@yao.run").MustCount(), "the row should not be deleted")
+}
+
+func TestTransactionPanic(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	assert.Panics(t, func() {
+		qb.Transaction(func(qb Query) error {
+			qb.Table("table_test_transaction").MustInsert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+			qb.Table("table_test_transaction").MustInsert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+			return nil
+		})
+	})
+	assert.Equal(t, int64(2), qb.Table("table_test_transaction").MustCount(), "the rows count should be 2")
+}
+
+func TestTransactionNested(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	err := qb.Transaction(func(qb Query) error {
+		qb.Table("table_test_transaction").MustInsert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+
+		err := qb.Transaction(func(qb Query) error {
+			qb.Table("table_test_transaction").MustInsert(xun.R{"email": "ken@yao.run", "name": "Ken", "vote": 1})
+			return fmt.Errorf("rollback to savepoint")
+		})
+		assert.Equal(t, "rollback to savepoint", err.Error())
+
+		return qb.Transaction(func(qb Query) error {
+			qb.Table("table_test_transaction").MustInsert(xun.R{"email": "ben@yao.run", "name": "Ben", "vote": 1})
+			return nil
+		})
+	})
+
+	assert.Nil(t, err, "the error should be nil")
+	rows := qb.Table("table_test_transaction").OrderBy("id").MustGet()
+	assert.Equal(t, 4, len(rows), "the rows count should be 4")
+	if len(rows) == 4 {
+		assert.Equal(t, "max@yao.run", rows[2].Get("email"))
+		assert.Equal(t, "ben@yao.run", rows[3].Get("email"))
+	}
+}
+
+func TestTransactionBeginCommitRollback(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+
+	tx, err := qb.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Table("table_test_transaction").MustInsert(xun.R{"email": "max@yao.run", "name": "Max", "vote": 1})
+	id := tx.Table("table_test_transaction").MustInsertGetID(xun.R{"email": "ken@yao.run", "name": "Ken", "vote": 1})
+	assert.Equal(t, int64(4), id, "the last id should be 4")
+
+	nested, err := tx.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nested.Table("table_test_transaction").Where("email", "max@yao.run").MustDelete()
+	err = nested.Rollback()
+	assert.Nil(t, err, "the error should be nil")
+
+	err = tx.Commit()
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, int64(4), qb.Table("table_test_transaction").MustCount(), "the rows count should be 4")
+
+	tx, err = qb.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Table("table_test_transaction").Where("email", "max@yao.run").MustDelete()
+	err = tx.Rollback()
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, int64(4), qb.Table("table_test_transaction").MustCount(), "the rows count should be 4")
+}
+
+func TestTransactionCommitError(t *testing.T) {
+	qb := getTestBuilder()
+	assert.Equal(t, "the builder is not in a transaction", qb.Commit().Error())
+	assert.Equal(t, "the builder is not in a transaction", qb.Rollback().Error())
+}
+
+// clean the test data
+func TestTransactionRetry(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	attempts := 0
+	retries := []int{}
+	err := qb.Retry(dbal.RetryPolicy{
+		BaseDelay: time.Millisecond,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			retries = append(retries, attempt)
+			assert.True(t, errors.Is(err, dbal.ErrDeadlock), "the error should be a deadlock")
+			assert.True(t, delay <= 2*time.Millisecond, "the delay should be under the backoff")
+		},
+	}).Transaction(func(qb Query) error {
+		attempts++
+		qb.Table("table_test_transaction").MustInsert(xun.R{"email": fmt.Sprintf("max%d@yao.run", attempts), "name": "Max", "vote": 1})
+		if attempts < 3 {
+			return dbal.NewError(dbal.ErrDeadlock, fmt.Errorf("deadlock found"))
+		}
+		return nil
+	})
+
+	assert.Nil(t, err, "the error should be nil")
+	assert.Equal(t, 3, attempts, "the callback should be run 3 times")
+	assert.Equal(t, []int{1, 2}, retries, "the hook should be called before each retry")
+	assert.Equal(t, int64(3), qb.Table("table_test_transaction").MustCount(), "only the last attempt should be committed")
+}
+
+func TestTransactionRetryMaxAttempts(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	attempts := 0
+	err := qb.Retry(dbal.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}).Transaction(func(qb Query) error {
+		attempts++
+		return dbal.NewError(dbal.ErrSerialization, fmt.Errorf("could not serialize access"))
+	})
+	assert.True(t, errors.Is(err, dbal.ErrSerialization), "the last error should be returned")
+	assert.Equal(t, 2, attempts, "the callback should be run 2 times")
+}
+
+func TestTransactionRetryNotRetryable(t *testing.T) {
+	NewTableForTransactionTest()
+	qb := getTestBuilder()
+	attempts := 0
+	err := qb.Retry(dbal.RetryPolicy{BaseDelay: time.Millisecond}).Transaction(func(qb Query) error {
+		attempts++
+		nested := 0
+		err := qb.Transaction(func(qb Query) error {
+			nested++
+			return dbal.NewError(dbal.ErrDeadlock, fmt.Errorf("deadlock found"))
+		})
+		assert.Equal(t, 1, nested, "the nested transaction should not be retried")
+		assert.NotNil(t, err, "the nested transaction should return the error")
+		return fmt.Errorf("something wrong")
+	})
+	assert.Equal(t, "something wrong", err.Error())
+	assert.Equal(t, 1, attempts, "the callback should not be retried")
+}
+
+func TestTransactionRetryDelay(t *testing.T) {
+	policy := dbal.RetryPolicy{BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	for attempt, max := range map[int]time.Duration{1: 10, 2: 20, 3: 40, 4: 50, 10: 50} {
+		delay := policy.Delay(attempt)
+		assert.True(t, delay >= max*time.Millisecond/2 && delay <= max*time.Millisecond, "the delay %s of the attempt %d should be in the range", delay, attempt)
+	}
+	assert.Equal(t, 3, policy.Attempts(), "the default attempts should be 3")
+}
+
+func TestTransactionClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_transaction")
+}
+
+func NewTableForTransactionTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_transaction")
+	builder.MustCreateTable("table_test_transaction", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email").Unique()
+		table.String("name").Index()
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_transaction").MustInsert([]xun.R{
+		{"email": "john@yao.run", "name": "John", "vote": 10},
+		{"email": "lee@yao.run", "name": "Lee", "vote": 5},
+	})
+}
@@ -1,18 +1,23 @@
 package query
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun/dbal"
 )
 
 // Builder the dbal query builder
 type Builder struct {
-	Conn     *Connection
-	Query    *dbal.Query
-	Mode     string
-	Database string
-	Schema   string
-	Grammar  dbal.Grammar
+	Conn        *Connection
+	Query       *dbal.Query
+	Mode        string
+	Database    string
+	Schema      string
+	Grammar     dbal.Grammar
+	Tx          *dbal.Transaction
+	Ctx         context.Context
+	RetryPolicy *dbal.RetryPolicy
 }
 
 // Connection DB Connection
@@ -22,4 +27,86 @@ type Connection struct {
 	Read        *sqlx.DB
 	ReadConfig  *dbal.Config
 	Option      *dbal.Option
+	Version     *dbal.Version
+	Metadata    *Metadata                 // The metadata of the database server, e.g. the packet size
+	Scopes      map[string]func(qb Query) // The global scopes of the tables, the table names are given without the prefix
+}
+
+// BatchSize the number of rows inserted by each statement of the Insert, InsertOrIgnore and Upsert methods,
+// the rows are split by the bindings limit of the database server if it's not given.
+//
+//	qb.Table("users").Insert(rows, query.BatchSize(500))
+type BatchSize int
+
+// Paginator the typed simple paginator returned by the PaginateAs function, the items are the values of T.
+type Paginator[T any] struct {
+	Items        []T `json:"items"`
+	Total        int `json:"total"`
+	TotalPages   int `json:"total_pages"`
+	PageSize     int `json:"page_size"`
+	CurrentPage  int `json:"current_page"`
+	NextPage     int `json:"next_page"`
+	PreviousPage int `json:"previous_page"`
+	LastPage     int `json:"last_page"`
+}
+
+// DSLVersion the version of the query DSL documents
+const DSLVersion = "1.0"
+
+// DSL the query document, it could be written in JSON or YAML and compiled to the query builder by the LoadDSL method.
+//
+//	{"version": "1.0", "from": "users as u", "select": ["u.id", "u.name"], "wheres": [{"column": "u.vote", "op": ">", "value": 10}]}
+type DSL struct {
+	Version    string         `json:"version" yaml:"version"`
+	From       string         `json:"from" yaml:"from"`
+	Select     []string       `json:"select,omitempty" yaml:"select,omitempty"`
+	Distinct   bool           `json:"distinct,omitempty" yaml:"distinct,omitempty"`
+	Aggregates []DSLAggregate `json:"aggregates,omitempty" yaml:"aggregates,omitempty"`
+	Joins      []DSLJoin      `json:"joins,omitempty" yaml:"joins,omitempty"`
+	Wheres     []DSLWhere     `json:"wheres,omitempty" yaml:"wheres,omitempty"`
+	Groups     []string       `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Havings    []DSLWhere     `json:"havings,omitempty" yaml:"havings,omitempty"`
+	Orders     []DSLOrder     `json:"orders,omitempty" yaml:"orders,omitempty"`
+	Limit      int            `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Offset     int            `json:"offset,omitempty" yaml:"offset,omitempty"`
+	Paginate   *DSLPaginate   `json:"paginate,omitempty" yaml:"paginate,omitempty"`
+}
+
+// DSLAggregate the aggregate column of the query document, the function could be count, sum, avg, min or max.
+type DSLAggregate struct {
+	Func     string `json:"func" yaml:"func"`
+	Column   string `json:"column" yaml:"column"`
+	Alias    string `json:"alias" yaml:"alias"`
+	Distinct bool   `json:"distinct,omitempty" yaml:"distinct,omitempty"`
+}
+
+// DSLJoin the join clause of the query document, the type could be inner (default), left, right or cross.
+type DSLJoin struct {
+	Type   string `json:"type,omitempty" yaml:"type,omitempty"`
+	Table  string `json:"table" yaml:"table"`
+	First  string `json:"first,omitempty" yaml:"first,omitempty"`
+	Op     string `json:"op,omitempty" yaml:"op,omitempty"`
+	Second string `json:"second,omitempty" yaml:"second,omitempty"`
+}
+
+// DSLWhere the where or having condition of the query document, the condition is a nested group if the wheres are given.
+// The op could be a comparison operator of the grammar, in, not in, between, not between, null or not null.
+type DSLWhere struct {
+	Column string      `json:"column,omitempty" yaml:"column,omitempty"`
+	Op     string      `json:"op,omitempty" yaml:"op,omitempty"`
+	Value  interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	Or     bool        `json:"or,omitempty" yaml:"or,omitempty"`
+	Wheres []DSLWhere  `json:"wheres,omitempty" yaml:"wheres,omitempty"`
+}
+
+// DSLOrder the order of the query document, the direction could be asc (default) or desc.
+type DSLOrder struct {
+	Column    string `json:"column" yaml:"column"`
+	Direction string `json:"direction,omitempty" yaml:"direction,omitempty"`
+}
+
+// DSLPaginate the page of the query document, it's compiled to the limit and the offset clauses.
+type DSLPaginate struct {
+	Page     int `json:"page" yaml:"page"`
+	PageSize int `json:"page_size" yaml:"page_size"`
 }
@@ -28,7 +28,7 @@ func (builder *Builder) Union(query interface{}, all ...bool) Query {
 			Query: qb.Query,
 			All:   isUnionAll,
 		})
-		builder.Query.AddBinding("union", qb.GetBindings())
+		builder.Query.AddBinding("union", qb.Query.GetBindings())
 	}
 	return builder
 
@@ -2,7 +2,7 @@ package query
 
 import (
 	"fmt"
-	"reflect"
+	"sort"
 
 	"github.com/yaoapp/kun/log"
 	"github.com/yaoapp/xun"
@@ -13,19 +13,30 @@ import (
 // Update Update records in the database.
 func (builder *Builder) Update(v interface{}) (int64, error) {
 
+	builder, err := builder.scoped()
+	if err != nil {
+		return 0, err
+	}
+
 	values := xun.MakeR(v).ToMap()
 	sql, bindings := builder.Grammar.CompileUpdate(builder.Query, values)
 	defer log.With(log.F{"bindings": bindings}).Debug(sql)
 
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
+	builder.UseWrite()
+	builder, cancel, err := builder.withTimeout()
 	if err != nil {
 		return 0, err
 	}
+	defer cancel()
+	stmt, err := builder.executor().Prepare(sql)
+	if err != nil {
+		return 0, builder.translateError(err)
+	}
 	defer stmt.Close()
 
-	res, err := stmt.Exec(bindings...)
+	res, err := stmt.ExecContext(builder.Context(), bindings...)
 	if err != nil {
-		return 0, err
+		return 0, builder.translateError(err)
 	}
 
 	return res.RowsAffected()
@@ -38,6 +49,133 @@ func (builder *Builder) MustUpdate(v interface{}) int64 {
 	return affected
 }
 
+// UpdateBatch Update the records with their own values in one statement, the records are matched by the key column.
+// The "case" strategy sets each column by a "case" expression, the "values" strategy joins the rows as a derived table
+// on PostgreSQL and every row must have all the columns. The rows are split into several statements to stay under
+// the bindings limit of the database server, run it in a transaction if the statements should be atomic.
+//
+//	UpdateBatch([]xun.R{{"id": 1, "vote": 10}, {"id": 2, "vote": 20, "score": 99.5}}, "id")
+//	UpdateBatch([]xun.R{{"id": 1, "vote": 10}, {"id": 2, "vote": 20}}, "id", "values")
+func (builder *Builder) UpdateBatch(rows []xun.R, keyColumn string, strategy ...string) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	if len(builder.Query.Joins) > 0 {
+		return 0, fmt.Errorf("the batch update does not support joins")
+	}
+
+	mode := "case"
+	if len(strategy) > 0 {
+		mode = strategy[0]
+	}
+
+	if mode != "case" && mode != "values" {
+		return 0, fmt.Errorf(`the batch update strategy must be "case" or "values"`)
+	}
+
+	if mode == "values" {
+		version, err := builder.getVersion()
+		if err != nil {
+			return 0, err
+		}
+		if !builder.Grammar.SupportsUpdateBatchValues(version) {
+			return 0, fmt.Errorf("the values strategy of the batch update is not supported by %s %s", version.Driver, version.String())
+		}
+	}
+
+	columns, values, err := builder.prepareUpdateBatchValues(rows, keyColumn, mode)
+	if err != nil {
+		return 0, err
+	}
+
+	builder, err = builder.scoped()
+	if err != nil {
+		return 0, err
+	}
+
+	var affected int64 = 0
+	for _, chunk := range builder.chunkUpdateBatchValues(values, columns, mode) {
+		sql, bindings := builder.Grammar.CompileUpdateBatch(builder.Query, keyColumn, columns, chunk, mode)
+		log.With(log.F{"bindings": bindings}).Debug(sql)
+		res, err := builder.ExecWrite(sql, bindings...)
+		if err != nil {
+			return affected, err
+		}
+		chunkAffected, err := res.RowsAffected()
+		if err != nil {
+			return affected, err
+		}
+		affected = affected + chunkAffected
+	}
+	return affected, nil
+}
+
+// MustUpdateBatch Update the records with their own values in one statement, the records are matched by the key column.
+func (builder *Builder) MustUpdateBatch(rows []xun.R, keyColumn string, strategy ...string) int64 {
+	affected, err := builder.UpdateBatch(rows, keyColumn, strategy...)
+	utils.PanicIF(err)
+	return affected
+}
+
+// prepareUpdateBatchValues Get the columns to set (sorted, without the key column) and the values of the rows of a batch update
+func (builder *Builder) prepareUpdateBatchValues(rows []xun.R, keyColumn string, strategy string) ([]string, []map[string]interface{}, error) {
+	columns := []string{}
+	values := []map[string]interface{}{}
+	has := map[string]bool{}
+	for i, row := range rows {
+		if !row.Has(keyColumn) {
+			return nil, nil, fmt.Errorf("the row %d of the batch update has no %s column", i, keyColumn)
+		}
+		for _, column := range row.KeysString() {
+			if column != keyColumn && !has[column] {
+				has[column] = true
+				columns = append(columns, column)
+			}
+		}
+		values = append(values, row.ToMap())
+	}
+
+	if len(columns) == 0 {
+		return nil, nil, fmt.Errorf("the batch update has no column to set")
+	}
+	sort.Strings(columns)
+
+	if strategy == "values" {
+		for i, row := range rows {
+			for _, column := range columns {
+				if !row.Has(column) {
+					return nil, nil, fmt.Errorf("the row %d of the batch update has no %s column, the values strategy needs all the columns", i, column)
+				}
+			}
+		}
+	}
+	return columns, values, nil
+}
+
+// chunkUpdateBatchValues Split the rows of a batch update, the bindings of each statement stay under the limit of the database server
+func (builder *Builder) chunkUpdateBatchValues(values []map[string]interface{}, columns []string, strategy string) [][]map[string]interface{} {
+	limit := builder.Grammar.GetMaxBindings() - len(builder.Query.GetBindings("where"))
+	chunks := [][]map[string]interface{}{}
+	chunk := []map[string]interface{}{}
+	size := 0
+	for _, row := range values {
+		// the key is bound to the "in" list, and to each "when" clause of the columns the row has
+		cost := 1 + 2*(len(row)-1)
+		if strategy == "values" {
+			cost = 1 + len(columns)
+		}
+		if len(chunk) > 0 && size+cost > limit {
+			chunks = append(chunks, chunk)
+			chunk = []map[string]interface{}{}
+			size = 0
+		}
+		chunk = append(chunk, row)
+		size = size + cost
+	}
+	return append(chunks, chunk)
+}
+
 // UpdateOrInsert Insert or update a record matching the attributes, and fill it with values.
 func (builder *Builder) UpdateOrInsert(attributes interface{}, values ...interface{}) (bool, error) {
 
@@ -77,45 +215,21 @@ func (builder *Builder) MustUpdateOrInsert(attributes interface{}, values ...int
 }
 
 // Upsert new records or update the existing ones.
+// The rows are split into batches like the Insert method, it returns the sum of the affected rows of the batches.
 func (builder *Builder) Upsert(v interface{}, uniqueBy interface{}, update interface{}, columns ...interface{}) (int64, error) {
-
+	columns, size := splitBatchSize(columns)
 	columns, values := builder.prepareInsertValues(v, columns...)
-	sql, bindings := builder.Grammar.CompileUpsert(builder.Query, columns, values, utils.Flatten(uniqueBy), update)
-	defer log.With(log.F{"bindings": bindings}).Debug(sql)
-
-	stmt, err := builder.UseWrite().DB().Prepare(sql)
-	if err != nil {
-		return 0, err
-	}
-	defer stmt.Close()
-
-	// res, err := stmt.Exec(bindings...)
-	// if err != nil {
-	// 	return 0, err
-	// }
-	var res int64 = 0
-
-	if len(bindings) > 0 {
-		var dummy1 []interface{}
-		if reflect.TypeOf(bindings[0]) == reflect.TypeOf(dummy1) {
-			for _, row := range bindings {
-				sqlres, err := stmt.Exec(row.([]interface{})...)
-				if err != nil {
-					return 0, err
-				}
-				res2, _ := sqlres.RowsAffected()
-				res = res + res2
-			}
-			return res, nil
-		}
+	reserved := 0
+	if updateValues, ok := update.(map[string]interface{}); ok {
+		reserved = len(updateValues)
 	}
-	sqlres, err := stmt.Exec(bindings...)
+	batches, err := builder.chunkInsertValues(columns, values, size, reserved)
 	if err != nil {
 		return 0, err
 	}
-	res2, _ := sqlres.RowsAffected()
-
-	return res2, err
+	return builder.execInsertBatches(batches, func(values [][]interface{}) (string, []interface{}) {
+		return builder.Grammar.CompileUpsert(builder.Query, columns, values, utils.Flatten(uniqueBy), update)
+	})
 }
 
 // MustUpsert new records or update the existing ones.
@@ -89,6 +89,25 @@ func TestUpdateMustUpsertUpdateRaw(t *testing.T) {
 	}
 }
 
+func TestUpdateMustUpsertBatchSize(t *testing.T) {
+	NewTableForUpdateTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_update").MustUpsert([]xun.R{
+		{"email": "john@yao.run", "name": "John", "vote": 30, "score": 96.32, "score_grade": 99.27},
+		{"email": "lee@yao.run", "name": "Lee", "vote": 31, "score": 64.56, "score_grade": 99.27},
+		{"email": "max@yao.run", "name": "Max", "vote": 32, "score": 86.32, "score_grade": 99.27},
+	}, "email", []string{"vote"}, BatchSize(2))
+
+	if unit.DriverIs("mysql") {
+		assert.Equal(t, int64(5), affected, "The affected rows should be 5")
+	} else {
+		assert.Equal(t, int64(3), affected, "The affected rows should be 3")
+	}
+	assert.Equal(t, int64(5), qb.Table("table_test_update").MustCount(), "The table should has 5 rows")
+	vote := qb.Table("table_test_update").Where("email", "lee@yao.run").MustValue("vote")
+	assert.Equal(t, "31", fmt.Sprintf("%v", vote), "the vote of lee should be 31")
+}
+
 func TestUpdateMustUpdate(t *testing.T) {
 	NewTableForUpdateTest()
 	qb := getTestBuilder()
@@ -153,6 +172,162 @@ func TestUpdateMustUpdateWithJoin(t *testing.T) {
 
 }
 
+func TestUpdateMustUpdateWithJoinColumn(t *testing.T) {
+	if unit.DriverIs("hdb") {
+		return
+	}
+
+	NewTableForUpdateTest()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_update_bonus")
+	builder.MustCreateTable("table_test_update_bonus", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.Integer("bonus")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_update_bonus").MustInsert([]xun.R{
+		{"email": "john@yao.run", "bonus": 100},
+		{"email": "ken@yao.run", "bonus": 300},
+	})
+
+	bonus := dbal.Raw(qb.Builder().Grammar.Wrap("table_test_update_bonus.bonus"))
+	affected := qb.Table("table_test_update").
+		Join("table_test_update_bonus", "table_test_update_bonus.email", "=", "table_test_update.email").
+		Where("table_test_update_bonus.bonus", ">", 200).
+		MustUpdate(xun.R{"table_test_update.vote": bonus})
+	assert.Equal(t, int64(1), affected, "The affected rows should be 1")
+
+	rows := qb.Table("table_test_update").Select("email", "vote").OrderBy("id").MustGet()
+	assert.Equal(t, 4, len(rows), "the return value should has 4 rows")
+	if len(rows) == 4 {
+		assert.Equal(t, "10", fmt.Sprintf("%v", rows[0]["vote"]), "the vote of john should not be changed")
+		assert.Equal(t, "300", fmt.Sprintf("%v", rows[2]["vote"]), "the vote of ken should be 300")
+	}
+	builder.DropTableIfExists("table_test_update_bonus")
+}
+
+func TestUpdateMustUpdateWithLeftJoin(t *testing.T) {
+	if unit.DriverIs("hdb") {
+		return
+	}
+
+	NewTableForUpdateTest()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_update_bonus")
+	builder.MustCreateTable("table_test_update_bonus", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("email")
+		table.Integer("bonus")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_update_bonus").MustInsert([]xun.R{
+		{"email": "john@yao.run", "bonus": 100},
+		{"email": "ken@yao.run", "bonus": 300},
+	})
+
+	affected := qb.Table("table_test_update").
+		LeftJoin("table_test_update_bonus as b", "b.email", "=", "table_test_update.email").
+		WhereNull("b.id").
+		MustUpdate(xun.R{"vote": 0})
+	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
+
+	rows := qb.Table("table_test_update").Select("email", "vote").OrderBy("id").MustGet()
+	assert.Equal(t, 4, len(rows), "the return value should has 4 rows")
+	if len(rows) == 4 {
+		assert.Equal(t, "10", fmt.Sprintf("%v", rows[0]["vote"]), "the vote of john should not be changed")
+		assert.Equal(t, "0", fmt.Sprintf("%v", rows[1]["vote"]), "the vote of lee should be 0")
+		assert.Equal(t, "0", fmt.Sprintf("%v", rows[3]["vote"]), "the vote of ben should be 0")
+	}
+	builder.DropTableIfExists("table_test_update_bonus")
+}
+
+func TestUpdateMustUpdateBatch(t *testing.T) {
+	NewTableForUpdateTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_update").
+		Where("status", "<>", "DONE").
+		MustUpdateBatch([]xun.R{
+			{"id": 1, "vote": 11, "name": "Johnny"},
+			{"id": 2, "vote": 12},
+			{"id": 3, "vote": 13},
+		}, "id")
+	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
+
+	rows := qb.Table("table_test_update").Select("name", "vote").OrderBy("id").MustGet()
+	assert.Equal(t, 4, len(rows), "the return value should has 4 rows")
+	if len(rows) == 4 {
+		assert.Equal(t, "Johnny", rows[0]["name"], "the name of 1st row should be Johnny")
+		assert.Equal(t, "11", fmt.Sprintf("%v", rows[0]["vote"]), "the vote of 1st row should be 11")
+		assert.Equal(t, "Lee", rows[1]["name"], "the name of 2nd row should not be changed")
+		assert.Equal(t, "12", fmt.Sprintf("%v", rows[1]["vote"]), "the vote of 2nd row should be 12")
+		assert.Equal(t, "125", fmt.Sprintf("%v", rows[2]["vote"]), "the vote of 3rd row should not be changed")
+	}
+}
+
+func TestUpdateMustUpdateBatchChunked(t *testing.T) {
+	NewTableForUpdateTest()
+	qb := getTestBuilder()
+	values := []xun.R{}
+	for i := 0; i < 600; i++ {
+		values = append(values, xun.R{"email": fmt.Sprintf("user%d@yao.run", i), "name": "User", "vote": 0, "score": 1, "score_grade": 1})
+	}
+	qb.Table("table_test_update").MustInsert(values)
+
+	// each row costs 3 bindings, the rows are split when they are over the limit of the driver
+	rows := []xun.R{}
+	limit := qb.Builder().Grammar.GetMaxBindings()/3 + 10
+	for i := 1; i <= 604 && i <= limit; i++ {
+		rows = append(rows, xun.R{"id": i, "vote": i * 2})
+	}
+	affected := qb.Table("table_test_update").MustUpdateBatch(rows, "id")
+	assert.Equal(t, int64(len(rows)), affected, "All the rows should be updated")
+
+	sum := qb.Table("table_test_update").MustSum("vote")
+	assert.Equal(t, len(rows)*(len(rows)+1), sum.MustInt(), "the sum of the votes should be matched")
+}
+
+func TestUpdateMustUpdateBatchValues(t *testing.T) {
+	NewTableForUpdateTest()
+	qb := getTestBuilder()
+	version, err := qb.Builder().getVersion()
+	assert.Nil(t, err, "the version should be returned")
+
+	rows := []xun.R{{"id": 1, "vote": 21, "score": 1.5}, {"id": 4, "vote": 24, "score": 4.5}}
+	if !qb.Builder().Grammar.SupportsUpdateBatchValues(version) {
+		_, err := qb.Table("table_test_update").UpdateBatch(rows, "id", "values")
+		assert.Equal(t, fmt.Sprintf("the values strategy of the batch update is not supported by %s %s", version.Driver, version.String()), err.Error())
+		return
+	}
+
+	affected := qb.Table("table_test_update").MustUpdateBatch(rows, "id", "values")
+	assert.Equal(t, int64(2), affected, "The affected rows should be 2")
+	vote := qb.Table("table_test_update").Where("id", 4).MustValue("vote")
+	assert.Equal(t, "24", fmt.Sprintf("%v", vote), "the vote of 4th row should be 24")
+
+	_, err = qb.Table("table_test_update").UpdateBatch([]xun.R{{"id": 1, "vote": 21}, {"id": 2}}, "id", "values")
+	assert.Equal(t, "the row 1 of the batch update has no vote column, the values strategy needs all the columns", err.Error())
+}
+
+func TestUpdateUpdateBatchError(t *testing.T) {
+	NewTableForUpdateTest()
+	qb := getTestBuilder()
+	_, err := qb.Table("table_test_update").UpdateBatch([]xun.R{{"vote": 1}}, "id")
+	assert.Equal(t, "the row 0 of the batch update has no id column", err.Error())
+
+	_, err = qb.Table("table_test_update").UpdateBatch([]xun.R{{"id": 1}}, "id")
+	assert.Equal(t, "the batch update has no column to set", err.Error())
+
+	_, err = qb.Table("table_test_update").UpdateBatch([]xun.R{{"id": 1, "vote": 1}}, "id", "merge")
+	assert.Equal(t, `the batch update strategy must be "case" or "values"`, err.Error())
+
+	affected, err := qb.Table("table_test_update").UpdateBatch([]xun.R{}, "id")
+	assert.Nil(t, err, "the empty batch update should not return an error")
+	assert.Equal(t, int64(0), affected, "The affected rows should be 0")
+}
+
 func TestUpdateMustIncrement(t *testing.T) {
 	NewTableForUpdateTest()
 	qb := getTestBuilder()
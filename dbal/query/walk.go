@@ -0,0 +1,23 @@
+package query
+
+import "github.com/yaoapp/xun/dbal"
+
+// Walk Walk the query syntax tree with the visitor, the tables, the columns, the bindings and the subqueries could be
+// replaced and the conditions could be added to the queries of the nodes. The subqueries compiled to SQL are compiled
+// again after they are rewritten, the query is not changed if the visitor returns an error.
+//
+//	qb.Walk(func(node *dbal.Node) error {
+//		if table, ok := node.Value.(dbal.Name); ok && node.Type == dbal.NodeTable && table.Name == "orders" {
+//			node.Where(table.Reference()+".tenant_id", "=", tenantID)
+//		}
+//		return nil
+//	})
+func (builder *Builder) Walk(visitor dbal.Visitor) error {
+	query := translateQuery(builder.Grammar, builder.Query)
+	err := dbal.Walk(query, visitor)
+	if err != nil {
+		return err
+	}
+	builder.Query = translateQuery(builder.Grammar, query)
+	return nil
+}
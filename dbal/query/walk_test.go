@@ -0,0 +1,198 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+// tenantVisitor add the tenant predicate to every table
+func tenantVisitor(tenantID int) dbal.Visitor {
+	return func(node *dbal.Node) error {
+		if table, ok := node.Value.(dbal.Name); ok && node.Type == dbal.NodeTable {
+			node.Where(table.Reference()+".tenant_id", "=", tenantID)
+		}
+		return nil
+	}
+}
+
+func TestWalkNodes(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.Table("users as u").
+		Select("u.id", "u.name").
+		Join("posts as p", "p.user_id", "u.id").
+		Where("u.vote", ">", 10).
+		WhereIn("u.id", func(sub Query) {
+			sub.From("members").Select("user_id").Where("team", "core")
+		}).
+		OrderBy("u.id")
+
+	nodes := []string{}
+	err := qb.Walk(func(node *dbal.Node) error {
+		switch node.Type {
+		case dbal.NodeTable:
+			nodes = append(nodes, fmt.Sprintf("%d:%s:table:%s", node.Depth, node.Clause, node.Value.(dbal.Name).Name))
+		case dbal.NodeColumn:
+			nodes = append(nodes, fmt.Sprintf("%d:%s:column:%v", node.Depth, node.Clause, node.Value))
+		case dbal.NodeBinding:
+			nodes = append(nodes, fmt.Sprintf("%d:%s:binding:%v", node.Depth, node.Clause, node.Value))
+		case dbal.NodeQuery:
+			nodes = append(nodes, fmt.Sprintf("%d:%s:query", node.Depth, node.Clause))
+		}
+		return nil
+	})
+	assert.Nil(t, err, "the query should be walked")
+	assert.Equal(t, []string{
+		"0::query",
+		"0:select:column:u.id", "0:select:column:u.name",
+		"0:from:table:users",
+		"0:join:table:posts", "0:join:column:p.user_id", "0:join:column:u.id",
+		"0:where:column:u.vote", "0:where:column:u.id",
+		"1:where:query", "1:select:column:user_id", "1:from:table:members", "1:where:column:team",
+		"0:order:column:u.id",
+		"0:where:binding:10", "0:where:binding:core",
+	}, nodes, "the nodes should be walked in depth-first order")
+}
+
+func TestWalkTenant(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	qb.Table("users as u").
+		LeftJoin("posts as p", "p.user_id", "u.id").
+		Where("u.vote", ">", 10).
+		OrWhere("u.vote", "<", 2).
+		WhereIn("u.id", func(sub Query) {
+			sub.From("members").Select("user_id").Where("team", "core")
+		})
+
+	err := qb.Walk(tenantVisitor(7))
+	assert.Nil(t, err, "the tenant predicate should be added")
+	assert.Equal(t,
+		"select * from `users` as `u` left join `posts` as `p` on `p`.`user_id` = `u`.`id` and `p`.`tenant_id` = ? "+
+			"where (`u`.`vote` > ? or `u`.`vote` < ? and `u`.`id` in (select `user_id` from `members` where `team` = ? and `members`.`tenant_id` = ?)) and `u`.`tenant_id` = ?",
+		qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{7, 10, 2, "core", 7, 7}, qb.GetBindings(), "the bindings should be consistent")
+
+	qb = NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")}, dbal.Option{Prefix: "xun_"})
+	qb.Table("users").
+		FromSub(func(sub Query) {
+			sub.From("users").Where("vote", ">", 10)
+		}, "u").
+		Where("u.status", "active")
+
+	err = qb.Walk(tenantVisitor(7))
+	assert.Nil(t, err, "the tenant predicate should be added")
+	assert.Equal(t,
+		`select * from (select * from "xun_users" where "vote" > $1 and "xun_users"."tenant_id" = $2) as "u" where "u"."status" = $3`,
+		qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{10, 7, "active"}, qb.GetBindings(), "the bindings should be consistent")
+}
+
+func TestWalkReplace(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	qb.Table("users").Select("id", "mail").Where("mail", "like", "%@yao.run").OrderBy("mail")
+
+	err := qb.Walk(func(node *dbal.Node) error {
+		switch {
+		case node.Type == dbal.NodeTable:
+			node.Replace("accounts")
+		case node.Type == dbal.NodeColumn && node.Value == "mail":
+			node.Replace("email")
+		case node.Type == dbal.NodeBinding:
+			node.Replace("%@yaojs.org")
+		}
+		return nil
+	})
+	assert.Nil(t, err, "the nodes should be replaced")
+	assert.Equal(t, "select `id`, `email` from `accounts` where `email` like ? order by `email` asc", qb.ToSQL(), "the query sql not equal")
+	assert.Equal(t, []interface{}{"%@yaojs.org"}, qb.GetBindings(), "the binding should be replaced")
+}
+
+func TestWalkError(t *testing.T) {
+	qb := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	qb.Table("users").WhereExists(func(sub Query) {
+		sub.From("secrets").WhereColumn("secrets.user_id", "users.id")
+	})
+	sql := qb.ToSQL()
+
+	errBlocked := errors.New("the secrets table is not allowed")
+	err := qb.Walk(func(node *dbal.Node) error {
+		if node.Type == dbal.NodeTable {
+			node.Replace("tables")
+			if node.Value.(dbal.Name).Name == "tables" && node.Depth > 0 {
+				return errBlocked
+			}
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errBlocked, "the visitor error should be returned")
+	assert.Equal(t, sql, qb.ToSQL(), "the query should not be changed")
+
+	// the bindings of two raw where clauses could not be located
+	qb = NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	qb.Table("users").WhereRaw("vote > ?", 1).WhereRaw("score > ?", 2).WhereExists(func(sub Query) {
+		sub.From("posts")
+	})
+	err = qb.Walk(tenantVisitor(7))
+	assert.ErrorIs(t, err, dbal.ErrWalkBindings, "the bindings could not be rebuilt")
+
+	// the subqueries are skipped
+	err = qb.Walk(func(node *dbal.Node) error {
+		if node.Type == dbal.NodeQuery && node.Depth > 0 {
+			return dbal.SkipNode
+		}
+		if node.Type == dbal.NodeTable {
+			node.Replace("accounts")
+		}
+		return nil
+	})
+	assert.Nil(t, err, "the skipped subqueries should not return the error")
+	assert.Equal(t, "select * from `accounts` where vote > ? and score > ? and exists (select * from `posts`)", qb.ToSQL(), "the query sql not equal")
+}
+
+func TestWalkGet(t *testing.T) {
+	NewTableForWalkTest()
+	qb := getTestBuilder().New()
+	qb.Table("table_test_walk as w").Where("w.vote", ">", 1).OrWhere("w.vote", "<", 0).OrderBy("w.id")
+
+	err := qb.Walk(tenantVisitor(2))
+	if !assert.Nil(t, err, "the tenant predicate should be added") {
+		return
+	}
+
+	rows := qb.MustGet()
+	if assert.Equal(t, 1, len(rows), "the return value should has 1 row") {
+		assert.Equal(t, "Lee", rows[0]["name"], "the name should be Lee")
+	}
+}
+
+// clean the test data
+func TestWalkClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_walk")
+}
+
+func NewTableForWalkTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_walk")
+	builder.MustCreateTable("table_test_walk", func(table schema.Blueprint) {
+		table.ID("id")
+		table.Integer("tenant_id")
+		table.String("name")
+		table.Integer("vote")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_walk").MustInsert([]xun.R{
+		{"tenant_id": 1, "name": "John", "vote": 10},
+		{"tenant_id": 2, "name": "Lee", "vote": 5},
+		{"tenant_id": 2, "name": "Ken", "vote": 1},
+	})
+}
@@ -1,11 +1,13 @@
 package query
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 
 	"github.com/yaoapp/xun"
 	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
 	"github.com/yaoapp/xun/utils"
 )
 
@@ -91,6 +93,7 @@ func (builder *Builder) where(column interface{}, operator string, value interfa
 		segment := builder.parseSub(sub)
 		builder.Query.AddBinding("where", bindings)
 		builder.Where(dbal.Raw(fmt.Sprintf("(%s)", segment)), operator, value, boolean, whereOffset)
+		builder.Query.Wheres[len(builder.Query.Wheres)-1].Query = subQuery(sub)
 		return builder
 	}
 
@@ -242,8 +245,8 @@ func (builder *Builder) makeSub(subquery interface{}) (interface{}, []interface{
 	switch subquery.(type) {
 	case *Builder:
 		qb := builder.prependDatabaseNameIfCrossDatabaseQuery(subquery.(*Builder))
-		offset := len(builder.GetBindings())
-		bindings := qb.GetBindings()
+		offset := len(builder.Query.GetBindings())
+		bindings := qb.Query.GetBindings()
 		whereOffset := offset + len(utils.Flatten(bindings))
 		qb.Query.BindingOffset = offset
 		return qb.Query, bindings, whereOffset
@@ -460,6 +463,7 @@ func (builder *Builder) OrWhereNotIn(column interface{}, values interface{}) Que
 func (builder *Builder) whereIn(column interface{}, values interface{}, boolean string, not bool) Query {
 
 	inOffset := 0
+	var query *dbal.Query
 
 	// If the value is a query builder instance we will assume the developer wants to
 	// look for any values that exists within this given query. So we will add the
@@ -468,12 +472,14 @@ func (builder *Builder) whereIn(column interface{}, values interface{}, boolean
 		sub, bindings, offset := builder.createSub(values)
 		segment := builder.parseSub(sub)
 		values = dbal.Raw(segment)
+		query = subQuery(sub)
 		builder.Query.AddBinding("where", bindings)
 		inOffset = offset
 	}
 
 	where := dbal.Where{
 		Type:     "in",
+		Query:    query,
 		ValuesIn: values,
 		Column:   column,
 		Offset:   inOffset,
@@ -521,7 +527,7 @@ func (builder *Builder) whereExists(closure func(qb Query), boolean string, not
 		Boolean: boolean,
 		Query:   new.Query,
 	})
-	builder.Query.AddBinding("where", new.GetBindings())
+	builder.Query.AddBinding("where", new.Query.GetBindings())
 	return builder
 }
 
@@ -645,25 +651,114 @@ func (builder *Builder) Unless(value bool, callback func(qb Query, value bool),
 }
 
 // WhereJSONContains Add a "where JSON contains" clause to the query.
-func (builder *Builder) WhereJSONContains() {
+//
+//	WhereJSONContains("options->languages", "en")
+//	WhereJSONContains("options->languages", []string{"en", "de"})
+func (builder *Builder) WhereJSONContains(column string, value interface{}) Query {
+	return builder.whereJSONContains(column, value, "and", false)
 }
 
 // OrWhereJSONContains Add an "or where JSON contains" clause to the query.
-func (builder *Builder) OrWhereJSONContains() {
+func (builder *Builder) OrWhereJSONContains(column string, value interface{}) Query {
+	return builder.whereJSONContains(column, value, "or", false)
 }
 
 // WhereJSONDoesntContain Add a "where JSON not contains" clause to the query.
-func (builder *Builder) WhereJSONDoesntContain() {
+func (builder *Builder) WhereJSONDoesntContain(column string, value interface{}) Query {
+	return builder.whereJSONContains(column, value, "and", true)
 }
 
 // OrWhereJSONDoesntContain Add an "or where JSON not contains" clause to the query.
-func (builder *Builder) OrWhereJSONDoesntContain() {
+func (builder *Builder) OrWhereJSONDoesntContain(column string, value interface{}) Query {
+	return builder.whereJSONContains(column, value, "or", true)
+}
+
+// whereJSONContains Add a "where JSON contains" clause to the query.
+func (builder *Builder) whereJSONContains(column string, value interface{}, boolean string, not bool) Query {
+	if !builder.isExpression(value) {
+		bytes, err := json.Marshal(value)
+		if err != nil {
+			panic(fmt.Errorf("the value can't be encoded to JSON. (%s)", err))
+		}
+		value = string(bytes)
+	}
+
+	builder.Query.Wheres = append(builder.Query.Wheres, dbal.Where{
+		Type:    "jsonContains",
+		Column:  column,
+		Value:   value,
+		Boolean: boolean,
+		Not:     not,
+		Offset:  1,
+	})
+
+	if !builder.isExpression(value) {
+		builder.Query.AddBinding("where", value)
+	}
+	return builder
 }
 
 // WhereJSONLength Add a "where JSON length" clause to the query.
-func (builder *Builder) WhereJSONLength() {
+//
+//	WhereJSONLength("options->languages", 0)
+//	WhereJSONLength("options->languages", ">", 1)
+func (builder *Builder) WhereJSONLength(column string, args ...interface{}) Query {
+	operator, value, boolean, _ := builder.prepareWhereArgs(args...)
+	builder.Query.Wheres = append(builder.Query.Wheres, dbal.Where{
+		Type:     "jsonLength",
+		Column:   column,
+		Operator: operator,
+		Value:    value,
+		Boolean:  boolean,
+		Offset:   1,
+	})
+
+	if !builder.isExpression(value) {
+		builder.Query.AddBinding("where", value)
+	}
+	return builder
 }
 
 // OrWhereJSONLength Add an "or where JSON length" clause to the query.
-func (builder *Builder) OrWhereJSONLength() {
+func (builder *Builder) OrWhereJSONLength(column string, args ...interface{}) Query {
+	operator, value, _, _ := builder.prepareWhereArgs(args...)
+	return builder.WhereJSONLength(column, operator, value, "or")
+}
+
+// WhereJSONContainsKey Add a "where JSON contains key" clause to the query.
+//
+//	WhereJSONContainsKey("options->address->city")
+//	WhereJSONContainsKey("options->languages->0")
+func (builder *Builder) WhereJSONContainsKey(column string) Query {
+	return builder.whereJSONContainsKey(column, "and", false)
+}
+
+// OrWhereJSONContainsKey Add an "or where JSON contains key" clause to the query.
+func (builder *Builder) OrWhereJSONContainsKey(column string) Query {
+	return builder.whereJSONContainsKey(column, "or", false)
+}
+
+// WhereJSONDoesntContainKey Add a "where JSON not contains key" clause to the query.
+func (builder *Builder) WhereJSONDoesntContainKey(column string) Query {
+	return builder.whereJSONContainsKey(column, "and", true)
+}
+
+// OrWhereJSONDoesntContainKey Add an "or where JSON not contains key" clause to the query.
+func (builder *Builder) OrWhereJSONDoesntContainKey(column string) Query {
+	return builder.whereJSONContainsKey(column, "or", true)
+}
+
+// whereJSONContainsKey Add a "where JSON contains key" clause to the query.
+func (builder *Builder) whereJSONContainsKey(column string, boolean string, not bool) Query {
+	if _, path := sql.ParseJSONSelector(column); !sql.IsJSONSelector(column) || len(path) == 0 {
+		panic(fmt.Errorf("the column %q must be a JSON selector, e.g. options->address->city", column))
+	}
+
+	builder.Query.Wheres = append(builder.Query.Wheres, dbal.Where{
+		Type:    "jsonContainsKey",
+		Column:  column,
+		Boolean: boolean,
+		Not:     not,
+	})
+	return builder
 }
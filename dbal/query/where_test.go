@@ -2,7 +2,6 @@ package query
 
 import (
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/yaoapp/xun"
@@ -48,11 +47,7 @@ func TestWhereWhereArray(t *testing.T) {
 		assert.Equal(t, "john@yao.run", rows[0]["email"].(string), "the email of first row should be john@yao.run")
 		assert.Equal(t, int64(1), rows[0]["id"].(int64), "the email of first row should be 1")
 		assert.Equal(t, "WAITING", rows[0]["status"].(string), "the email of first row should be WAITING")
-		if unit.DriverIs("sqlite3") {
-			assert.Equal(t, "2021-03-25 00:21:16", rows[0]["created_at"].(string), "the email of first row should be WAITING")
-		} else {
-			assert.Equal(t, "2021-03-25T00:21:16", rows[0]["created_at"].(time.Time).Format("2006-01-02T15:04:05"), "the email of first row should be WAITING")
-		}
+		assert.Equal(t, "2021-03-25T00:21:16", rows[0]["created_at"].(xun.T).MustToTime().Format("2006-01-02T15:04:05"), "the email of first row should be WAITING")
 
 	}
 }
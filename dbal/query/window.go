@@ -0,0 +1,137 @@
+package query
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+var reWindowFunc = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Over Make a new window specification, the name is the named window the specification is based on.
+//
+//	Over().PartitionBy("dept").OrderBy("salary", "desc")
+//	Over("w").Rows("unbounded preceding", "current row")
+func Over(name ...string) *dbal.Window {
+	return dbal.NewWindow(name...)
+}
+
+// SelectWindow Add a window function column to the query.
+// The args could be nil, a column name, a raw expression or a slice of them,
+// the over could be a window specification made by Over() or the name of a named window.
+//
+//	SelectWindow("row_number", nil, Over().PartitionBy("dept").OrderBy("salary", "desc"), "rn")
+//	SelectWindow("sum", "salary", "w", "running_total")
+//	SelectWindow("lag", []interface{}{"salary", 1}, Over().OrderBy("id"), "prev")
+func (builder *Builder) SelectWindow(fn string, args interface{}, over interface{}, alias string) Query {
+	if !reWindowFunc.MatchString(fn) {
+		panic(fmt.Errorf("the window function name %q is invalid", fn))
+	}
+
+	window := builder.prepareWindow(over)
+	builder.Query.AddColumn(dbal.WindowFunction{
+		Func:   fn,
+		Args:   builder.prepareWindowArgs(args),
+		Window: window,
+		Alias:  alias,
+	})
+	return builder
+}
+
+// Window Add a named window to the "window" clause of the query.
+//
+//	Window("w", Over().PartitionBy("dept").OrderBy("salary", "desc"))
+func (builder *Builder) Window(name string, window *dbal.Window) Query {
+	if window == nil {
+		window = Over()
+	}
+	over := builder.prepareWindow(window)
+	builder.Query.Windows = append(builder.Query.Windows, dbal.NamedWindow{
+		Name:   name,
+		Window: over,
+	})
+	return builder
+}
+
+// prepareWindow Cast the given over value to a window specification
+func (builder *Builder) prepareWindow(over interface{}) dbal.Window {
+	var window dbal.Window
+	switch value := over.(type) {
+	case nil:
+		window = *Over()
+	case string:
+		window = *Over(value)
+	case *dbal.Window:
+		window = *value
+	case dbal.Window:
+		window = value
+	default:
+		panic(fmt.Errorf("the window must be a window specification or the name of a named window"))
+	}
+
+	err := window.Validate()
+	if err != nil {
+		panic(err)
+	}
+	return window
+}
+
+// prepareWindowArgs Cast the given arguments of a window function to a slice
+func (builder *Builder) prepareWindowArgs(args interface{}) []interface{} {
+	switch values := args.(type) {
+	case nil:
+		return []interface{}{}
+	case []interface{}:
+		return values
+	case []string:
+		res := []interface{}{}
+		for _, value := range values {
+			res = append(res, value)
+		}
+		return res
+	}
+	return []interface{}{args}
+}
+
+// checkWindowFunctions Return an error if the query uses the window functions and the database server does not support them,
+// the check is skipped when the version of the server is unknown.
+func (builder *Builder) checkWindowFunctions() error {
+	if !hasWindowFunctions(builder.Query) {
+		return nil
+	}
+
+	version, err := builder.getVersion()
+	if err != nil {
+		return nil
+	}
+
+	if !builder.Grammar.SupportsWindowFunctions(version) {
+		return fmt.Errorf("the window functions are not supported by %s %s", version.Driver, version.String())
+	}
+	return nil
+}
+
+// hasWindowFunctions Determine if the query or its subqueries use the window functions or the named windows
+func hasWindowFunctions(query *dbal.Query) bool {
+	has := false
+	dbal.Walk(query, func(node *dbal.Node) error {
+		if node.Type != dbal.NodeQuery {
+			return nil
+		}
+
+		if len(node.Query.Windows) > 0 {
+			has = true
+			return nil
+		}
+
+		for _, column := range node.Query.Columns {
+			if _, ok := column.(dbal.WindowFunction); ok {
+				has = true
+				return nil
+			}
+		}
+		return nil
+	})
+	return has
+}
@@ -0,0 +1,165 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestWindowSelectWindow(t *testing.T) {
+	NewTableForWindowTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_window").
+		Select("name", "dept", "salary").
+		SelectWindow("row_number", nil, Over().PartitionBy("dept").OrderBy("salary", "desc"), "rn").
+		OrderBy("dept").
+		OrderBy("rn")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "name", "dept", "salary", row_number() over (partition by "dept" order by "salary" desc) as "rn" from "table_test_window" order by "dept" asc, "rn" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `name`, `dept`, `salary`, row_number() over (partition by `dept` order by `salary` desc) as `rn` from `table_test_window` order by `dept` asc, `rn` asc", sql, "the query sql not equal")
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 5, len(rows), "the return value should has 5 rows")
+	if len(rows) == 5 {
+		assert.Equal(t, "Bob", rows[0]["name"].(string), "the name of 1st row should be Bob")
+		assert.Equal(t, int64(1), rows[0].Get("rn"), "the rn of 1st row should be 1")
+		assert.Equal(t, "Cat", rows[1]["name"].(string), "the name of 2nd row should be Cat")
+		assert.Equal(t, int64(2), rows[1].Get("rn"), "the rn of 2nd row should be 2")
+		assert.Equal(t, "Eve", rows[3]["name"].(string), "the name of 4th row should be Eve")
+		assert.Equal(t, int64(1), rows[3].Get("rn"), "the rn of 4th row should be 1")
+	}
+}
+
+func TestWindowNamedWindowAndFrame(t *testing.T) {
+	NewTableForWindowTest()
+	qb := getTestBuilder()
+	qb.Table("table_test_window").
+		Select("name").
+		SelectWindow("sum", "salary", Over("w").Rows("unbounded preceding", "current row"), "running_total").
+		SelectWindow("count", "*", "w", "total").
+		Window("w", Over().PartitionBy("dept").OrderBy("id")).
+		OrderBy("id")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `select "name", sum("salary") over ("w" rows between unbounded preceding and current row) as "running_total", count(*) over "w" as "total" from "table_test_window" window "w" as (partition by "dept" order by "id" asc) order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "select `name`, sum(`salary`) over (`w` rows between unbounded preceding and current row) as `running_total`, count(*) over `w` as `total` from `table_test_window` window `w` as (partition by `dept` order by `id` asc) order by `id` asc", sql, "the query sql not equal")
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 5, len(rows), "the return value should has 5 rows")
+	if len(rows) == 5 {
+		assert.Equal(t, "100", fmt.Sprintf("%v", rows[0].Get("running_total")), "the running total of 1st row should be 100")
+		assert.Equal(t, int64(1), rows[0].Get("total"), "the total of 1st row should be 1")
+		assert.Equal(t, "300", fmt.Sprintf("%v", rows[1].Get("running_total")), "the running total of 2nd row should be 300")
+		assert.Equal(t, int64(2), rows[1].Get("total"), "the total of 2nd row should be 2")
+	}
+}
+
+func TestWindowLag(t *testing.T) {
+	NewTableForWindowTest()
+	qb := getTestBuilder()
+	rows := qb.Table("table_test_window").
+		Select("name").
+		SelectWindow("lag", []interface{}{"name", 1}, Over().OrderBy("id"), "prev").
+		OrderBy("id").
+		MustGet()
+
+	assert.Equal(t, 5, len(rows), "the return value should has 5 rows")
+	if len(rows) == 5 {
+		assert.Nil(t, rows[0]["prev"], "the prev of 1st row should be nil")
+		assert.Equal(t, "Bob", rows[2]["prev"], "the prev of 3rd row should be Bob")
+	}
+}
+
+func TestWindowInvalid(t *testing.T) {
+	qb := getTestBuilder()
+	assert.PanicsWithError(t, `the frame start bound "two preceding" is invalid`, func() {
+		qb.Table("table_test_window").SelectWindow("sum", "salary", Over().Rows("two preceding"), "s")
+	})
+	assert.PanicsWithError(t, `the window function name "sum(salary);" is invalid`, func() {
+		qb.Table("table_test_window").SelectWindow("sum(salary);", nil, nil, "s")
+	})
+}
+
+func TestWindowUnsupportedVersion(t *testing.T) {
+	if unit.DriverIs("postgres") {
+		return
+	}
+
+	builder := getTestBuilder().Builder()
+	conn := *builder.Conn
+	builder.Conn = &conn
+	if unit.DriverIs("sqlite3") {
+		conn.Version = &dbal.Version{Driver: "sqlite3", Version: semver.MustParse("3.24.0")}
+	} else {
+		conn.Version = &dbal.Version{Driver: "mysql", Version: semver.MustParse("5.7.30")}
+	}
+
+	qb := builder.Table("table_test_window").SelectWindow("row_number", nil, Over(), "rn")
+	_, err := qb.Get()
+	assert.EqualError(t, err, "the window functions are not supported by "+conn.Version.Driver+" "+conn.Version.String())
+}
+
+func TestWindowOffline(t *testing.T) {
+	qb := NewOffline("mysql", dbal.Version{Version: semver.MustParse("5.7.30")})
+	qb.Table("users").Select("name").SelectWindow("row_number", nil, Over().OrderBy("id"), "rn")
+	assert.Equal(t, "select `name`, row_number() over (order by `id` asc) as `rn` from `users`", qb.ToSQL(), "the query sql not equal")
+
+	// the support is checked when the query is executed
+	_, err := qb.Get()
+	assert.EqualError(t, err, "the window functions are not supported by mysql 5.7.30")
+	_, err = qb.Cursor()
+	assert.EqualError(t, err, "the window functions are not supported by mysql 5.7.30")
+
+	// the window functions in the subqueries are checked too
+	_, err = qb.New().FromSub(qb, "ranked").Where("rn", 1).Exists()
+	assert.EqualError(t, err, "the window functions are not supported by mysql 5.7.30")
+
+	// the supported version
+	qb = NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	_, err = qb.Table("users").SelectWindow("row_number", nil, Over().OrderBy("id"), "rn").Get()
+	assert.ErrorIs(t, err, dbal.ErrOffline, "the offline builder should not execute the query")
+}
+
+// clean the test data
+func TestWindowClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_window")
+}
+
+func NewTableForWindowTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_window")
+	builder.MustCreateTable("table_test_window", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name")
+		table.String("dept")
+		table.Integer("salary")
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_window").MustInsert([]xun.R{
+		{"name": "Ada", "dept": "dev", "salary": 100},
+		{"name": "Bob", "dept": "dev", "salary": 200},
+		{"name": "Cat", "dept": "dev", "salary": 150},
+		{"name": "Eve", "dept": "ops", "salary": 300},
+		{"name": "Fox", "dept": "ops", "salary": 120},
+	})
+}
@@ -0,0 +1,77 @@
+package query
+
+import (
+	"github.com/yaoapp/xun/dbal"
+)
+
+// With Add a common table expression to the query. The qb could be a query builder instance, a Closure or a raw SQL string.
+//
+//	qb.Table("users_tree").With("users_tree", func(qb Query) { qb.Table("users").Where("vote", ">", 10) })
+func (builder *Builder) With(name string, qb interface{}, columns ...interface{}) Query {
+	return builder.with(name, qb, columns, false, "")
+}
+
+// WithRecursive Add a recursive common table expression to the query.
+//
+//	qb.Table("tree").WithRecursive("tree", func(qb Query) {
+//		qb.Table("categories").WhereNull("parent_id").
+//			UnionAll(func(qb Query) {
+//				qb.Table("categories as c").Select("c.*").Join("tree as t", "t.id", "=", "c.parent_id")
+//			})
+//	})
+func (builder *Builder) WithRecursive(name string, qb interface{}, columns ...interface{}) Query {
+	return builder.with(name, qb, columns, true, "")
+}
+
+// WithMaterialized Add a common table expression with the "materialized" hint to the query.
+// The hint is supported by postgres, it will be ignored by the other drivers.
+func (builder *Builder) WithMaterialized(name string, qb interface{}, columns ...interface{}) Query {
+	return builder.with(name, qb, columns, false, "materialized")
+}
+
+// WithNotMaterialized Add a common table expression with the "not materialized" hint to the query.
+// The hint is supported by postgres, it will be ignored by the other drivers.
+func (builder *Builder) WithNotMaterialized(name string, qb interface{}, columns ...interface{}) Query {
+	return builder.with(name, qb, columns, false, "not materialized")
+}
+
+// with Add a common table expression to the query.
+func (builder *Builder) with(name string, qb interface{}, columns []interface{}, recursive bool, materialized string) Query {
+	sub, bindings, _ := builder.createSub(qb)
+
+	cte := dbal.CTE{
+		Name:         name,
+		Columns:      builder.prepareColumns(columns...),
+		Recursive:    recursive,
+		Materialized: materialized,
+		Offset:       len(bindings),
+	}
+
+	switch value := sub.(type) {
+	case *dbal.Query:
+		cte.Query = value
+	case string:
+		cte.SQL = value
+	}
+
+	builder.Query.CTEs = append(builder.Query.CTEs, cte)
+	builder.Query.AddBinding("with", bindings)
+
+	// The common table expression is not a table, so the table prefix should
+	// not be prepended if the query is selecting from it.
+	if from, ok := builder.Query.From.Name.(dbal.Name); ok && builder.Query.From.Type == "basic" && from.Name == name {
+		from.Prefix = ""
+		builder.Query.From.Name = from
+	}
+	return builder
+}
+
+// isCTE Determine if the given name is a common table expression of the query.
+func (builder *Builder) isCTE(name string) bool {
+	for _, cte := range builder.Query.CTEs {
+		if cte.Name == name {
+			return true
+		}
+	}
+	return false
+}
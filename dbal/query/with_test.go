@@ -0,0 +1,171 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun"
+	"github.com/yaoapp/xun/dbal/schema"
+	"github.com/yaoapp/xun/unit"
+)
+
+func TestWithWith(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	qb.Table("top_users").
+		With("top_users", func(qb Query) {
+			qb.Table("table_test_with").Where("vote", ">", 5).Select("id", "name", "vote")
+		}).
+		Where("vote", "<", 100).
+		OrderBy("id")
+
+	// checking sql
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `with "top_users" as (select "id", "name", "vote" from "table_test_with" where "vote" > $1) select * from "top_users" where "vote" < $2 order by "id" asc`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "with `top_users` as (select `id`, `name`, `vote` from `table_test_with` where `vote` > ?) select * from `top_users` where `vote` < ? order by `id` asc", sql, "the query sql not equal")
+	}
+
+	bindings := qb.GetBindings()
+	assert.Equal(t, 2, len(bindings), "the bindings should have 2 items")
+	if len(bindings) == 2 {
+		assert.Equal(t, 5, bindings[0], "the 1st binding should be 5")
+		assert.Equal(t, 100, bindings[1], "the 2nd binding should be 100")
+	}
+
+	// checking result
+	rows := qb.MustGet()
+	assert.Equal(t, 2, len(rows), "the return value should has 2 rows")
+	if len(rows) == 2 {
+		assert.Equal(t, "Parent", rows[0]["name"].(string), "the name of first row should be Parent")
+		assert.Equal(t, "Grandson", rows[1]["name"].(string), "the name of second row should be Grandson")
+	}
+}
+
+func TestWithWithColumns(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	rows := qb.Table("summary").
+		With("summary", "select count(*), max(vote) from table_test_with", "total", "top").
+		MustGet()
+
+	assert.Equal(t, 1, len(rows), "the return value should has 1 row")
+	if len(rows) == 1 {
+		assert.Equal(t, int64(4), rows[0].Get("total"), "the total should be 4")
+		assert.Equal(t, int64(20), rows[0].Get("top"), "the top should be 20")
+	}
+}
+
+func TestWithWithRecursive(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	qb.Table("tree").
+		WithRecursive("tree", func(qb Query) {
+			qb.Table("table_test_with").
+				Select("id", "name", "parent_id").
+				Where("name", "Root").
+				UnionAll(func(qb Query) {
+					qb.Table("table_test_with as child").
+						Select("child.id", "child.name", "child.parent_id").
+						Join("tree", "tree.id", "=", "child.parent_id")
+				})
+		}).
+		OrderBy("id")
+
+	rows := qb.MustGet()
+	assert.Equal(t, 3, len(rows), "the return value should has 3 rows")
+	if len(rows) == 3 {
+		assert.Equal(t, "Root", rows[0]["name"].(string), "the name of first row should be Root")
+		assert.Equal(t, "Parent", rows[1]["name"].(string), "the name of second row should be Parent")
+		assert.Equal(t, "Grandson", rows[2]["name"].(string), "the name of third row should be Grandson")
+	}
+}
+
+func TestWithWithMaterialized(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	qb.Table("voters").
+		WithMaterialized("voters", func(qb Query) {
+			qb.Table("table_test_with").Where("vote", ">", 0)
+		})
+
+	sql := qb.ToSQL()
+	if unit.DriverIs("postgres") {
+		assert.Equal(t, `with "voters" as materialized (select * from "table_test_with" where "vote" > $1) select * from "voters"`, sql, "the query sql not equal")
+	} else {
+		assert.Equal(t, "with `voters` as (select * from `table_test_with` where `vote` > ?) select * from `voters`", sql, "the query sql not equal")
+	}
+	assert.Equal(t, 3, len(qb.MustGet()), "the return value should has 3 rows")
+}
+
+func TestWithUpdate(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_with").
+		With("low", func(qb Query) {
+			qb.Table("table_test_with").Where("vote", "<", 10).Select("id")
+		}).
+		WhereIn("id", func(qb Query) { qb.From("low").Select("id") }).
+		MustUpdate(xun.R{"vote": 99})
+
+	assert.Equal(t, int64(2), affected, "the affected rows should be 2")
+	assert.Equal(t, int64(2), qb.Table("table_test_with").Where("vote", 99).MustCount(), "the rows count should be 2")
+}
+
+func TestWithDelete(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_with").
+		With("low", func(qb Query) {
+			qb.Table("table_test_with").Where("vote", "<", 10).Select("id")
+		}).
+		WhereIn("id", func(qb Query) { qb.From("low").Select("id") }).
+		MustDelete()
+
+	assert.Equal(t, int64(2), affected, "the affected rows should be 2")
+	assert.Equal(t, int64(2), qb.Table("table_test_with").MustCount(), "the rows count should be 2")
+}
+
+func TestWithInsertUsing(t *testing.T) {
+	NewTableForWithTest()
+	qb := getTestBuilder()
+	affected := qb.Table("table_test_with").
+		With("top", func(qb Query) {
+			qb.Table("table_test_with").Where("vote", ">=", 10)
+		}).
+		MustInsertUsing(func(qb Query) {
+			qb.From("top").SelectRaw("name || '-copy', vote, ?", 99)
+		}, "name", "vote", "parent_id")
+
+	if !unit.DriverIs("mysql") {
+		assert.Equal(t, int64(2), affected, "the affected rows should be 2")
+		assert.Equal(t, int64(2), qb.Table("table_test_with").Where("parent_id", 99).MustCount(), "the rows count should be 2")
+	}
+}
+
+// clean the test data
+func TestWithClean(t *testing.T) {
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_with")
+}
+
+func NewTableForWithTest() {
+	defer unit.Catch()
+	builder := getTestSchemaBuilder()
+	builder.DropTableIfExists("table_test_with")
+	builder.MustCreateTable("table_test_with", func(table schema.Blueprint) {
+		table.ID("id")
+		table.String("name")
+		table.Integer("vote")
+		table.BigInteger("parent_id").Null()
+	})
+
+	qb := getTestBuilder()
+	qb.Table("table_test_with").MustInsert([]xun.R{
+		{"name": "Root", "vote": 0, "parent_id": nil},
+		{"name": "Parent", "vote": 10, "parent_id": 1},
+		{"name": "Grandson", "vote": 20, "parent_id": 2},
+		{"name": "Orphan", "vote": 5, "parent_id": nil},
+	})
+}
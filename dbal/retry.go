@@ -0,0 +1,72 @@
+package dbal
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy the policy of re-running the transactions failed with a deadlock or a serialization failure,
+// the delay of each retry grows exponentially with a random jitter. Only the transaction closures are retried,
+// the single statements out of the transactions are never retried.
+//
+//	RetryPolicy{MaxAttempts: 5, BaseDelay: 20 * time.Millisecond, OnRetry: func(attempt int, err error, delay time.Duration) {
+//		log.Warn("retry the transaction: %s", err)
+//	}}
+type RetryPolicy struct {
+	MaxAttempts int                                               // the maximum number of the attempts including the first one, 3 by default
+	BaseDelay   time.Duration                                     // the delay before the first retry, 10ms by default
+	MaxDelay    time.Duration                                     // the maximum delay of the retries, 1s by default
+	OnRetry     func(attempt int, err error, delay time.Duration) // the hook called before each retry with the number of the failed attempts
+	Retryable   func(err error) bool                              // the retryable errors checker, IsRetryable by default
+}
+
+// IsRetryable Determine if the transaction failed with the error could be re-run, the deadlocks
+// and the serialization failures are retryable.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrDeadlock) || errors.Is(err, ErrSerialization)
+}
+
+// Attempts Get the maximum number of the attempts
+func (policy RetryPolicy) Attempts() int {
+	if policy.MaxAttempts <= 0 {
+		return 3
+	}
+	return policy.MaxAttempts
+}
+
+// ShouldRetry Determine if the transaction failed with the error should be re-run
+func (policy RetryPolicy) ShouldRetry(err error) bool {
+	if err == nil {
+		return false
+	}
+	if policy.Retryable != nil {
+		return policy.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// Delay Get the delay before the retry after the given number of the failed attempts,
+// it's a random duration between the half and the whole of the exponential backoff.
+func (policy RetryPolicy) Delay(attempt int) time.Duration {
+	base := policy.BaseDelay
+	if base <= 0 {
+		base = 10 * time.Millisecond
+	}
+
+	max := policy.MaxDelay
+	if max <= 0 {
+		max = time.Second
+	}
+
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay = delay * 2
+	}
+	if delay > max {
+		delay = max
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
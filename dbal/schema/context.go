@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+)
+
+// WithContext Create a new schema builder instance bound to the given context,
+// all of the schema statements will be executed using the context.
+func (builder *Builder) WithContext(ctx context.Context) Schema {
+	if ctx == nil {
+		panic(fmt.Errorf("the context is nil"))
+	}
+
+	grammar, err := builder.Grammar.NewWithContext(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	new := *builder
+	new.Ctx = ctx
+	new.Grammar = grammar
+	return &new
+}
+
+// Context Get the context of the schema builder, returns context.Background() if the builder is not bound to one
+func (builder *Builder) Context() context.Context {
+	if builder.Ctx != nil {
+		return builder.Ctx
+	}
+	return context.Background()
+}
@@ -36,8 +36,20 @@ func (table *Table) AddIndex(key string, columnNames ...string) *Table {
 	return table
 }
 
-// AddFulltext Indicate that the given fulltext index should be created.(donthing here)
+// AddFulltext Indicate that the given fulltext index should be created.
+// It creates a FULLTEXT index on MySQL and a GIN index over to_tsvector on PostgreSQL,
+// the other drivers fall back to a plain index.
 func (table *Table) AddFulltext(key string, columnNames ...string) *Table {
+	columns := []*Column{}
+	for _, name := range columnNames {
+		columns = append(columns, table.GetColumn(name))
+	}
+	index := table.newIndex(key, columns...)
+	index.Type = "fulltext"
+	table.pushIndex(index)
+	table.createIndexCommand(index.Index, nil, func() {
+		delete(table.IndexMap, index.Name)
+	})
 	return table
 }
 
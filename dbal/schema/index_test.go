@@ -54,6 +54,29 @@ func TestIndexAddIndex(t *testing.T) {
 	assert.False(t, err == nil, "The return error should not be nil")
 }
 
+func TestIndexAddFulltext(t *testing.T) {
+	defer unit.Catch()
+	builder := getTestBuilder()
+	builder.DropTableIfExists("table_test_index")
+	builder.MustCreateTable("table_test_index", func(table Blueprint) {
+		table.ID("id")
+		table.String("title", 80)
+		table.Text("content")
+		table.AddFulltext("title_content", "title", "content")
+	})
+	table := builder.MustGetTable("table_test_index")
+	assert.True(t, table.HasIndex("title_content"), "the table should have the title_content index")
+	if table.HasIndex("title_content") {
+		index := table.GetIndex("title_content")
+		if unit.DriverIs("sqlite3") {
+			assert.Equal(t, "index", index.Type, "the type of title_content index should be 'index'")
+		} else {
+			assert.Equal(t, "fulltext", index.Type, "the type of title_content index should be 'fulltext'")
+		}
+		assert.Equal(t, 2, len(index.Columns), "the title_content index should have 2 columns")
+	}
+}
+
 func TestIndexAddUnique(t *testing.T) {
 	defer unit.Catch()
 	builder := getTestBuilder()
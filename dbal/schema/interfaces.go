@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun/dbal"
 )
@@ -39,6 +41,19 @@ type Schema interface {
 	MustDropTableIfExists(name string)
 
 	DB() *sqlx.DB // alias MustGetDB
+
+	// defined in transaction.go
+	Transaction(callback func(schema Schema) error) error
+	Begin() (Schema, error)
+	Commit() error
+	Rollback() error
+
+	// defined in context.go
+	WithContext(ctx context.Context) Schema
+	Context() context.Context
+
+	// defined in offline.go
+	Statements() []string
 }
 
 // Blueprint the table operating interface
@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// NewOffline create a new schema interface of the given driver without connecting to a database server,
+// the version of the server is assumed. The DDL statements are recorded instead of being executed,
+// get them with the Statements method. The methods reading the schema (GetTable, HasTable, AlterTable...)
+// return the dbal.ErrOffline error.
+//
+//	sch := schema.NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+//	sch.MustCreateTable("users", func(table schema.Blueprint) { table.ID("id") })
+//	statements := sch.Statements()
+func NewOffline(driver string, version dbal.Version, option ...dbal.Option) Schema {
+	grammar, has := dbal.Grammars[driver]
+	if !has {
+		panic(fmt.Errorf("The %s driver not import", driver))
+	}
+
+	if version.Driver == "" {
+		version.Driver = driver
+	}
+
+	opt := dbal.Option{}
+	if len(option) > 0 {
+		opt = option[0]
+	}
+
+	offline := dbal.NewOffline(true)
+	conn := &Connection{
+		Write: offline.Open(driver),
+		WriteConfig: &dbal.Config{
+			Driver:  driver,
+			Name:    "offline",
+			Version: &version,
+		},
+		Option:  &opt,
+		Version: &version,
+		Offline: offline,
+	}
+
+	// the OnConnected event is not triggered, there is no database server to set up
+	grammar, err := grammar.NewWith(conn.Write, conn.WriteConfig, conn.Option)
+	if err != nil {
+		panic(fmt.Errorf("grammar setup error. (%s)", err))
+	}
+
+	return &Builder{
+		Mode:     "production",
+		Conn:     conn,
+		Grammar:  grammar,
+		Database: grammar.GetDatabase(),
+		Schema:   grammar.GetSchema(),
+	}
+}
+
+// Statements Get the DDL statements recorded by the offline schema in the executing order, it returns nil for the online schemas.
+func (builder *Builder) Statements() []string {
+	if builder.Conn == nil || builder.Conn.Offline == nil {
+		return nil
+	}
+	return builder.Conn.Offline.Statements()
+}
@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestOfflineCreateTable(t *testing.T) {
+	sch := NewOffline("mysql", dbal.Version{Version: semver.MustParse("8.0.26")})
+	err := sch.CreateTable("users", func(table Blueprint) {
+		table.ID("id")
+		table.String("email", 80).Unique()
+		table.JSON("options")
+	})
+	assert.Nil(t, err, "the create table statements should be recorded")
+
+	statements := sch.Statements()
+	if assert.Equal(t, 1, len(statements), "the create table statement should be recorded") {
+		assert.True(t, strings.HasPrefix(statements[0], "CREATE TABLE `users` ("), "the statement should create the users table")
+		assert.Contains(t, statements[0], "`options` JSON", "the json column should be compiled by the assumed version")
+	}
+
+	sch = NewOffline("mysql", dbal.Version{Version: semver.MustParse("5.7.7")})
+	sch.MustCreateTable("users", func(table Blueprint) {
+		table.JSON("options")
+	})
+	statements = sch.Statements()
+	if assert.Equal(t, 1, len(statements), "the create table statement should be recorded") {
+		assert.Contains(t, statements[0], "`options` TEXT", "the json column should be compiled by the assumed version")
+	}
+}
+
+func TestOfflineCreateTablePostgres(t *testing.T) {
+	sch := NewOffline("postgres", dbal.Version{Version: semver.MustParse("14.0.0")}, dbal.Option{Prefix: "xun_"})
+	sch.MustCreateTable("users", func(table Blueprint) {
+		table.ID("id")
+		table.String("email", 80).Unique()
+	})
+	statements := sch.Statements()
+	if assert.Equal(t, 2, len(statements), "the create table and the create index statements should be recorded") {
+		assert.True(t, strings.HasPrefix(statements[0], `CREATE TABLE "xun_users" (`), "the statement should create the xun_users table")
+		assert.Contains(t, statements[1], "CREATE UNIQUE INDEX", "the statement should create the unique index")
+	}
+
+	sch.MustDropTableIfExists("users")
+	statements = sch.Statements()
+	assert.Equal(t, 3, len(statements), "the drop table statement should be recorded")
+}
+
+func TestOfflineRead(t *testing.T) {
+	sch := NewOffline("sqlite3", dbal.Version{Version: semver.MustParse("3.35.0")})
+	_, err := sch.HasTable("users")
+	assert.True(t, errors.Is(err, dbal.ErrOffline), "the HasTable method should return the ErrOffline error")
+
+	version, err := sch.GetVersion()
+	assert.Nil(t, err, "the version should be returned")
+	assert.Equal(t, "sqlite3", version.Driver, "the driver of the version should be sqlite3")
+}
+
+func TestOfflineStatementsOnline(t *testing.T) {
+	assert.Nil(t, getTestBuilder().Statements(), "the online schema should not record the statements")
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// Transaction Execute the callback within a transaction, the transaction will be committed if the callback returns nil,
+// otherwise it will be rolled back. Only the drivers with transactional DDL (postgres, sqlite3) are supported.
+func (builder *Builder) Transaction(callback func(schema Schema) error) (err error) {
+	schema, err := builder.begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			schema.Rollback()
+			panic(r)
+		}
+	}()
+
+	err = callback(schema)
+	if err != nil {
+		if errRollback := schema.Rollback(); errRollback != nil {
+			return fmt.Errorf("%s (rollback failed: %s)", err, errRollback)
+		}
+		return err
+	}
+
+	return schema.Commit()
+}
+
+// Begin Start a new transaction, or create a savepoint if the builder is already in a transaction.
+func (builder *Builder) Begin() (Schema, error) {
+	return builder.begin()
+}
+
+// Commit Commit the transaction, or release the savepoint if the transaction is nested.
+func (builder *Builder) Commit() error {
+	if builder.Tx == nil {
+		return fmt.Errorf("the schema builder is not in a transaction")
+	}
+	return builder.Tx.Commit(builder.Grammar)
+}
+
+// Rollback Rollback the transaction, or rollback to the savepoint if the transaction is nested.
+func (builder *Builder) Rollback() error {
+	if builder.Tx == nil {
+		return fmt.Errorf("the schema builder is not in a transaction")
+	}
+	return builder.Tx.Rollback(builder.Grammar)
+}
+
+// begin Start a new transaction and return a new schema builder instance bound to it.
+func (builder *Builder) begin() (*Builder, error) {
+
+	if !builder.Grammar.SupportsTransactionalDDL() {
+		return nil, fmt.Errorf("the %s driver does not support transactional DDL", builder.Conn.WriteConfig.Driver)
+	}
+
+	new := *builder
+	if builder.Tx != nil {
+		tx, err := builder.Tx.Nested(builder.Grammar)
+		if err != nil {
+			return nil, err
+		}
+		new.Tx = tx
+		return &new, nil
+	}
+
+	tx, err := dbal.BeginTransaction(builder.Context(), builder.Conn.Write)
+	if err != nil {
+		return nil, err
+	}
+
+	grammar, err := builder.Grammar.NewWithTx(tx.Tx)
+	if err != nil {
+		tx.Tx.Rollback()
+		return nil, err
+	}
+
+	new.Tx = tx
+	new.Grammar = grammar
+	return &new, nil
+}
@@ -1,6 +1,8 @@
 package schema
 
 import (
+	"context"
+
 	"github.com/jmoiron/sqlx"
 	"github.com/yaoapp/xun/dbal"
 )
@@ -11,6 +13,7 @@ type Connection struct {
 	WriteConfig *dbal.Config
 	Option      *dbal.Option
 	Version     *dbal.Version
+	Offline     *dbal.Offline // the offline handle recording the statements, nil for the online connections
 }
 
 // Builder the table schema builder struct
@@ -19,6 +22,8 @@ type Builder struct {
 	Mode     string
 	Database string
 	Schema   string
+	Tx       *dbal.Transaction
+	Ctx      context.Context
 	dbal.Grammar
 }
 
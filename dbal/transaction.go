@@ -0,0 +1,53 @@
+package dbal
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BeginTransaction begin a new transaction using the given context and database connection
+func BeginTransaction(ctx context.Context, db *sqlx.DB) (*Transaction, error) {
+	if db == nil {
+		return nil, fmt.Errorf("the connection is nil")
+	}
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{Tx: tx, Level: 1}, nil
+}
+
+// Savepoint get the savepoint name of the transaction level
+func (trans *Transaction) Savepoint() string {
+	return fmt.Sprintf("trans%d", trans.Level)
+}
+
+// Nested create a savepoint and return the nested transaction
+func (trans *Transaction) Nested(grammar Grammar) (*Transaction, error) {
+	nested := &Transaction{Tx: trans.Tx, Level: trans.Level + 1}
+	_, err := trans.Tx.Exec(grammar.CompileSavepoint(nested.Savepoint()))
+	if err != nil {
+		return nil, err
+	}
+	return nested, nil
+}
+
+// Commit commit the transaction, release the savepoint if the transaction is nested
+func (trans *Transaction) Commit(grammar Grammar) error {
+	if trans.Level > 1 {
+		_, err := trans.Tx.Exec(grammar.CompileSavepointRelease(trans.Savepoint()))
+		return err
+	}
+	return trans.Tx.Commit()
+}
+
+// Rollback rollback the transaction, rollback to the savepoint if the transaction is nested
+func (trans *Transaction) Rollback(grammar Grammar) error {
+	if trans.Level > 1 {
+		_, err := trans.Tx.Exec(grammar.CompileSavepointRollback(trans.Savepoint()))
+		return err
+	}
+	return trans.Tx.Rollback()
+}
@@ -15,19 +15,34 @@ type Connection struct {
 	Version *Version
 }
 
+// Transaction the database transaction, Level is the depth of the nested savepoints (the outermost transaction is 1)
+type Transaction struct {
+	Tx    *sqlx.Tx
+	Level int
+}
+
 // Config the Connection configuration
 type Config struct {
 	Driver   string `json:"driver"`        // The driver name. mysql,pgsql,sqlite3,oci,sqlsrv
 	DSN      string `json:"dsn,omitempty"` // The driver wrapper. sqlite:///:memory:, mysql://localhost:4486/foo?charset=UTF8
 	Name     string `json:"name,omitempty"`
 	ReadOnly bool   `json:"readonly,omitempty"`
+	// The default timeout of the statements, the statements are canceled by the context when it's exceeded.
+	// The server-side limits could be set by the DSN too, e.g. max_execution_time (mysql) or statement_timeout (postgres).
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// The assumed version of the database server, the grammars don't query the version if it's given.
+	Version *Version `json:"-"`
 }
 
 // Option the database configuration
 type Option struct {
-	Prefix    string `json:"prefix,omitempty"` // Table prifix
-	Collation string `json:"collation,omitempty"`
-	Charset   string `json:"charset,omitempty"`
+	Prefix    string       `json:"prefix,omitempty"` // Table prifix
+	Collation string       `json:"collation,omitempty"`
+	Charset   string       `json:"charset,omitempty"`
+	CursorKey string       `json:"cursor_key,omitempty"` // The key for signing the pagination cursors, required by the cursor pagination
+	Retry     *RetryPolicy `json:"-"`                    // The retry policy of the transactions
+	// The maximum size in bytes of the bulk insert statements, the limit of the server is used if it's not given (max_allowed_packet of MySQL)
+	MaxPacketSize int `json:"max_packet_size,omitempty"`
 }
 
 // Version the database version
@@ -173,6 +188,7 @@ type Where struct {
 	ValuesIn interface{}
 	Not      bool
 	Offset   int
+	FullText *FullText // for the full-text search
 }
 
 // Join the join clause for the query
@@ -185,6 +201,64 @@ type Join struct {
 	Offset int
 }
 
+// CTE the common table expression of the query
+type CTE struct {
+	Name         string        // The name of the common table expression
+	Columns      []interface{} // The column names of the common table expression
+	Query        *Query        // The query of the common table expression
+	SQL          string        // The raw SQL of the common table expression (if the query is nil)
+	Recursive    bool          // Whether the common table expression is recursive
+	Materialized string        // The materialized hint, "materialized" or "not materialized", default is ""
+	Offset       int           // The bindings count of the common table expression
+}
+
+// Window the window specification of the window functions and the named windows
+type Window struct {
+	Name       string        // The name of the named window the specification is based on
+	Partitions []interface{} // The "partition by" columns of the window
+	Orders     []Order       // The "order by" columns of the window
+	Frame      string        // The frame unit of the window, "rows" or "range"
+	FrameStart string        // The frame start bound, e.g. "unbounded preceding", "1 preceding", "current row"
+	FrameEnd   string        // The frame end bound, e.g. "current row", "1 following", "unbounded following"
+}
+
+// NamedWindow the named window of the "window" clause
+type NamedWindow struct {
+	Name   string
+	Window Window
+}
+
+// WindowFunction the window function column of the select
+type WindowFunction struct {
+	Func   string        // The function name, e.g. row_number, rank, sum, lag
+	Args   []interface{} // The arguments of the function
+	Window Window        // The window specification, the Window.Name without any other attributes means "over name"
+	Alias  string
+}
+
+// AggregateFunction the aggregate function column of the select
+type AggregateFunction struct {
+	Func     string      // The function name, count, sum, avg, min or max
+	Column   interface{} // The column of the function, "*" counts all of the rows
+	Distinct bool        // Whether only the distinct values are aggregated
+	Alias    string
+}
+
+// FullText the full-text search of the where clause, the rank column and the relevance order
+type FullText struct {
+	Columns []interface{} // The columns to search
+	Term    interface{}   // The search term
+	Alias   string        // The alias of the rank column
+	FullTextOptions
+}
+
+// FullTextOptions the options of the full-text search
+type FullTextOptions struct {
+	Mode     string // The search mode, natural (default) or boolean
+	Language string // The text search configuration of postgres, default is english
+	Expanded bool   // Search with query expansion in the natural mode (MySQL only)
+}
+
 // Union the query union statement
 type Union struct {
 	All   bool // Union all
@@ -217,6 +291,7 @@ type Order struct {
 	Direction string
 	Offset    int
 	SQL       string
+	Query     *Query // The subquery of the order (if the column is a subquery)
 }
 
 // From the from query
@@ -226,6 +301,7 @@ type From struct {
 	Alias  string
 	Offset int
 	SQL    string
+	Query  *Query // The subquery of the from clause (if the type is sub)
 }
 
 // Select the from query
@@ -235,11 +311,13 @@ type Select struct {
 	Alias  string
 	Offset int
 	SQL    string
+	Query  *Query // The subquery of the column (if the type is sub)
 }
 
 // Query the query builder
 type Query struct {
 	UseWriteConnection bool                     // Whether to use write connection for the select. default is false
+	CTEs               []CTE                    // The common table expressions of the query.
 	Lock               interface{}              //  Indicates whether row locking is being used.
 	From               From                     // The table which the query is targeting.
 	Columns            []interface{}            // The columns that should be returned. (Name or Expression)
@@ -255,10 +333,14 @@ type Query struct {
 	Offset             int                      // The number of records to skip.
 	Groups             []interface{}            // The groupings for the query.
 	Havings            []Having                 // The having constraints for the query.
+	Windows            []NamedWindow            // The named windows for the query.
 	Bindings           map[string][]interface{} // The current query value bindings.
 	Distinct           bool                     // Indicates if the query returns distinct results. Occasionally contains the columns that should be distinct. default is false
 	DistinctColumns    []interface{}            // Indicates if the query returns distinct results. Occasionally contains the columns that should be distinct.
 	IsJoinClause       bool                     // Determine if the query is a join clause.
 	BindingOffset      int                      // The Binding offset before select
+	Timeout            time.Duration            // The timeout of the query, the connection default is used if it's zero
+	RawValues          bool                     // Keep the values returned by the driver, the values are not decoded by the column types
+	Unscoped           []string                 // The tables of the global scopes which are not applied to the query, "*" for all of them
 	SQL                string                   // The SQL STMT
 }
@@ -0,0 +1,650 @@
+package dbal
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// The types of the query syntax tree nodes
+const (
+	NodeTable   = "table"
+	NodeColumn  = "column"
+	NodeBinding = "binding"
+	NodeQuery   = "query"
+)
+
+// SkipNode the visitor returns it to skip the children of a query node, it's not returned by the Walk function.
+var SkipNode = errors.New("skip the children of the node")
+
+// ErrWalkBindings the bindings of a clause could not be rebuilt after its subqueries are rewritten,
+// the clause has more than one raw expression and the number of their bindings is unknown.
+var ErrWalkBindings = errors.New("the bindings could not be rebuilt")
+
+// Node the node of the query syntax tree
+type Node struct {
+	Type   string      // The type of the node, table, column, binding or query
+	Clause string      // The clause the node is referenced by, the binding keys: with, select, from, join, where, groupBy, having, order, union, unionOrder or sql
+	Value  interface{} // The Name of the tables, the string, Name or Expression of the columns, the value of the bindings and the *Query of the queries
+	Query  *Query      // The query the node belongs to, the nested where groups and the join clauses (of the joined tables) are queries of their own
+	Depth  int         // The depth of the subquery, the root query is 0
+	set    func(value interface{})
+	walker *walker
+}
+
+// Visitor the function called with each node of the query syntax tree, it could replace the node
+// or add conditions to the query of the node. It returns SkipNode to skip the children of a query node.
+type Visitor func(node *Node) error
+
+// walkBlock the bindings of a node in a binding section of the query
+type walkBlock struct {
+	count  int             // the number of the bindings, -1 if it's unknown
+	query  *Query          // the subquery of the bindings
+	where  bool            // only the where bindings of the subquery are added
+	offset bool            // the binding offset of the subquery is kept in sync
+	move   func(delta int) // move the offset of the clause given by the query builder
+	size   bool            // the offset moved by the move function is the number of the bindings, it's not moved with the position
+}
+
+// walker the state of walking a query syntax tree
+type walker struct {
+	visitor Visitor
+	all     map[*Query][]interface{}
+	where   map[*Query][]interface{}
+	added   map[*Query]bool
+}
+
+// Walk Walk the query syntax tree in depth-first order, the visitor is called with each query, table and column node,
+// then with the bindings of the root query. The bindings of the rewritten subqueries are copied to their parent queries,
+// the subqueries compiled to SQL by the query builder should be compiled again.
+//
+//	dbal.Walk(query, func(node *dbal.Node) error {
+//		if node.Type == dbal.NodeTable && node.Value.(dbal.Name).Name == "secrets" {
+//			return fmt.Errorf("the secrets table is not allowed")
+//		}
+//		return nil
+//	})
+func Walk(query *Query, visitor Visitor) error {
+	w := &walker{
+		visitor: visitor,
+		all:     map[*Query][]interface{}{},
+		where:   map[*Query][]interface{}{},
+		added:   map[*Query]bool{},
+	}
+	w.snapshot(query)
+
+	skip := false
+	err := w.query(query, "", 0)
+	if err == SkipNode {
+		skip = true
+	} else if err != nil {
+		return err
+	}
+
+	err = w.rebind(query)
+	if err != nil || skip {
+		return err
+	}
+
+	for _, key := range BindingKeys {
+		for i := range query.Bindings[key] {
+			key, i := key, i
+			err := w.visit(&Node{Type: NodeBinding, Clause: key, Value: query.Bindings[key][i], Query: query}, func(value interface{}) {
+				query.Bindings[key][i] = value
+			})
+			if err != nil && err != SkipNode {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Replace Replace the value of the node. The tables are replaced by a Name or a string, the prefix and the alias
+// of the table are kept if it's a string. The queries are replaced by a *Query, the bindings of the parent queries are rebuilt.
+func (node *Node) Replace(value interface{}) {
+	if node.Type == NodeTable {
+		if name, ok := value.(string); ok {
+			table := node.Value.(Name)
+			table.Name = name
+			value = table
+		}
+	}
+
+	if node.set != nil {
+		node.set(value)
+	}
+	node.Value = value
+}
+
+// Where Add a basic where condition to the query of the node, the existing conditions are grouped
+// if one of them is an "or" condition. The bindings of the query and its parent queries are kept consistent.
+//
+//	node.Where("u.tenant_id", "=", 1)
+func (node *Node) Where(column interface{}, operator string, value interface{}) {
+	query := node.Query
+	node.group()
+	query.Wheres = append(query.Wheres, Where{
+		Type:     "basic",
+		Column:   column,
+		Operator: operator,
+		Value:    value,
+		Boolean:  "and",
+		Offset:   1,
+	})
+
+	if !IsExpression(value) {
+		query.AddBinding("where", value)
+	}
+}
+
+// WhereNested Add the where conditions of the given query to the query of the node as a nested where group, the existing
+// conditions are grouped if one of them is an "or" condition. The bindings of the query and its parent queries are kept consistent.
+func (node *Node) WhereNested(nested *Query) {
+	if len(nested.Wheres) == 0 {
+		return
+	}
+
+	query := node.Query
+	node.group()
+	nested.IsJoinClause = query.IsJoinClause
+	query.Wheres = append(query.Wheres, Where{Type: "nested", Query: nested, Boolean: "and"})
+	if node.walker == nil {
+		query.AddBinding("where", nested.Bindings["where"])
+		return
+	}
+
+	// the bindings of the group are added to the query when the bindings are rebuilt,
+	// the binding offsets of its subqueries are moved from the start of the group.
+	node.walker.snapshot(nested)
+	node.walker.where[nested] = []interface{}{}
+	node.walker.added[nested] = true
+}
+
+// group Group the where conditions of the query of the node if one of them is an "or" condition
+func (node *Node) group() {
+	query := node.Query
+	for _, where := range query.Wheres {
+		if where.Boolean == "or" {
+			nested := NewQuery()
+			nested.From = query.From
+			nested.IsJoinClause = query.IsJoinClause
+			nested.Wheres = query.Wheres
+			nested.Bindings["where"] = append([]interface{}{}, query.Bindings["where"]...)
+			if node.walker != nil {
+				node.walker.snapshot(nested)
+			}
+			query.Wheres = []Where{{Type: "nested", Query: nested, Boolean: "and"}}
+			return
+		}
+	}
+}
+
+// visit Call the visitor with the node
+func (w *walker) visit(node *Node, set func(value interface{})) error {
+	node.set = set
+	node.walker = w
+	return w.visitor(node)
+}
+
+// query Walk the query and its subqueries
+func (w *walker) query(query *Query, clause string, depth int) error {
+	if query == nil {
+		return nil
+	}
+
+	err := w.visit(&Node{Type: NodeQuery, Clause: clause, Value: query, Query: query, Depth: depth}, func(value interface{}) {
+		if new, ok := value.(*Query); ok && new != query {
+			*query = *new
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cte := range query.CTEs {
+		if err := w.subquery(cte.Query, "with", depth+1); err != nil {
+			return err
+		}
+	}
+
+	for i := range query.Columns {
+		if err := w.column(query, "select", depth, &query.Columns[i]); err != nil {
+			return err
+		}
+	}
+
+	switch name := query.From.Name.(type) {
+	case Name:
+		err := w.visit(&Node{Type: NodeTable, Clause: "from", Value: name, Query: query, Depth: depth}, func(value interface{}) {
+			query.From.Name = value
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := w.subquery(query.From.Query, "from", depth+1); err != nil {
+		return err
+	}
+
+	for i := range query.Joins {
+		join := &query.Joins[i]
+		switch name := join.Name.(type) {
+		case Name:
+			// the conditions of the joined table are added to the join clause, the left
+			// and the right joins keep the rows of the other table if they're not matched.
+			owner := query
+			if join.Query != nil && join.Query != query && join.Type != "cross" {
+				owner = join.Query
+			}
+			err := w.visit(&Node{Type: NodeTable, Clause: "join", Value: name, Query: owner, Depth: depth}, func(value interface{}) {
+				join.Name = value
+			})
+			if err != nil {
+				return err
+			}
+		case *Query:
+			if err := w.subquery(name, "join", depth+1); err != nil {
+				return err
+			}
+		}
+
+		if join.Query != nil && join.Query != query {
+			if err := w.wheres(join.Query, "join", depth); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := w.wheres(query, "where", depth); err != nil {
+		return err
+	}
+
+	for i := range query.Groups {
+		if err := w.column(query, "groupBy", depth, &query.Groups[i]); err != nil {
+			return err
+		}
+	}
+
+	for i := range query.Havings {
+		if query.Havings[i].Type == "raw" {
+			continue
+		}
+		if err := w.column(query, "having", depth, &query.Havings[i].Column); err != nil {
+			return err
+		}
+	}
+
+	if err := w.orders(query, query.Orders, "order", depth); err != nil {
+		return err
+	}
+
+	for _, union := range query.Unions {
+		if err := w.subquery(union.Query, "union", depth+1); err != nil {
+			return err
+		}
+	}
+
+	return w.orders(query, query.UnionOrders, "unionOrder", depth)
+}
+
+// subquery Walk the subquery, the SkipNode of the subquery is not returned to the parent query
+func (w *walker) subquery(query *Query, clause string, depth int) error {
+	err := w.query(query, clause, depth)
+	if err == SkipNode {
+		return nil
+	}
+	return err
+}
+
+// wheres Walk the where clauses of the query, the nested where groups are walked as a part of the query.
+func (w *walker) wheres(query *Query, clause string, depth int) error {
+	for i := range query.Wheres {
+		where := &query.Wheres[i]
+		switch where.Type {
+		case "nested":
+			if err := w.wheres(where.Query, clause, depth); err != nil {
+				return err
+			}
+			continue
+		case "column":
+			for _, column := range []*interface{}{&where.First, &where.Second} {
+				if err := w.column(query, clause, depth, column); err != nil {
+					return err
+				}
+			}
+			continue
+		case "raw", "exists", "fullText":
+		default:
+			if err := w.column(query, clause, depth, &where.Column); err != nil {
+				return err
+			}
+		}
+
+		if err := w.subquery(where.Query, clause, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orders Walk the orders of the query
+func (w *walker) orders(query *Query, orders []Order, clause string, depth int) error {
+	for i := range orders {
+		if orders[i].Query != nil {
+			if err := w.subquery(orders[i].Query, clause, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		if orders[i].Type == "raw" {
+			continue
+		}
+		if err := w.column(query, clause, depth, &orders[i].Column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// column Walk the column, the raw expressions are visited as the columns,
+// the subqueries of the selected columns are walked.
+func (w *walker) column(query *Query, clause string, depth int, column *interface{}) error {
+	switch value := (*column).(type) {
+	case string, Name, Expression:
+		return w.visit(&Node{Type: NodeColumn, Clause: clause, Value: value, Query: query, Depth: depth}, func(value interface{}) {
+			*column = value
+		})
+	case AggregateFunction:
+		return w.visit(&Node{Type: NodeColumn, Clause: clause, Value: value.Column, Query: query, Depth: depth}, func(v interface{}) {
+			value.Column = v
+			*column = value
+		})
+	case Select:
+		return w.subquery(value.Query, clause, depth+1)
+	}
+	return nil
+}
+
+// snapshot Keep the bindings of the query and its subqueries before they are rewritten
+func (w *walker) snapshot(query *Query) {
+	w.all[query] = query.GetBindings()
+	w.where[query] = append([]interface{}{}, query.Bindings["where"]...)
+	for _, blocks := range walkBlocks(query) {
+		for _, block := range blocks {
+			if block.query != nil {
+				w.snapshot(block.query)
+			}
+		}
+	}
+}
+
+// bindings Get the bindings of the subquery which are added to its parent query, the snapshot is used if old is true.
+func (w *walker) bindings(block walkBlock, old bool) []interface{} {
+	if old {
+		if block.where {
+			return w.where[block.query]
+		}
+		return w.all[block.query]
+	}
+
+	if block.where {
+		return block.query.Bindings["where"]
+	}
+	return block.query.GetBindings()
+}
+
+// rebind Rebuild the bindings of the query from the bindings of its subqueries, the subqueries are rebuilt first.
+func (w *walker) rebind(query *Query) error {
+	sections := walkBlocks(query)
+	for _, blocks := range sections {
+		for _, block := range blocks {
+			if block.query != nil {
+				if err := w.rebind(block.query); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	oldBefore, newBefore := 0, 0
+	for _, key := range BindingKeys {
+		values := query.Bindings[key]
+		rebuilt, err := w.section(key, values, sections[key], oldBefore, newBefore)
+		if err != nil {
+			return err
+		}
+		oldBefore = oldBefore + len(values)
+		newBefore = newBefore + len(rebuilt)
+		if _, has := query.Bindings[key]; has {
+			query.Bindings[key] = rebuilt
+		}
+	}
+	return nil
+}
+
+// section Rebuild the bindings of a clause, the binding offsets of the subqueries are moved with their bindings.
+func (w *walker) section(key string, values []interface{}, blocks []walkBlock, oldBefore int, newBefore int) ([]interface{}, error) {
+	changed := false
+	for _, block := range blocks {
+		if block.query != nil && !reflect.DeepEqual(w.bindings(block, true), w.bindings(block, false)) {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		for _, block := range blocks {
+			walkShift(block, newBefore-oldBefore)
+		}
+		return values, nil
+	}
+
+	unknown, known := -1, 0
+	for i, block := range blocks {
+		if block.query != nil {
+			known = known + len(w.bindings(block, true))
+		} else if block.count >= 0 {
+			known = known + block.count
+		} else if unknown >= 0 {
+			return nil, fmt.Errorf("%w, the %s clause has more than one raw expression", ErrWalkBindings, key)
+		} else {
+			unknown = i
+		}
+	}
+
+	if unknown >= 0 {
+		blocks[unknown].count = len(values) - known
+	}
+
+	if known > len(values) || (unknown < 0 && known != len(values)) {
+		return nil, fmt.Errorf("%w, the bindings of the %s clause are inconsistent with its nodes", ErrWalkBindings, key)
+	}
+
+	rebuilt := []interface{}{}
+	cursor := 0
+	for _, block := range blocks {
+		if block.query == nil {
+			rebuilt = append(rebuilt, values[cursor:cursor+block.count]...)
+			cursor = cursor + block.count
+			continue
+		}
+
+		if w.added[block.query] {
+			walkShift(block, newBefore+len(rebuilt))
+		} else {
+			walkShift(block, (newBefore+len(rebuilt))-(oldBefore+cursor))
+		}
+		if block.move != nil {
+			block.move(len(w.bindings(block, false)) - len(w.bindings(block, true)))
+		}
+		rebuilt = append(rebuilt, w.bindings(block, false)...)
+		cursor = cursor + len(w.bindings(block, true))
+	}
+	return rebuilt, nil
+}
+
+// walkShift Move the binding offsets of the subquery with its bindings, the subqueries compiled with the
+// binding offset of the parent query (nested wheres, join clauses ...) move the offsets of their subqueries.
+func walkShift(block walkBlock, delta int) {
+	if block.query == nil || delta == 0 {
+		return
+	}
+
+	if block.offset {
+		block.query.BindingOffset = block.query.BindingOffset + delta
+		if block.move != nil && !block.size {
+			block.move(delta)
+		}
+		return
+	}
+
+	for _, blocks := range walkBlocks(block.query) {
+		for _, child := range blocks {
+			walkShift(child, delta)
+		}
+	}
+}
+
+// walkBlocks Get the bindings of the nodes of each binding section in the order they are added by the query builder,
+// the offsets of the subqueries given by the query builder are moved with their bindings.
+func walkBlocks(query *Query) map[string][]walkBlock {
+	sections := map[string][]walkBlock{}
+	add := func(key string, blocks ...walkBlock) {
+		sections[key] = append(sections[key], blocks...)
+	}
+
+	for i, cte := range query.CTEs {
+		if cte.Query != nil {
+			cte := &query.CTEs[i]
+			add("with", walkBlock{query: cte.Query, offset: true, size: true, move: func(delta int) { cte.Offset = cte.Offset + delta }})
+		} else {
+			add("with", walkBlock{count: cte.Offset})
+		}
+	}
+
+	for i, column := range query.Columns {
+		switch value := column.(type) {
+		case Select:
+			if value.Query != nil {
+				i := i
+				add("select", walkBlock{query: value.Query, offset: true, move: func(delta int) {
+					sel := query.Columns[i].(Select)
+					sel.Offset = sel.Offset + delta
+					query.Columns[i] = sel
+				}})
+			}
+		case FullText:
+			add("select", walkBlock{count: 1})
+		case Expression:
+			add("select", walkBlock{count: -1})
+		}
+	}
+
+	if query.From.Type == "sub" && query.From.Query != nil {
+		add("from", walkBlock{query: query.From.Query, offset: true, move: func(delta int) { query.From.Offset = query.From.Offset + delta }})
+	} else if query.From.Type == "raw" {
+		add("from", walkBlock{count: query.From.Offset})
+	}
+
+	for _, join := range query.Joins {
+		if join.Type == "raw" {
+			add("join", walkBlock{count: -1})
+			continue
+		}
+		if sub, ok := join.Name.(*Query); ok {
+			add("join", walkBlock{query: sub, offset: true})
+		}
+		if join.Query != nil && join.Query != query {
+			add("join", walkBlock{query: join.Query})
+		}
+	}
+
+	for i := range query.Wheres {
+		where := &query.Wheres[i]
+		move := func(delta int) { where.Offset = where.Offset + delta }
+		switch where.Type {
+		case "nested", "sub":
+			add("where", walkBlock{query: where.Query, where: true})
+		case "exists":
+			add("where", walkBlock{query: where.Query})
+		case "in":
+			if where.Query != nil {
+				add("where", walkBlock{query: where.Query, offset: true, move: move})
+			} else {
+				add("where", walkBlock{count: walkValues(where.ValuesIn)})
+			}
+		case "between":
+			add("where", walkBlock{count: len(where.Values)})
+		case "fullText":
+			add("where", walkBlock{count: 1})
+		case "column", "null", "notnull", "jsonContainsKey":
+		case "raw":
+			add("where", walkBlock{count: -1})
+		default:
+			if where.Query != nil {
+				add("where", walkBlock{query: where.Query, offset: true, move: move})
+			}
+			if !IsExpression(where.Value) {
+				add("where", walkBlock{count: 1})
+			}
+		}
+	}
+
+	for _, group := range query.Groups {
+		if IsExpression(group) {
+			add("groupBy", walkBlock{count: -1})
+		}
+	}
+
+	for _, having := range query.Havings {
+		switch having.Type {
+		case "raw":
+			add("having", walkBlock{count: -1})
+		case "between":
+			add("having", walkBlock{count: len(having.Values)})
+		default:
+			if !IsExpression(having.Value) {
+				add("having", walkBlock{count: 1})
+			}
+		}
+	}
+
+	for key, orders := range map[string][]Order{"order": query.Orders, "unionOrder": query.UnionOrders} {
+		for _, order := range orders {
+			if order.Query != nil {
+				add(key, walkBlock{query: order.Query, offset: true})
+			} else if order.Type == "raw" {
+				add(key, walkBlock{count: -1})
+			} else if _, ok := order.Column.(FullText); ok {
+				add(key, walkBlock{count: 1})
+			}
+		}
+	}
+
+	for _, union := range query.Unions {
+		add("union", walkBlock{query: union.Query})
+	}
+
+	if query.SQL != "" {
+		add("sql", walkBlock{count: -1})
+	}
+	return sections
+}
+
+// walkValues Get the number of the values which are not expressions
+func walkValues(values interface{}) int {
+	reflectValues := reflect.Indirect(reflect.ValueOf(values))
+	if reflectValues.Kind() != reflect.Slice && reflectValues.Kind() != reflect.Array {
+		return 0
+	}
+
+	count := 0
+	for i := 0; i < reflectValues.Len(); i++ {
+		if !IsExpression(reflectValues.Index(i).Interface()) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,84 @@
+package dbal
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var reWindowBound = regexp.MustCompile(`^(unbounded preceding|unbounded following|current row|[0-9]+ preceding|[0-9]+ following)$`)
+
+// NewWindow make a new window specification instance, the name is the named window the specification is based on.
+func NewWindow(name ...string) *Window {
+	window := &Window{
+		Partitions: []interface{}{},
+		Orders:     []Order{},
+	}
+	if len(name) > 0 {
+		window.Name = name[0]
+	}
+	return window
+}
+
+// PartitionBy Add the "partition by" columns to the window.
+func (window *Window) PartitionBy(columns ...interface{}) *Window {
+	window.Partitions = append(window.Partitions, columns...)
+	return window
+}
+
+// OrderBy Add an "order by" column to the window.
+func (window *Window) OrderBy(column interface{}, direction ...string) *Window {
+	order := Order{Type: "basic", Column: column, Direction: "asc"}
+	if len(direction) > 0 {
+		order.Direction = strings.ToLower(direction[0])
+	}
+	window.Orders = append(window.Orders, order)
+	return window
+}
+
+// Rows Set the "rows" frame of the window, the end bound could be empty.
+//
+//	Over().OrderBy("id").Rows("unbounded preceding", "current row")
+func (window *Window) Rows(start string, end ...string) *Window {
+	return window.setFrame("rows", start, end...)
+}
+
+// Range Set the "range" frame of the window, the end bound could be empty.
+//
+//	Over().OrderBy("id").Range("1 preceding", "1 following")
+func (window *Window) Range(start string, end ...string) *Window {
+	return window.setFrame("range", start, end...)
+}
+
+// Validate Determine if the window specification is valid.
+func (window Window) Validate() error {
+	for _, order := range window.Orders {
+		if order.Direction != "asc" && order.Direction != "desc" {
+			return fmt.Errorf(`Order direction must be "asc" or "desc"`)
+		}
+	}
+
+	if window.Frame == "" {
+		return nil
+	}
+
+	if !reWindowBound.MatchString(window.FrameStart) {
+		return fmt.Errorf("the frame start bound %q is invalid", window.FrameStart)
+	}
+
+	if window.FrameEnd != "" && !reWindowBound.MatchString(window.FrameEnd) {
+		return fmt.Errorf("the frame end bound %q is invalid", window.FrameEnd)
+	}
+	return nil
+}
+
+// setFrame Set the frame of the window
+func (window *Window) setFrame(unit string, start string, end ...string) *Window {
+	window.Frame = unit
+	window.FrameStart = strings.ToLower(strings.TrimSpace(start))
+	window.FrameEnd = ""
+	if len(end) > 0 {
+		window.FrameEnd = strings.ToLower(strings.TrimSpace(end[0]))
+	}
+	return window
+}
@@ -17,6 +17,7 @@ require (
 	github.com/qustavo/sqlhooks/v2 v2.1.0
 	github.com/stretchr/testify v1.7.1
 	github.com/yaoapp/kun v0.9.0
+	gopkg.in/yaml.v3 v3.0.0
 )
 
 require (
@@ -30,5 +31,4 @@ require (
 	golang.org/x/crypto v0.23.0 // indirect
 	golang.org/x/sys v0.20.0 // indirect
 	golang.org/x/text v0.15.0 // indirect
-	gopkg.in/yaml.v3 v3.0.0 // indirect
 )
@@ -0,0 +1,96 @@
+package mysql
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// bulkLoadSeq the sequence of the reader handlers registered by the bulk loads
+var bulkLoadSeq uint64 = 0
+
+// BulkLoad Load the rows into the table with the "load data local infile" statement, the rows are streamed
+// to the server by a registered reader. The local_infile variable of the server should be enabled.
+// It returns the number of the loaded rows.
+func (grammarSQL MySQL) BulkLoad(query *dbal.Query, columns []string, rows dbal.RowIterator) (int64, error) {
+	names := []interface{}{}
+	for _, column := range columns {
+		names = append(names, column)
+	}
+
+	handler := fmt.Sprintf("xun_bulk_load_%d", atomic.AddUint64(&bulkLoadSeq, 1))
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeBulkLoadRows(writer, rows))
+	}()
+
+	// the reader is closed after the statement, so the writer stops if the server does not read all the rows
+	defer reader.Close()
+	mysql.RegisterReaderHandler(handler, func() io.Reader { return reader })
+	defer mysql.DeregisterReaderHandler(handler)
+
+	sql := fmt.Sprintf(
+		"load data local infile 'Reader::%s' into table %s character set utf8mb4 fields terminated by ',' enclosed by '\"' escaped by '' lines terminated by '\\n' (%s)",
+		handler, grammarSQL.WrapTable(query.From), grammarSQL.Columnize(names),
+	)
+	defer log.Debug(sql)
+
+	res, err := grammarSQL.Executor().ExecContext(grammarSQL.Context(), sql)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// writeBulkLoadRows Write the rows in the format of the "load data" statement, the strings are always enclosed
+// and the quotes are doubled, the NULL values are written as the unenclosed NULL word.
+func writeBulkLoadRows(w io.Writer, rows dbal.RowIterator) error {
+	buf := bufio.NewWriter(w)
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			return buf.Flush()
+		}
+		if err != nil {
+			return err
+		}
+
+		fields := []string{}
+		for _, value := range row {
+			fields = append(fields, bulkLoadField(value))
+		}
+
+		_, err = buf.WriteString(strings.Join(fields, ",") + "\n")
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// bulkLoadField Format a value as a field of the "load data" statement
+func bulkLoadField(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v)
+	case time.Time:
+		return fmt.Sprintf(`"%s"`, v.Format("2006-01-02 15:04:05.999999"))
+	case []byte:
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(string(v), `"`, `""`))
+	default:
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(fmt.Sprintf("%v", v), `"`, `""`))
+	}
+}
@@ -0,0 +1,34 @@
+package mysql
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkWriteBulkLoadRows(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := writeBulkLoadRows(buf, &testRows{rows: [][]interface{}{
+		{"max@yao.run", 19, true, nil},
+		{`say "hi"`, 1.5, false, time.Date(2021, 3, 25, 8, 30, 15, 0, time.UTC)},
+		{"NULL", int64(-1), []byte("a,b"), "line\nbreak"},
+	}})
+	assert.Nil(t, err)
+	assert.Equal(t, "\"max@yao.run\",19,1,NULL\n\"say \"\"hi\"\"\",1.5,0,\"2021-03-25 08:30:15\"\n\"NULL\",-1,\"a,b\",\"line\nbreak\"\n", buf.String())
+}
+
+type testRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (rows *testRows) Next() ([]interface{}, error) {
+	if rows.pos >= len(rows.rows) {
+		return nil, io.EOF
+	}
+	rows.pos++
+	return rows.rows[rows.pos-1], nil
+}
@@ -43,6 +43,7 @@ func (grammarSQL MySQL) CompileSelectOffset(query *dbal.Query, offset *int) stri
 	// To compile the query, we'll spin through each component of the query and
 	// see if that component exists. If it does we'll just call the compiler
 	// function for the component which is responsible for making the SQL.
+	sqls["with"] = grammarSQL.CompileWith(query, offset)
 	sqls["aggregate"] = grammarSQL.CompileAggregate(query, query.Aggregate)
 	sqls["columns"] = grammarSQL.CompileColumns(query, query.Columns, offset)
 	sqls["from"] = grammarSQL.CompileFrom(query, query.From, offset)
@@ -50,13 +51,21 @@ func (grammarSQL MySQL) CompileSelectOffset(query *dbal.Query, offset *int) stri
 	sqls["wheres"] = grammarSQL.CompileWheres(query, query.Wheres, offset)
 	sqls["groups"] = grammarSQL.CompileGroups(query, query.Groups, offset)
 	sqls["havings"] = grammarSQL.CompileHavings(query, query.Havings, offset)
+	sqls["windows"] = grammarSQL.CompileWindows(query, query.Windows)
 	sqls["orders"] = grammarSQL.CompileOrders(query, query.Orders, offset)
 	sqls["limit"] = grammarSQL.CompileLimit(query, query.Limit, offset)
 	sqls["offset"] = grammarSQL.CompileOffset(query, query.Offset)
 	sqls["lock"] = grammarSQL.CompileLock(query, query.Lock)
 
+	// The execution time of the select is limited by the optimizer hint
+	if sqls["aggregate"] != "" {
+		sqls["aggregate"] = grammarSQL.CompileTimeoutHint(sqls["aggregate"], query.Timeout)
+	} else {
+		sqls["columns"] = grammarSQL.CompileTimeoutHint(sqls["columns"], query.Timeout)
+	}
+
 	sql := ""
-	for _, name := range []string{"aggregate", "columns", "from", "joins", "wheres", "groups", "havings", "orders", "limit", "offset", "lock"} {
+	for _, name := range []string{"with", "aggregate", "columns", "from", "joins", "wheres", "groups", "havings", "windows", "orders", "limit", "offset", "lock"} {
 		segment, has := sqls[name]
 		if has && segment != "" {
 			sql = sql + segment + " "
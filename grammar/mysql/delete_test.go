@@ -0,0 +1,55 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestDeleteCompileDeleteJoin(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	on := dbal.NewQuery()
+	on.IsJoinClause = true
+	on.Wheres = []dbal.Where{{Type: "column", First: "users.id", Operator: "=", Second: "scores.user_id", Boolean: "and"}}
+	query.Joins = []dbal.Join{{Type: "inner", Name: dbal.NewName("scores"), Query: on}}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "scores.vote", Operator: ">", Value: 10, Boolean: "and", Offset: 1}}
+	query.AddBinding("where", 10)
+
+	sql, bindings := grammarSQL.CompileDelete(query)
+	assert.Equal(t, "delete `users` from `users` inner join `scores` on `users`.`id` = `scores`.`user_id` where `scores`.`vote` > ?", sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestDeleteCompileDeleteOrderLimit(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "vote", Operator: ">", Value: 10, Boolean: "and", Offset: 1}}
+	query.AddBinding("where", 10)
+	query.Orders = []dbal.Order{{Type: "basic", Column: "id", Direction: "desc"}}
+	query.Limit = 2
+
+	sql, bindings := grammarSQL.CompileDelete(query)
+	assert.Equal(t, "delete from `users` where `vote` > ? order by `id` desc limit 2", sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestDeleteCompileDeleteJoinLimit(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	on := dbal.NewQuery()
+	on.IsJoinClause = true
+	on.Wheres = []dbal.Where{{Type: "column", First: "users.id", Operator: "=", Second: "scores.user_id", Boolean: "and"}}
+	query.Joins = []dbal.Join{{Type: "inner", Name: dbal.NewName("scores"), Query: on}}
+	query.Orders = []dbal.Order{{Type: "basic", Column: "users.id", Direction: "desc"}}
+	query.Limit = 2
+
+	assert.False(t, grammarSQL.SupportsDeleteJoinOrderLimit())
+	assert.PanicsWithError(t, "the joined delete with the order or the limit is not supported by mysql", func() {
+		grammarSQL.CompileDelete(query)
+	})
+}
@@ -0,0 +1,65 @@
+package mysql
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/yaoapp/xun/dbal"
+)
+
+var (
+	errorDuplicateKey = regexp.MustCompile(`for key '([^']+)'`)
+	errorForeignKey   = regexp.MustCompile("\\(`[^`]+`\\.`([^`]+)`, CONSTRAINT `([^`]+)` FOREIGN KEY \\(`([^`]+)`\\)")
+	errorColumn       = regexp.MustCompile(`(?:Column|Field) '([^']+)'`)
+)
+
+// TranslateError Translate the *mysql.MySQLError into a *dbal.Error by the error number,
+// the constraint and column are extracted from the message.
+func (grammarSQL MySQL) TranslateError(err error) error {
+	var myErr *mysql.MySQLError
+	if err == nil || !errors.As(err, &myErr) {
+		return grammarSQL.SQL.TranslateError(err)
+	}
+
+	switch myErr.Number {
+	case 1062:
+		dbErr := dbal.NewError(dbal.ErrUniqueViolation, err)
+		if matched := errorDuplicateKey.FindStringSubmatch(myErr.Message); len(matched) == 2 {
+			// MySQL 8.0 gives the key name with the table name
+			dbErr.Constraint = matched[1]
+			if idx := strings.LastIndex(matched[1], "."); idx >= 0 {
+				dbErr.Table, dbErr.Constraint = matched[1][:idx], matched[1][idx+1:]
+			}
+		}
+		return dbErr
+
+	case 1216, 1217, 1451, 1452:
+		dbErr := dbal.NewError(dbal.ErrForeignKeyViolation, err)
+		if matched := errorForeignKey.FindStringSubmatch(myErr.Message); len(matched) == 4 {
+			dbErr.Table, dbErr.Constraint, dbErr.Column = matched[1], matched[2], matched[3]
+		}
+		return dbErr
+
+	case 1048, 1364:
+		dbErr := dbal.NewError(dbal.ErrNotNullViolation, err)
+		if matched := errorColumn.FindStringSubmatch(myErr.Message); len(matched) == 2 {
+			dbErr.Column = matched[1]
+		}
+		return dbErr
+
+	case 1213:
+		return dbal.NewError(dbal.ErrDeadlock, err)
+
+	case 1205, 3572:
+		return dbal.NewError(dbal.ErrLockTimeout, err)
+
+	case 1317:
+		return dbal.NewError(dbal.ErrQueryCanceled, err)
+
+	case 3024:
+		return dbal.NewError(dbal.ErrQueryTimeout, err)
+	}
+	return grammarSQL.SQL.TranslateError(err)
+}
@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestErrorsTranslateUniqueViolation(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	err := grammarSQL.TranslateError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'max@yao.run' for key 'users.users_email_unique'"})
+	assert.True(t, errors.Is(err, dbal.ErrUniqueViolation))
+
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr)) {
+		assert.Equal(t, "users", dbErr.Table)
+		assert.Equal(t, "users_email_unique", dbErr.Constraint)
+	}
+
+	var myErr *mysql.MySQLError
+	assert.True(t, errors.As(err, &myErr), "the driver error should be wrapped")
+}
+
+func TestErrorsTranslateForeignKeyViolation(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	err := grammarSQL.TranslateError(&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`xun`.`posts`, CONSTRAINT `posts_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`))"})
+	assert.True(t, errors.Is(err, dbal.ErrForeignKeyViolation))
+
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr)) {
+		assert.Equal(t, "posts", dbErr.Table)
+		assert.Equal(t, "posts_user_id_foreign", dbErr.Constraint)
+		assert.Equal(t, "user_id", dbErr.Column)
+	}
+}
+
+func TestErrorsTranslateOthers(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	err := grammarSQL.TranslateError(&mysql.MySQLError{Number: 1048, Message: "Column 'name' cannot be null"})
+	assert.True(t, errors.Is(err, dbal.ErrNotNullViolation))
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr)) {
+		assert.Equal(t, "name", dbErr.Column)
+	}
+
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&mysql.MySQLError{Number: 1213}), dbal.ErrDeadlock))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&mysql.MySQLError{Number: 1205}), dbal.ErrLockTimeout))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&mysql.MySQLError{Number: 1317}), dbal.ErrQueryCanceled))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&mysql.MySQLError{Number: 3024}), dbal.ErrQueryTimeout))
+
+	other := &mysql.MySQLError{Number: 1146, Message: "Table 'xun.missing' doesn't exist"}
+	assert.Equal(t, error(other), grammarSQL.TranslateError(other))
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/yaoapp/xun/dbal"
@@ -9,6 +10,16 @@ import (
 // CompileInsertOrIgnore Compile an insert ignore statement into SQL.
 func (grammarSQL MySQL) CompileInsertOrIgnore(query *dbal.Query, columns []interface{}, values [][]interface{}) (string, []interface{}) {
 	sql, bindings := grammarSQL.CompileInsert(query, columns, values)
-	sql = strings.Replace(sql, "insert", "insert ignore", 1)
+	sql = strings.Replace(sql, "insert into", "insert ignore into", 1)
 	return sql, bindings
 }
+
+// CompileInsertUsing Compile an insert statement using a subquery into SQL.
+// MySQL requires the common table expressions placed between the insert clause and the select statement.
+func (grammarSQL MySQL) CompileInsertUsing(query *dbal.Query, columns []interface{}, sql string) string {
+	offset := 0
+	if with := grammarSQL.CompileWith(query, &offset); with != "" {
+		return fmt.Sprintf("INSERT INTO %s (%s) %s %s", grammarSQL.WrapTable(query.From), grammarSQL.Columnize(columns), with, sql)
+	}
+	return grammarSQL.SQL.CompileInsertUsing(query, columns, sql)
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/blang/semver/v4"
@@ -66,6 +67,24 @@ func (grammarSQL MySQL) NewWithRead(write *sqlx.DB, writeConfig *dbal.Config, re
 	return grammarSQL, nil
 }
 
+// NewWithTx Create a new grammar interface, using the given *sqlx.Tx for executing statements.
+func (grammarSQL MySQL) NewWithTx(tx *sqlx.Tx) (dbal.Grammar, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("tx is nil")
+	}
+	grammarSQL.Tx = tx
+	return grammarSQL, nil
+}
+
+// NewWithContext Create a new grammar interface, using the given context for executing statements.
+func (grammarSQL MySQL) NewWithContext(ctx context.Context) (dbal.Grammar, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("ctx is nil")
+	}
+	grammarSQL.Ctx = ctx
+	return grammarSQL, nil
+}
+
 // OnConnected the event will be triggered when db server was connected
 func (grammarSQL MySQL) OnConnected() error {
 	version, err := grammarSQL.GetVersion()
@@ -77,13 +96,13 @@ func (grammarSQL MySQL) OnConnected() error {
 		return err
 	}
 	if version.LE(ver577) {
-		grammarSQL.DB.Exec("SET GLOBAL innodb_file_format=`BARRACUDA`")
-		grammarSQL.DB.Exec("SET GLOBAL innodb_file_per_table=`ON`;")
-		grammarSQL.DB.Exec("SET GLOBAL innodb_large_prefix=`ON`;")
+		grammarSQL.Executor().Exec("SET GLOBAL innodb_file_format=`BARRACUDA`")
+		grammarSQL.Executor().Exec("SET GLOBAL innodb_file_per_table=`ON`;")
+		grammarSQL.Executor().Exec("SET GLOBAL innodb_large_prefix=`ON`;")
 	}
 
 	// Auto set sql mode
-	grammarSQL.DB.Exec("SET GLOBAL sql_mode=`STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION`;")
+	grammarSQL.Executor().Exec("SET GLOBAL sql_mode=`STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION`;")
 	return nil
 }
 
@@ -105,3 +124,13 @@ func New(opts ...sql.Option) dbal.Grammar {
 	}
 	return my
 }
+
+// GetMaxPacketSize get the maximum size in bytes of one statement, the max_allowed_packet of the server
+func (grammarSQL MySQL) GetMaxPacketSize() (int, error) {
+	size := 0
+	err := grammarSQL.Executor().Get(&size, "SELECT @@max_allowed_packet")
+	if err != nil {
+		return 0, err
+	}
+	return size, nil
+}
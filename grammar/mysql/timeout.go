@@ -0,0 +1,23 @@
+package mysql
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CompileTimeoutHint Add the MAX_EXECUTION_TIME optimizer hint to the given "select" clause,
+// the server stops the select when the timeout is exceeded (MySQL 5.7.8+, ignored by MariaDB).
+//
+//	select /*+ MAX_EXECUTION_TIME(1000) */ `id` ...
+func (grammarSQL MySQL) CompileTimeoutHint(sql string, timeout time.Duration) string {
+	if timeout <= 0 || !strings.HasPrefix(sql, "select") {
+		return sql
+	}
+
+	ms := timeout.Milliseconds()
+	if ms < 1 {
+		ms = 1
+	}
+	return fmt.Sprintf("select /*+ MAX_EXECUTION_TIME(%d) */%s", ms, strings.TrimPrefix(sql, "select"))
+}
@@ -0,0 +1,27 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestTimeoutCompileSelect(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	query.Columns = []interface{}{"id"}
+	query.Timeout = 1500 * time.Millisecond
+	assert.Equal(t, "select /*+ MAX_EXECUTION_TIME(1500) */ `id` from `users`", grammarSQL.CompileSelect(query))
+
+	query.Timeout = 0
+	assert.Equal(t, "select `id` from `users`", grammarSQL.CompileSelect(query))
+}
+
+func TestTimeoutCompileTimeoutHint(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	assert.Equal(t, "select /*+ MAX_EXECUTION_TIME(1) */ count(*) as aggregate", grammarSQL.CompileTimeoutHint("select count(*) as aggregate", time.Microsecond))
+	assert.Equal(t, "select distinct `id`", grammarSQL.CompileTimeoutHint("select distinct `id`", 0))
+}
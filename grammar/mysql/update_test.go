@@ -0,0 +1,49 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestUpdateCompileUpdateJoin(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	on := dbal.NewQuery()
+	on.IsJoinClause = true
+	on.Wheres = []dbal.Where{{Type: "column", First: "users.id", Operator: "=", Second: "scores.user_id", Boolean: "and"}}
+	query.Joins = []dbal.Join{{Type: "inner", Name: dbal.NewName("scores"), Query: on}}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "scores.vote", Operator: ">", Value: 10, Boolean: "and", Offset: 1}}
+	query.AddBinding("where", 10)
+
+	sql, bindings := grammarSQL.CompileUpdate(query, map[string]interface{}{
+		"users.vote": dbal.Raw("`scores`.`vote`"),
+	})
+	assert.Equal(t, "update `users` inner join `scores` on `users`.`id` = `scores`.`user_id` set `users`.`vote`=`scores`.`vote` where `scores`.`vote` > ?", sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestUpdateCompileUpdateBatch(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "status", Operator: "=", Value: "DONE", Boolean: "and", Offset: 1}}
+	query.AddBinding("where", "DONE")
+	sql, bindings := grammarSQL.CompileUpdateBatch(query, "id", []string{"name", "vote"}, []map[string]interface{}{
+		{"id": 1, "vote": 10, "name": "Max"},
+		{"id": 2, "vote": 20},
+	}, "case")
+	assert.Equal(t, "update `users` set `name`=case `id` when ? then ? else `name` end, `vote`=case `id` when ? then ? when ? then ? else `vote` end where `id` in (?, ?) and (`status` = ?)", sql)
+	assert.Equal(t, []interface{}{1, "Max", 1, 10, 2, 20, 1, 2, "DONE"}, bindings)
+}
+
+func TestUpdateCompileUpdateBatchValues(t *testing.T) {
+	grammarSQL := New().(MySQL)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	assert.PanicsWithError(t, "the values strategy of the batch update is not supported by mysql", func() {
+		grammarSQL.CompileUpdateBatch(query, "id", []string{"vote"}, []map[string]interface{}{{"id": 1, "vote": 10}}, "values")
+	})
+}
@@ -0,0 +1,12 @@
+package mysql
+
+import (
+	"github.com/blang/semver/v4"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsWindowFunctions Determine if the given version of the database server supports the window functions.
+// The window functions are available since MySQL 8.0.
+func (grammarSQL MySQL) SupportsWindowFunctions(version *dbal.Version) bool {
+	return version.GTE(semver.MustParse("8.0.0"))
+}
@@ -105,6 +105,9 @@ func (grammarSQL Postgres) SQLAddIndex(index *dbal.Index) string {
 
 	sql := ""
 	name := quoter.ID(fmt.Sprintf("%s_%s", index.TableName, index.Name))
+	if index.Type == "fulltext" {
+		return grammarSQL.sqlAddFullTextIndex(name, index)
+	}
 
 	for _, column := range index.Columns {
 		columns = append(columns, quoter.ID(column.Name))
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// BulkLoad Load the rows into the table with the "copy ... from stdin" statement, it must be called within a transaction.
+// It returns the number of the loaded rows.
+func (grammarSQL Postgres) BulkLoad(query *dbal.Query, columns []string, rows dbal.RowIterator) (int64, error) {
+	name, ok := query.From.Name.(dbal.Name)
+	if !ok {
+		return 0, fmt.Errorf("the bulk load needs a table")
+	}
+
+	schema, table := grammarSQL.copyTable(name)
+	sql := pq.CopyInSchema(schema, table, columns...)
+	defer log.Debug(sql)
+
+	stmt, err := grammarSQL.Executor().Prepare(sql)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var loaded int64 = 0
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return loaded, err
+		}
+
+		_, err = stmt.ExecContext(grammarSQL.Context(), row...)
+		if err != nil {
+			return loaded, err
+		}
+		loaded++
+	}
+
+	// the buffered rows are flushed by the statement without any argument
+	_, err = stmt.ExecContext(grammarSQL.Context())
+	if err != nil {
+		return loaded, err
+	}
+	return loaded, nil
+}
+
+// copyTable Split the schema and the table name of the "copy" statement, the schema of the connection is used if the name
+// is not qualified, and the prefix is added to the table name only ( sales.users => "sales"."xun_users" ).
+func (grammarSQL Postgres) copyTable(name dbal.Name) (string, string) {
+	schema := grammarSQL.GetSchema()
+	table := name.Name
+	if idx := strings.LastIndex(table, "."); idx >= 0 {
+		schema = table[:idx]
+		table = table[idx+1:]
+	}
+	return schema, name.Prefix + table
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestBulkBulkLoadPrefix(t *testing.T) {
+	offline := dbal.NewOffline(true)
+	grammar, err := New().NewWith(offline.Open("postgres"), &dbal.Config{Driver: "postgres"}, &dbal.Option{Prefix: "xun_"})
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users", "xun_")}
+	loaded, err := grammar.BulkLoad(query, []string{"email", "vote"}, &testRows{rows: [][]interface{}{{"max@yao.run", 19}}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), loaded)
+	assert.Equal(t, `COPY "public"."xun_users" ("email", "vote") FROM STDIN`, offline.Statements()[0])
+
+	// the prefix is added to the table name of the schema-qualified tables
+	offline.Reset()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("sales.users", "xun_")}
+	_, err = grammar.BulkLoad(query, []string{"email"}, &testRows{rows: [][]interface{}{{"max@yao.run"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, `COPY "sales"."xun_users" ("email") FROM STDIN`, offline.Statements()[0])
+}
+
+type testRows struct {
+	rows [][]interface{}
+	pos  int
+}
+
+func (rows *testRows) Next() ([]interface{}, error) {
+	if rows.pos >= len(rows.rows) {
+		return nil, io.EOF
+	}
+	rows.pos++
+	return rows.rows[rows.pos-1], nil
+}
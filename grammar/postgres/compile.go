@@ -45,6 +45,7 @@ func (grammarSQL Postgres) CompileSelectOffset(query *dbal.Query, offset *int) s
 	// To compile the query, we'll spin through each component of the query and
 	// see if that component exists. If it does we'll just call the compiler
 	// function for the component which is responsible for making the SQL.
+	sqls["with"] = grammarSQL.CompileWith(query, offset)
 	sqls["aggregate"] = grammarSQL.CompileAggregate(query, query.Aggregate)
 	sqls["columns"] = grammarSQL.CompileColumns(query, query.Columns, offset)
 	sqls["from"] = grammarSQL.CompileFrom(query, query.From, offset)
@@ -52,13 +53,14 @@ func (grammarSQL Postgres) CompileSelectOffset(query *dbal.Query, offset *int) s
 	sqls["wheres"] = grammarSQL.CompileWheres(query, query.Wheres, offset)
 	sqls["groups"] = grammarSQL.CompileGroups(query, query.Groups, offset)
 	sqls["havings"] = grammarSQL.CompileHavings(query, query.Havings, offset)
+	sqls["windows"] = grammarSQL.CompileWindows(query, query.Windows)
 	sqls["orders"] = grammarSQL.CompileOrders(query, query.Orders, offset)
 	sqls["limit"] = grammarSQL.CompileLimit(query, query.Limit, offset)
 	sqls["offset"] = grammarSQL.CompileOffset(query, query.Offset)
 	sqls["lock"] = grammarSQL.CompileLock(query, query.Lock)
 
 	sql := ""
-	for _, name := range []string{"aggregate", "columns", "from", "joins", "wheres", "groups", "havings", "orders", "limit", "offset", "lock"} {
+	for _, name := range []string{"with", "aggregate", "columns", "from", "joins", "wheres", "groups", "havings", "windows", "orders", "limit", "offset", "lock"} {
 		segment, has := sqls[name]
 		if has && segment != "" {
 			sql = sql + segment + " "
@@ -92,15 +94,8 @@ func (grammarSQL Postgres) CompileColumns(query *dbal.Query, columns []interface
 		sql = "select distinct"
 	}
 
-	sql = fmt.Sprintf("%s %s", sql, grammarSQL.Columnize(columns))
-
-	for _, col := range columns {
-		switch col.(type) {
-		case dbal.Select:
-			*bindingOffset = *bindingOffset + col.(dbal.Select).Offset
-		}
-	}
-
+	columns = grammarSQL.CompileFullTextColumns(columns, bindingOffset, grammarSQL.CompileFullTextRank)
+	sql = fmt.Sprintf("%s %s", sql, grammarSQL.Columnize(grammarSQL.CompileWindowFunctions(grammarSQL.CompileAggregateFunctions(columns))))
 	return sql
 }
 
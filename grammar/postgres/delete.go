@@ -7,22 +7,21 @@ import (
 )
 
 // CompileDelete  Compile a delete statement into SQL.
+// The inner joins compile to "delete from t using ...", the other joins, orders and limit are matched by the ctid.
 func (grammarSQL Postgres) CompileDelete(query *dbal.Query) (string, []interface{}) {
 
-	if len(query.Joins) == 0 && query.Limit < 0 {
+	if grammarSQL.IsDeleteUsing(query) {
+		return grammarSQL.compileDeleteUsing(query)
+	}
+
+	if len(query.Joins) == 0 && query.Limit < 0 && len(query.Orders) == 0 {
 		return grammarSQL.SQL.CompileDelete(query)
 	}
 
 	offset := 0
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
-
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.ctid", alias)}
-	} else {
-		query.Columns = []interface{}{"ctid"}
-	}
+	query.Columns = []interface{}{fmt.Sprintf("%s.ctid", grammarSQL.FromReference(query.From))}
 
 	selectSQL := grammarSQL.CompileSelectOffset(query, &offset)
 
@@ -32,6 +31,37 @@ func (grammarSQL Postgres) CompileDelete(query *dbal.Query) (string, []interface
 	return sql, bindings
 }
 
+// compileDeleteUsing Compile a "delete ... using" statement, the joined tables are moved to the "using" clause
+// and the join conditions are moved to the "where" clause.
+//
+//	delete from "users" using "orders" where "users"."id" = "orders"."user_id" and ("orders"."status" = $1)
+func (grammarSQL Postgres) compileDeleteUsing(query *dbal.Query) (string, []interface{}) {
+	offset := 0
+	bindings := []interface{}{}
+	table := grammarSQL.WrapTable(query.From)
+
+	with := grammarSQL.CompileWith(query, &offset)
+	bindings = append(bindings, query.GetBindings("with")...)
+
+	tables, conditions, joinBindings := grammarSQL.CompileFromJoins(query, &offset, grammarSQL.CompileWheres)
+	bindings = append(bindings, joinBindings...)
+
+	sql := fmt.Sprintf("delete from %s using %s", table, tables)
+	if conditions != "" {
+		sql = fmt.Sprintf("%s where %s", sql, conditions)
+	}
+	if with != "" {
+		sql = fmt.Sprintf("%s %s", with, sql)
+	}
+	return sql, bindings
+}
+
+// SupportsDeleteJoinOrderLimit Determine if the joined deletes could be ordered and limited,
+// the rows of the joined deletes are matched by a subquery.
+func (grammarSQL Postgres) SupportsDeleteJoinOrderLimit() bool {
+	return true
+}
+
 // CompileTruncate Compile a truncate table statement into SQL.
 func (grammarSQL Postgres) CompileTruncate(query *dbal.Query) ([]string, [][]interface{}) {
 	sql := fmt.Sprintf("truncate table %s restart identity cascade", grammarSQL.WrapTable(query.From))
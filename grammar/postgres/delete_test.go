@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteCompileDeleteUsing(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := newUpdateJoinQueryForTest()
+	sql, bindings := grammarSQL.CompileDelete(query)
+	assert.Equal(t, `delete from "users" using "scores" where "users"."id" = "scores"."user_id" and ("scores"."vote" > $1)`, sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestDeleteCompileDeleteLeftJoin(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := newUpdateJoinQueryForTest()
+	query.Joins[0].Type = "left"
+	sql, bindings := grammarSQL.CompileDelete(query)
+	assert.Equal(t, `delete from "users" where "ctid" in (select "users"."ctid" from "users" left join "scores" on "users"."id" = "scores"."user_id" where "scores"."vote" > $1)`, sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/yaoapp/xun/dbal"
+)
+
+var errorKeyDetail = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+
+// TranslateError Translate the *pq.Error into a *dbal.Error by the SQLSTATE code,
+// the constraint, table and column are given by the error fields.
+func (grammarSQL Postgres) TranslateError(err error) error {
+	var pqErr *pq.Error
+	if err == nil || !errors.As(err, &pqErr) {
+		return grammarSQL.SQL.TranslateError(err)
+	}
+
+	var kind error
+	switch pqErr.Code {
+	case "23505":
+		kind = dbal.ErrUniqueViolation
+	case "23503":
+		kind = dbal.ErrForeignKeyViolation
+	case "23502":
+		kind = dbal.ErrNotNullViolation
+	case "40P01":
+		kind = dbal.ErrDeadlock
+	case "40001":
+		kind = dbal.ErrSerialization
+	case "55P03":
+		kind = dbal.ErrLockTimeout
+	case "57014":
+		kind = dbal.ErrQueryCanceled
+		if strings.Contains(pqErr.Message, "statement timeout") {
+			kind = dbal.ErrQueryTimeout
+		}
+	default:
+		return grammarSQL.SQL.TranslateError(err)
+	}
+
+	dbErr := dbal.NewError(kind, err)
+	dbErr.Constraint = pqErr.Constraint
+	dbErr.Table = pqErr.Table
+	dbErr.Column = pqErr.Column
+
+	// the columns of the unique and foreign keys are given by the detail
+	if matched := errorKeyDetail.FindStringSubmatch(pqErr.Detail); dbErr.Column == "" && len(matched) == 2 {
+		dbErr.Column = matched[1]
+	}
+	return dbErr
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestErrorsTranslateUniqueViolation(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	err := grammarSQL.TranslateError(&pq.Error{
+		Code:       "23505",
+		Table:      "users",
+		Constraint: "users_email_unique",
+		Detail:     "Key (email)=(max@yao.run) already exists.",
+	})
+	assert.True(t, errors.Is(err, dbal.ErrUniqueViolation))
+
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr)) {
+		assert.Equal(t, "users", dbErr.Table)
+		assert.Equal(t, "users_email_unique", dbErr.Constraint)
+		assert.Equal(t, "email", dbErr.Column)
+	}
+
+	var pqErr *pq.Error
+	assert.True(t, errors.As(err, &pqErr), "the driver error should be wrapped")
+}
+
+func TestErrorsTranslateOthers(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	err := grammarSQL.TranslateError(&pq.Error{Code: "23502", Table: "users", Column: "name"})
+	assert.True(t, errors.Is(err, dbal.ErrNotNullViolation))
+	var dbErr *dbal.Error
+	if assert.True(t, errors.As(err, &dbErr)) {
+		assert.Equal(t, "name", dbErr.Column)
+	}
+
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&pq.Error{Code: "23503"}), dbal.ErrForeignKeyViolation))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&pq.Error{Code: "40P01"}), dbal.ErrDeadlock))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&pq.Error{Code: "40001"}), dbal.ErrSerialization))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&pq.Error{Code: "55P03"}), dbal.ErrLockTimeout))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&pq.Error{Code: "57014", Message: "canceling statement due to user request"}), dbal.ErrQueryCanceled))
+	assert.True(t, errors.Is(grammarSQL.TranslateError(&pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"}), dbal.ErrQueryTimeout))
+
+	other := &pq.Error{Code: "42P01"}
+	assert.Equal(t, error(other), grammarSQL.TranslateError(other))
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// FullTextLanguage the default text search configuration of the full-text indexes and searches
+const FullTextLanguage = "english"
+
+var reFullTextColumn = regexp.MustCompile(`(?i)coalesce\(\(*"?([^"(),:\s]+)"?`)
+
+// WhereFullText Compile a "where full-text" clause.
+func (grammarSQL Postgres) WhereFullText(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	*bindingOffset = *bindingOffset + where.Offset
+	return grammarSQL.CompileFullTextMatch(*where.FullText, grammarSQL.Parameter(where.FullText.Term, *bindingOffset))
+}
+
+// CompileFullTextMatch Compile the full-text search expression.
+//
+//	to_tsvector('english', coalesce("title", '') || ' ' || coalesce("content", '')) @@ plainto_tsquery('english', $1)
+func (grammarSQL Postgres) CompileFullTextMatch(fulltext dbal.FullText, parameter string) string {
+	return fmt.Sprintf("%s @@ %s", grammarSQL.fullTextVector(fulltext), grammarSQL.fullTextQuery(fulltext, parameter))
+}
+
+// CompileFullTextRank Compile the full-text rank expression.
+//
+//	ts_rank(to_tsvector('english', coalesce("content", '')), plainto_tsquery('english', $1))
+func (grammarSQL Postgres) CompileFullTextRank(fulltext dbal.FullText, parameter string) string {
+	return fmt.Sprintf("ts_rank(%s, %s)", grammarSQL.fullTextVector(fulltext), grammarSQL.fullTextQuery(fulltext, parameter))
+}
+
+// CompileOrders Compile the "order by" portions of the query.
+func (grammarSQL Postgres) CompileOrders(query *dbal.Query, orders []dbal.Order, bindingOffset *int) string {
+	orders = grammarSQL.CompileFullTextOrders(orders, bindingOffset, grammarSQL.CompileFullTextRank)
+	return grammarSQL.SQL.CompileOrders(query, orders, bindingOffset)
+}
+
+// fullTextDocument Make the tsvector document of the given quoted columns, the expression
+// of the full-text index must be the same as the search one to be used by the planner.
+func (grammarSQL Postgres) fullTextDocument(language string, columns []string) string {
+	if language == "" {
+		language = FullTextLanguage
+	}
+	fields := []string{}
+	for _, column := range columns {
+		fields = append(fields, fmt.Sprintf("coalesce(%s, '')", column))
+	}
+	return fmt.Sprintf("to_tsvector(%s, %s)", grammarSQL.VAL(language), strings.Join(fields, " || ' ' || "))
+}
+
+// fullTextVector Make the tsvector document of the search
+func (grammarSQL Postgres) fullTextVector(fulltext dbal.FullText) string {
+	columns := []string{}
+	for _, column := range fulltext.Columns {
+		columns = append(columns, grammarSQL.Wrap(column))
+	}
+	return grammarSQL.fullTextDocument(fulltext.Language, columns)
+}
+
+// fullTextQuery Make the tsquery of the search, the boolean mode uses the web search syntax
+func (grammarSQL Postgres) fullTextQuery(fulltext dbal.FullText, parameter string) string {
+	language := fulltext.Language
+	if language == "" {
+		language = FullTextLanguage
+	}
+	fn := "plainto_tsquery"
+	if fulltext.Mode == "boolean" {
+		fn = "websearch_to_tsquery"
+	}
+	return fmt.Sprintf("%s(%s, %s)", fn, grammarSQL.VAL(language), parameter)
+}
+
+// sqlAddFullTextIndex return the create full-text index sql
+func (grammarSQL Postgres) sqlAddFullTextIndex(name string, index *dbal.Index) string {
+	columns := []string{}
+	for _, column := range index.Columns {
+		columns = append(columns, grammarSQL.ID(column.Name))
+	}
+	return fmt.Sprintf(
+		"CREATE INDEX %s ON %s USING gin (%s)",
+		name, grammarSQL.ID(index.TableName), grammarSQL.fullTextDocument(FullTextLanguage, columns))
+}
+
+// getFullTextIndexListing get the full-text indexes of the table, the columns are parsed from the index definition
+func (grammarSQL Postgres) getFullTextIndexListing(dbName string, tableName string) ([]*dbal.Index, error) {
+	sql := fmt.Sprintf(`
+			SELECT n.nspname as db_name, t.relname as table_name, i.relname as index_name, pg_get_indexdef(ix.indexrelid) as definition
+			FROM
+				pg_class t,pg_class i,pg_index ix,pg_namespace as n,pg_am as am
+			WHERE
+				t.oid = ix.indrelid
+				and n.oid = t.relnamespace
+				and i.oid = ix.indexrelid
+				and am.oid = i.relam
+				and am.amname = 'gin'
+				and t.relkind = 'r'
+				and n.nspname = %s
+				and t.relname = %s
+			ORDER BY
+				i.relname
+			`,
+		grammarSQL.VAL(dbName),
+		grammarSQL.VAL(tableName),
+	)
+	defer log.Debug(sql)
+	rows := []struct {
+		DBName     string `db:"db_name"`
+		TableName  string `db:"table_name"`
+		Name       string `db:"index_name"`
+		Definition string `db:"definition"`
+	}{}
+	err := grammarSQL.Executor().Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := []*dbal.Index{}
+	for _, row := range rows {
+		if !strings.Contains(row.Definition, "to_tsvector(") {
+			continue
+		}
+		for i, match := range reFullTextColumn.FindAllStringSubmatch(row.Definition, -1) {
+			indexes = append(indexes, &dbal.Index{
+				DBName:     row.DBName,
+				TableName:  row.TableName,
+				ColumnName: match[1],
+				Name:       strings.TrimPrefix(row.Name, tableName+"_"),
+				SEQ:        i + 1,
+				Type:       "fulltext",
+				IndexType:  "GIN",
+			})
+		}
+	}
+	return indexes, nil
+}
@@ -23,7 +23,7 @@ func (grammarSQL Postgres) CompileInsertGetID(query *dbal.Query, columns []inter
 // ProcessInsertGetID Execute an insert and get ID statement and return the id
 func (grammarSQL Postgres) ProcessInsertGetID(sql string, bindings []interface{}, sequence string) (int64, error) {
 	var seq int64
-	err := grammarSQL.DB.Get(&seq, sql, bindings...)
+	err := grammarSQL.Executor().Get(&seq, sql, bindings...)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
+)
+
+// WhereJsonContains Compile a "where JSON contains" clause.
+func (grammarSQL Postgres) WhereJsonContains(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := sql.ParseJSONSelector(where.Column.(string))
+	value := grammarSQL.jsonParameter(where, bindingOffset)
+
+	not := ""
+	if where.Not {
+		not = "not "
+	}
+	return fmt.Sprintf("%s(%s)::jsonb @> %s::jsonb", not, grammarSQL.jsonSelector(column, path), value)
+}
+
+// WhereJsonLength Compile a "where JSON length" clause.
+func (grammarSQL Postgres) WhereJsonLength(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := sql.ParseJSONSelector(where.Column.(string))
+	value := grammarSQL.jsonParameter(where, bindingOffset)
+	return fmt.Sprintf("jsonb_array_length((%s)::jsonb) %s %s", grammarSQL.jsonSelector(column, path), where.Operator, value)
+}
+
+// WhereJsonContainsKey Compile a "where JSON contains key" clause.
+func (grammarSQL Postgres) WhereJsonContainsKey(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := sql.ParseJSONSelector(where.Column.(string))
+	key := path[len(path)-1]
+	parent := grammarSQL.jsonSelector(column, path[:len(path)-1])
+
+	not := ""
+	if where.Not {
+		not = "not "
+	}
+
+	if sql.IsJSONIndex(key) {
+		return fmt.Sprintf("%s(case when jsonb_typeof((%s)::jsonb) = 'array' then jsonb_array_length((%s)::jsonb) > %s else false end)", not, parent, parent, key)
+	}
+	return fmt.Sprintf("%scoalesce(jsonb_exists((%s)::jsonb, '%s'), false)", not, parent, key)
+}
+
+// WhereNested Compile a nested where clause.
+func (grammarSQL Postgres) WhereNested(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+
+	offset := 6 // - where
+	if query.IsJoinClause {
+		offset = 3 // - on
+	}
+
+	sql := grammarSQL.CompileWheres(where.Query, where.Query.Wheres, bindingOffset)
+	end := len(sql)
+	if end > offset {
+		sql = sql[offset:end]
+	}
+	return fmt.Sprintf("(%s)", sql)
+}
+
+// jsonSelector Wrap the JSON selector as a JSON value ("meta"->'address'->'city')
+func (grammarSQL Postgres) jsonSelector(column string, path []string) string {
+	return fmt.Sprintf("%s%s", grammarSQL.Wrap(column), jsonSegments(path, false))
+}
+
+// jsonParameter Get the parameter place-holder of the JSON where clause
+func (grammarSQL Postgres) jsonParameter(where dbal.Where, bindingOffset *int) string {
+	if dbal.IsExpression(where.Value) {
+		return where.Value.(dbal.Expression).GetValue()
+	}
+	*bindingOffset = *bindingOffset + where.Offset
+	return grammarSQL.Parameter(where.Value, *bindingOffset)
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path/filepath"
@@ -86,6 +87,24 @@ func (grammarSQL Postgres) NewWithRead(write *sqlx.DB, writeConfig *dbal.Config,
 	return grammarSQL, nil
 }
 
+// NewWithTx Create a new grammar interface, using the given *sqlx.Tx for executing statements.
+func (grammarSQL Postgres) NewWithTx(tx *sqlx.Tx) (dbal.Grammar, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("tx is nil")
+	}
+	grammarSQL.Tx = tx
+	return grammarSQL, nil
+}
+
+// NewWithContext Create a new grammar interface, using the given context for executing statements.
+func (grammarSQL Postgres) NewWithContext(ctx context.Context) (dbal.Grammar, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("ctx is nil")
+	}
+	grammarSQL.Ctx = ctx
+	return grammarSQL, nil
+}
+
 // New Create a new mysql grammar inteface
 func New(opts ...sql.Option) dbal.Grammar {
 	pg := Postgres{
@@ -94,9 +113,11 @@ func New(opts ...sql.Option) dbal.Grammar {
 	if pg.Driver == "" || pg.Driver == "sql" {
 		pg.Driver = "postgres"
 	}
+	pg.Materialized = true
 	pg.IndexTypes = map[string]string{
-		"unique": "UNIQUE INDEX",
-		"index":  "INDEX",
+		"unique":   "UNIQUE INDEX",
+		"index":    "INDEX",
+		"fulltext": "INDEX",
 	}
 
 	// overwrite types
@@ -54,6 +54,12 @@ func (quoter *Quoter) Wrap(value interface{}) string {
 		return val
 	case dbal.Name:
 		col := value.(dbal.Name)
+		if sql.IsJSONSelector(col.Name) {
+			if col.As() != "" {
+				return fmt.Sprintf("%s as %s", quoter.WrapJSONSelector(col.Name), quoter.ID(col.As()))
+			}
+			return quoter.WrapJSONSelector(col.Name)
+		}
 		if col.As() != "" {
 			return fmt.Sprintf("%s as %s", quoter.ID(col.Name), col.As())
 		}
@@ -65,12 +71,51 @@ func (quoter *Quoter) Wrap(value interface{}) string {
 		}
 		return fmt.Sprintf("%s ", col.SQL)
 	case string:
+		if sql.IsJSONSelector(v2) {
+			name := dbal.NewName(v2)
+			if name.As() != "" {
+				return fmt.Sprintf("%s as %s", quoter.WrapJSONSelector(name.Name), quoter.ID(name.As()))
+			}
+			return quoter.WrapJSONSelector(name.Name)
+		}
 		return quoter.WrapAliasedValue(value.(string))
 	default:
 		return fmt.Sprintf("%v", value)
 	}
 }
 
+// WrapJSONSelector Wrap the given JSON selector as a text value ("meta"->'address'->>'city').
+func (quoter *Quoter) WrapJSONSelector(selector string) string {
+	column, path := sql.ParseJSONSelector(selector)
+	return fmt.Sprintf("%s%s", quoter.WrapAliasedValue(column), jsonSegments(path, true))
+}
+
+// WrapJSONSet Wrap the given target to set the value of the JSON selector (jsonb_set("meta"::jsonb, '{"address","city"}', $1::jsonb)).
+func (quoter *Quoter) WrapJSONSet(target string, selector string, parameter string) string {
+	_, path := sql.ParseJSONSelector(selector)
+	keys := []string{}
+	for _, segment := range path {
+		keys = append(keys, fmt.Sprintf(`"%s"`, segment))
+	}
+	return fmt.Sprintf("jsonb_set(%s::jsonb, '{%s}', %s::jsonb)", target, strings.Join(keys, ","), parameter)
+}
+
+// jsonSegments Make the "->" operators of the JSON path, the last one is "->>" if the text is true
+func jsonSegments(path []string, text bool) string {
+	segments := ""
+	for i, segment := range path {
+		operator := "->"
+		if text && i == len(path)-1 {
+			operator = "->>"
+		}
+		if !sql.IsJSONIndex(segment) {
+			segment = fmt.Sprintf("'%s'", segment)
+		}
+		segments = fmt.Sprintf("%s%s%s", segments, operator, segment)
+	}
+	return segments
+}
+
 // WrapAliasedValue Wrap a value that has an alias.
 func (quoter *Quoter) WrapAliasedValue(value string) string {
 	if value == "*" {
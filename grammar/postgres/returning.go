@@ -0,0 +1,10 @@
+package postgres
+
+import (
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsReturning Determine if the given version of the database server supports the "returning" clause.
+func (grammarSQL Postgres) SupportsReturning(version *dbal.Version) bool {
+	return true
+}
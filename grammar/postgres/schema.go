@@ -13,10 +13,14 @@ import (
 
 // GetVersion get the version of the connection database
 func (grammarSQL Postgres) GetVersion() (*dbal.Version, error) {
+	if grammarSQL.Config != nil && grammarSQL.Config.Version != nil {
+		return grammarSQL.Config.Version, nil
+	}
+
 	sql := fmt.Sprintf("SELECT VERSION()")
 	// defer logger.Debug(logger.RETRIEVE, sql).TimeCost(time.Now())
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +76,7 @@ func (grammarSQL Postgres) GetTables() ([]string, error) {
 	)
 	defer log.Debug(sql)
 	tables := []string{}
-	err := grammarSQL.DB.Select(&tables, sql)
+	err := grammarSQL.Executor().Select(&tables, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -89,7 +93,7 @@ func (grammarSQL Postgres) TableExists(name string) (bool, error) {
 	)
 	defer log.Debug(sql)
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return false, err
 	}
@@ -112,7 +116,7 @@ func (grammarSQL Postgres) CreateType(table *dbal.Table, types map[string][]stri
 	END $$;
 	`, table.SchemaName, name, typ)
 		defer log.Debug(typeSQL)
-		_, err := grammarSQL.DB.Exec(typeSQL)
+		_, err := grammarSQL.Executor().Exec(typeSQL)
 		if err != nil {
 			return err
 		}
@@ -178,7 +182,7 @@ func (grammarSQL Postgres) CreateTable(table *dbal.Table, options ...dbal.Create
 
 	// Create table
 	defer log.Debug(sql)
-	_, err = grammarSQL.DB.Exec(sql)
+	_, err = grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -242,7 +246,7 @@ func (grammarSQL Postgres) createTableCreateIndex(table *dbal.Table, indexes []*
 	if len(indexStmts) > 0 {
 		sql := strings.Join(indexStmts, ";\n")
 		defer log.Debug(sql)
-		_, err := grammarSQL.DB.Exec(sql)
+		_, err := grammarSQL.Executor().Exec(sql)
 		return err
 	}
 	return nil
@@ -252,7 +256,7 @@ func (grammarSQL Postgres) createTableAddComment(table *dbal.Table, commentStmts
 	if len(commentStmts) > 0 {
 		sql := strings.Join(commentStmts, ";\n")
 		defer log.Debug(sql)
-		_, err := grammarSQL.DB.Exec(sql)
+		_, err := grammarSQL.Executor().Exec(sql)
 		return err
 	}
 	return nil
@@ -262,7 +266,7 @@ func (grammarSQL Postgres) createTableAddComment(table *dbal.Table, commentStmts
 func (grammarSQL Postgres) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -556,7 +560,7 @@ func (grammarSQL Postgres) alterTableRenameIndex(table *dbal.Table, command *dba
 
 // ExecSQL execute sql then update table structure
 func (grammarSQL Postgres) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -636,6 +640,10 @@ func (grammarSQL Postgres) SQLAlterIndex(index *dbal.Index) string {
 	}
 
 	name := quoter.ID(index.Name)
+	if index.Type == "fulltext" {
+		return grammarSQL.sqlAddFullTextIndex(name, index)
+	}
+
 	sql := fmt.Sprintf(
 		"CREATE %s %s ON %s (%s)",
 		typ, name, quoter.ID(index.TableName), strings.Join(columns, ","))
@@ -687,7 +695,7 @@ func (grammarSQL Postgres) GetIndexListing(dbName string, tableName string) ([]*
 	)
 	defer log.Debug(sql)
 	indexes := []*dbal.Index{}
-	err := grammarSQL.DB.Select(&indexes, sql)
+	err := grammarSQL.Executor().Select(&indexes, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -704,7 +712,13 @@ func (grammarSQL Postgres) GetIndexListing(dbName string, tableName string) ([]*
 		}
 		index.Name = strings.TrimPrefix(index.Name, tableName+"_")
 	}
-	return indexes, nil
+
+	// the full-text indexes are expression indexes, which are not listed above
+	fulltexts, err := grammarSQL.getFullTextIndexListing(dbName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	return append(indexes, fulltexts...), nil
 }
 
 // GetColumnListing get a table columns structure
@@ -752,7 +766,7 @@ func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]
 	)
 	defer log.Debug(sql)
 	columns := []*dbal.Column{}
-	err := grammarSQL.DB.Select(&columns, sql)
+	err := grammarSQL.Executor().Select(&columns, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -780,7 +794,7 @@ func (grammarSQL Postgres) GetColumnListing(dbName string, tableName string) ([]
 				column.Type = "enum"
 				if _, has := enumOptions[column.TypeName]; !has {
 					optionRange := []string{}
-					err := grammarSQL.DB.Select(&optionRange, fmt.Sprintf("select enum_range(null::%s.%s)", dbName, column.TypeName))
+					err := grammarSQL.Executor().Select(&optionRange, fmt.Sprintf("select enum_range(null::%s.%s)", dbName, column.TypeName))
 					if err != nil {
 						return nil, err
 					}
@@ -0,0 +1,31 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+)
+
+// CompileStatementTimeout Compile the SQL statement to limit the execution time of the statements of the current transaction,
+// the "set local" is reverted when the transaction ends, so the other sessions of the pool are not affected.
+func (grammarSQL Postgres) CompileStatementTimeout(timeout time.Duration) string {
+	return fmt.Sprintf("set local statement_timeout = %d", milliseconds(timeout))
+}
+
+// CompileCurrentStatementTimeout Compile the SQL statement to get the current limit of the execution time of the statements
+func (grammarSQL Postgres) CompileCurrentStatementTimeout() string {
+	return "select current_setting('statement_timeout')"
+}
+
+// CompileRestoreStatementTimeout Compile the SQL statement to restore the limit of the execution time of the statements
+// of the current transaction, the "set local" of the timed statement lasts until the transaction ends otherwise.
+func (grammarSQL Postgres) CompileRestoreStatementTimeout() string {
+	return "select set_config('statement_timeout', $1, true)"
+}
+
+// milliseconds Get the milliseconds of the timeout, at least 1
+func milliseconds(timeout time.Duration) int64 {
+	if ms := timeout.Milliseconds(); ms > 0 {
+		return ms
+	}
+	return 1
+}
@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeoutCompileStatementTimeout(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	assert.Equal(t, "set local statement_timeout = 2000", grammarSQL.CompileStatementTimeout(2*time.Second))
+	assert.Equal(t, "set local statement_timeout = 1", grammarSQL.CompileStatementTimeout(time.Microsecond))
+}
+
+func TestTimeoutCompileRestoreStatementTimeout(t *testing.T) {
+	grammarSQL := New()
+	assert.Equal(t, "select current_setting('statement_timeout')", grammarSQL.CompileCurrentStatementTimeout())
+	assert.Equal(t, "select set_config('statement_timeout', $1, true)", grammarSQL.CompileRestoreStatementTimeout())
+}
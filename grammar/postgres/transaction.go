@@ -0,0 +1,6 @@
+package postgres
+
+// SupportsTransactionalDDL Determine if the DDL statements can be rolled back within a transaction.
+func (grammarSQL Postgres) SupportsTransactionalDDL() bool {
+	return true
+}
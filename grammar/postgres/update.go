@@ -26,7 +26,7 @@ func (grammarSQL Postgres) Upsert(query *dbal.Query, values []xun.R, uniqueBy []
 
 	sql, bindings := grammarSQL.CompileUpsert(query, columns, insertValues, uniqueBy, updateValues)
 	defer log.Debug(sql)
-	return grammarSQL.DB.Exec(sql, bindings...)
+	return grammarSQL.Executor().Exec(sql, bindings...)
 }
 
 // CompileUpsert Upsert new records or update the existing ones.
@@ -66,6 +66,10 @@ func (grammarSQL Postgres) CompileUpsert(query *dbal.Query, columns []interface{
 // CompileUpdate Compile an update statement into SQL.
 func (grammarSQL Postgres) CompileUpdate(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
 
+	if grammarSQL.IsUpdateFrom(query) {
+		return grammarSQL.compileUpdateFrom(query, values)
+	}
+
 	if len(query.Joins) == 0 && query.Limit < 0 {
 		return grammarSQL.SQL.CompileUpdate(query, values)
 	}
@@ -77,12 +81,7 @@ func (grammarSQL Postgres) CompileUpdate(query *dbal.Query, values map[string]in
 	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, values, &offset)
 	bindings = append(bindings, columnsBindings...)
 
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.ctid", alias)}
-	} else {
-		query.Columns = []interface{}{"ctid"}
-	}
+	query.Columns = []interface{}{fmt.Sprintf("%s.ctid", grammarSQL.FromReference(query.From))}
 
 	selectSQL := grammarSQL.CompileSelectOffset(query, &offset)
 
@@ -91,3 +90,105 @@ func (grammarSQL Postgres) CompileUpdate(query *dbal.Query, values map[string]in
 
 	return sql, bindings
 }
+
+// compileUpdateFrom Compile an "update ... from" statement, the joined tables are moved to the "from" clause
+// and the join conditions are moved to the "where" clause.
+//
+//	update "users" set "vote"="scores"."vote" from "scores" where "users"."id" = "scores"."user_id" and ("scores"."vote" > $1)
+func (grammarSQL Postgres) compileUpdateFrom(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
+	offset := 0
+	bindings := []interface{}{}
+	table := grammarSQL.WrapTable(query.From)
+
+	with := grammarSQL.CompileWith(query, &offset)
+	bindings = append(bindings, query.GetBindings("with")...)
+
+	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, grammarSQL.UpdateFromValues(values), &offset)
+	bindings = append(bindings, columnsBindings...)
+
+	tables, conditions, joinBindings := grammarSQL.CompileFromJoins(query, &offset, grammarSQL.CompileWheres)
+	bindings = append(bindings, joinBindings...)
+
+	sql := fmt.Sprintf("update %s set %s from %s", table, columns, tables)
+	if conditions != "" {
+		sql = fmt.Sprintf("%s where %s", sql, conditions)
+	}
+	if with != "" {
+		sql = fmt.Sprintf("%s %s", with, sql)
+	}
+	return sql, bindings
+}
+
+// SupportsUpdateBatchValues Determine if the given version of the database server supports the "values" strategy of the batch updates.
+func (grammarSQL Postgres) SupportsUpdateBatchValues(version *dbal.Version) bool {
+	return true
+}
+
+// CompileUpdateBatch Compile a batch update statement into SQL, the "values" strategy joins the rows as a derived table,
+// the "case" strategy is the default one.
+//
+//	update "users" set "vote"="batch"."c1" from (select "id", "vote" from "users" where false union all values ($1, $2), ($3, $4)) as "batch"("c0", "c1") where "users"."id" = "batch"."c0"
+func (grammarSQL Postgres) CompileUpdateBatch(query *dbal.Query, key string, columns []string, values []map[string]interface{}, strategy string) (string, []interface{}) {
+	offset := 0
+	table := grammarSQL.WrapTable(query.From)
+
+	sql := ""
+	bindings := []interface{}{}
+	switch strategy {
+	case "values":
+		sql, bindings = grammarSQL.compileUpdateBatchValues(query, key, columns, values, &offset)
+	case "", "case":
+		sets, keys, casesBindings := grammarSQL.CompileUpdateBatchCases(key, columns, values, &offset)
+		sql = fmt.Sprintf("update %s set %s where %s in (%s)", table, sets, grammarSQL.Wrap(key), keys)
+		bindings = casesBindings
+	default:
+		panic(fmt.Errorf("the %s strategy of the batch update is not supported by %s", strategy, grammarSQL.Driver))
+	}
+
+	if len(query.Wheres) > 0 {
+		wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
+		sql = fmt.Sprintf("%s and (%s)", sql, strings.TrimPrefix(wheres, "where "))
+		bindings = append(bindings, query.GetBindings("where")...)
+	}
+	return sql, bindings
+}
+
+// compileUpdateBatchValues Compile the "update ... from (values ...)" statement of a batch update, every row must have all the columns.
+// The empty select of the table gives the place-holders the types of the columns, and the columns of the derived table
+// are renamed by position so they do not shadow the columns of the updated table.
+func (grammarSQL Postgres) compileUpdateBatchValues(query *dbal.Query, key string, columns []string, values []map[string]interface{}, offset *int) (string, []interface{}) {
+	bindings := []interface{}{}
+	names := []interface{}{key}
+	aliases := []string{grammarSQL.ID("c0")}
+	sets := []string{}
+	for i, column := range columns {
+		names = append(names, column)
+		alias := fmt.Sprintf("c%d", i+1)
+		aliases = append(aliases, grammarSQL.ID(alias))
+		sets = append(sets, fmt.Sprintf("%s=%s.%s", grammarSQL.Wrap(column), grammarSQL.ID("batch"), grammarSQL.ID(alias)))
+	}
+
+	rows := []string{}
+	for _, row := range values {
+		params := []string{}
+		for _, name := range names {
+			value := row[name.(string)]
+			params = append(params, grammarSQL.Parameter(value, *offset+1))
+			if !dbal.IsExpression(value) {
+				bindings = append(bindings, value)
+				*offset++
+			}
+		}
+		rows = append(rows, fmt.Sprintf("(%s)", strings.Join(params, ", ")))
+	}
+
+	table := grammarSQL.WrapTable(query.From)
+	sql := fmt.Sprintf(
+		"update %s set %s from (select %s from %s where false union all values %s) as %s(%s) where %s = %s.%s",
+		table, strings.Join(sets, ", "),
+		grammarSQL.Columnize(names), table, strings.Join(rows, ", "),
+		grammarSQL.ID("batch"), strings.Join(aliases, ", "),
+		grammarSQL.Wrap(fmt.Sprintf("%s.%s", grammarSQL.FromReference(query.From), key)), grammarSQL.ID("batch"), grammarSQL.ID("c0"),
+	)
+	return sql, bindings
+}
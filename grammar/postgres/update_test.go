@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestUpdateCompileUpdateFrom(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := newUpdateJoinQueryForTest()
+	sql, bindings := grammarSQL.CompileUpdate(query, map[string]interface{}{
+		"users.vote": dbal.Raw(`"scores"."vote"`),
+	})
+	assert.Equal(t, `update "users" set "vote"="scores"."vote" from "scores" where "users"."id" = "scores"."user_id" and ("scores"."vote" > $1)`, sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestUpdateCompileUpdateFromWithValues(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := newUpdateJoinQueryForTest()
+	sql, bindings := grammarSQL.CompileUpdate(query, map[string]interface{}{"status": "DONE"})
+	assert.Equal(t, `update "users" set "status"=$1 from "scores" where "users"."id" = "scores"."user_id" and ("scores"."vote" > $2)`, sql)
+	assert.Equal(t, []interface{}{"DONE", 10}, bindings)
+}
+
+func TestUpdateCompileUpdateLeftJoin(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := newUpdateJoinQueryForTest()
+	query.Joins[0].Type = "left"
+	sql, bindings := grammarSQL.CompileUpdate(query, map[string]interface{}{"status": "DONE"})
+	assert.Equal(t, `update "users" set "status"=$1 where "ctid" in (select "users"."ctid" from "users" left join "scores" on "users"."id" = "scores"."user_id" where "scores"."vote" > $2)`, sql)
+	assert.Equal(t, []interface{}{"DONE", 10}, bindings)
+
+	query = newUpdateJoinQueryForTest()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users as u"), Alias: "u"}
+	query.Joins[0].Type = "left"
+	sql, _ = grammarSQL.CompileUpdate(query, map[string]interface{}{"status": "DONE"})
+	assert.Equal(t, `update "users" as "u" set "status"=$1 where "ctid" in (select "u"."ctid" from "users" as "u" left join "scores" on "users"."id" = "scores"."user_id" where "scores"."vote" > $2)`, sql)
+}
+
+func TestUpdateCompileUpdateBatchValues(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "status", Operator: "=", Value: "DONE", Boolean: "and", Offset: 1}}
+	query.AddBinding("where", "DONE")
+	sql, bindings := grammarSQL.CompileUpdateBatch(query, "id", []string{"vote"}, []map[string]interface{}{
+		{"id": 1, "vote": 10},
+		{"id": 2, "vote": 20},
+	}, "values")
+	assert.Equal(t, `update "users" set "vote"="batch"."c1" from (select "id", "vote" from "users" where false union all values ($1, $2), ($3, $4)) as "batch"("c0", "c1") where "users"."id" = "batch"."c0" and ("status" = $5)`, sql)
+	assert.Equal(t, []interface{}{1, 10, 2, 20, "DONE"}, bindings)
+}
+
+func TestUpdateCompileUpdateBatchCase(t *testing.T) {
+	grammarSQL := New().(Postgres)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	sql, bindings := grammarSQL.CompileUpdateBatch(query, "id", []string{"vote"}, []map[string]interface{}{
+		{"id": 1, "vote": 10},
+		{"id": 2, "vote": 20},
+	}, "")
+	assert.Equal(t, `update "users" set "vote"=case "id" when $1 then $2 when $3 then $4 else "vote" end where "id" in ($5, $6)`, sql)
+	assert.Equal(t, []interface{}{1, 10, 2, 20, 1, 2}, bindings)
+}
+
+func newUpdateJoinQueryForTest() *dbal.Query {
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	on := dbal.NewQuery()
+	on.IsJoinClause = true
+	on.Wheres = []dbal.Where{{Type: "column", First: "users.id", Operator: "=", Second: "scores.user_id", Boolean: "and"}}
+	query.Joins = []dbal.Join{{Type: "inner", Name: dbal.NewName("scores"), Query: on}}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "scores.vote", Operator: ">", Value: 10, Boolean: "and", Offset: 1}}
+	query.AddBinding("where", 10)
+	return query
+}
@@ -32,6 +32,12 @@ func (grammarSQL Hdb) CompileDelete(query *dbal.Query) (string, []interface{}) {
 	return sql, bindings
 }
 
+// SupportsDeleteJoinOrderLimit Determine if the joined deletes could be ordered and limited,
+// the rows of the joined deletes are matched by a subquery.
+func (grammarSQL Hdb) SupportsDeleteJoinOrderLimit() bool {
+	return true
+}
+
 // CompileTruncate Compile a truncate table statement into SQL.
 func (grammarSQL Hdb) CompileTruncate(query *dbal.Query) ([]string, [][]interface{}) {
 	sql := fmt.Sprintf("truncate table %s", grammarSQL.WrapTable(query.From))
@@ -0,0 +1,60 @@
+package saphdb
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// TranslateError Translate the error of the HANA driver into a *dbal.Error by the error code,
+// the message is checked if the error has no code.
+func (grammarSQL Hdb) TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var kind error
+	var coder interface{ Code() int }
+	if errors.As(err, &coder) {
+		switch coder.Code() {
+		case 301:
+			kind = dbal.ErrUniqueViolation
+		case 461, 462:
+			kind = dbal.ErrForeignKeyViolation
+		case 287:
+			kind = dbal.ErrNotNullViolation
+		case 133:
+			kind = dbal.ErrDeadlock
+		case 131, 146:
+			kind = dbal.ErrLockTimeout
+		case 139:
+			kind = dbal.ErrQueryCanceled
+		}
+	} else {
+		message := strings.ToLower(err.Error())
+		switch {
+		case strings.Contains(message, "unique constraint violated"):
+			kind = dbal.ErrUniqueViolation
+		case strings.Contains(message, "foreign key constraint violation"):
+			kind = dbal.ErrForeignKeyViolation
+		case strings.Contains(message, "cannot insert null"):
+			kind = dbal.ErrNotNullViolation
+		case strings.Contains(message, "deadlock"):
+			kind = dbal.ErrDeadlock
+		case strings.Contains(message, "lock wait timeout"):
+			kind = dbal.ErrLockTimeout
+		}
+	}
+
+	if kind == nil {
+		return grammarSQL.SQL.TranslateError(err)
+	}
+
+	// cannot insert NULL or update to NULL: NAME
+	dbErr := dbal.NewError(kind, err)
+	if idx := strings.LastIndex(err.Error(), "NULL: "); kind == dbal.ErrNotNullViolation && idx >= 0 {
+		dbErr.Column = strings.TrimSpace(err.Error()[idx+len("NULL: "):])
+	}
+	return dbErr
+}
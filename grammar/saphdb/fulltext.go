@@ -0,0 +1,10 @@
+package saphdb
+
+import (
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsFullText Determine if the given version of the database server supports the full-text search.
+func (grammarSQL Hdb) SupportsFullText(version *dbal.Version) bool {
+	return false
+}
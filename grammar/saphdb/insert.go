@@ -26,26 +26,26 @@ func (grammarSQL Hdb) CompileInsertGetID(query *dbal.Query, columns []interface{
 // ProcessInsertGetID Execute an insert and get ID statement and return the id
 func (grammarSQL Hdb) ProcessInsertGetID(sql string, bindings []interface{}, sequence string) (int64, error) {
 	// var seq int64
-	// err := grammarSQL.DB.Get(&seq, sql, bindings...)
+	// err := grammarSQL.Executor().Get(&seq, sql, bindings...)
 	// if err != nil {
 	// 	return 0, err
 	// }
 	// return seq, nil
 
-	stmt, err := grammarSQL.DB.Prepare(sql)
+	stmt, err := grammarSQL.Executor().Prepare(sql)
 	if err != nil {
 		return 0, err
 	}
 
 	defer stmt.Close()
 	// res, err := stmt.Exec(bindings...)
-	_, err = utils.StmtExec(stmt, bindings)
+	_, err = utils.StmtExecContext(grammarSQL.Context(), stmt, bindings)
 	if err != nil {
 		return 0, err
 	}
 
 	rows := []int64{}
-	err = grammarSQL.DB.Select(&rows, "select current_identity_value() FROM DUMMY;")
+	err = grammarSQL.Executor().Select(&rows, "select current_identity_value() FROM DUMMY;")
 	if err != nil {
 		return 0, err
 	}
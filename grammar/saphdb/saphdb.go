@@ -1,6 +1,7 @@
 package saphdb
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path/filepath"
@@ -70,6 +71,24 @@ func (grammarSQL Hdb) NewWithRead(write *sqlx.DB, writeConfig *dbal.Config, read
 	return grammarSQL, nil
 }
 
+// NewWithTx Create a new grammar interface, using the given *sqlx.Tx for executing statements.
+func (grammarSQL Hdb) NewWithTx(tx *sqlx.Tx) (dbal.Grammar, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("tx is nil")
+	}
+	grammarSQL.Tx = tx
+	return grammarSQL, nil
+}
+
+// NewWithContext Create a new grammar interface, using the given context for executing statements.
+func (grammarSQL Hdb) NewWithContext(ctx context.Context) (dbal.Grammar, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("ctx is nil")
+	}
+	grammarSQL.Ctx = ctx
+	return grammarSQL, nil
+}
+
 func New(opts ...sql.Option) dbal.Grammar {
 // func New() dbal.Grammar {
 	hdb := Hdb{
@@ -77,8 +96,9 @@ func New(opts ...sql.Option) dbal.Grammar {
 	}
 	hdb.Driver = "hdb"
 	hdb.IndexTypes = map[string]string{
-		"unique": "UNIQUE INDEX",
-		"index":  "INDEX",
+		"unique":   "UNIQUE INDEX",
+		"index":    "INDEX",
+		"fulltext": "INDEX",
 	}
 	// overwrite types
 	types := hdb.SQL.Types
@@ -117,6 +137,11 @@ func New(opts ...sql.Option) dbal.Grammar {
 	return hdb
 }
 
+// GetMaxBindings get the maximum number of the bindings of one statement
+func (grammarSQL Hdb) GetMaxBindings() int {
+	return 32767
+}
+
 // GetOperators get the operators
 func (grammarSQL Hdb) GetOperators() []string {
 	return []string{
@@ -13,10 +13,14 @@ import (
 
 // GetVersion get the version of the connection database
 func (grammarSQL Hdb) GetVersion() (*dbal.Version, error) {
+	if grammarSQL.Config != nil && grammarSQL.Config.Version != nil {
+		return grammarSQL.Config.Version, nil
+	}
+
 	sql := fmt.Sprintf("select VERSION  from \"SYS\".\"M_DATABASE\";")
 	// defer logger.Debug(logger.RETRIEVE, sql).TimeCost(time.Now())
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +76,7 @@ func (grammarSQL Hdb) GetTables() ([]string, error) {
 	)
 	defer log.Debug(sql)
 	tables := []string{}
-	err := grammarSQL.DB.Select(&tables, sql)
+	err := grammarSQL.Executor().Select(&tables, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -96,7 +100,7 @@ func (grammarSQL Hdb) TableExists(name string) (bool, error) {
 	)
 	defer log.Debug(sql)
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return false, err
 	}
@@ -119,7 +123,7 @@ func (grammarSQL Hdb) CreateType(table *dbal.Table, types map[string][]string) e
 	// END $$;
 	// `, table.SchemaName, name, typ)
 	// 	defer log.Debug(typeSQL)
-	// 	_, err := grammarSQL.DB.Exec(typeSQL)
+	// 	_, err := grammarSQL.Executor().Exec(typeSQL)
 	// 	if err != nil {
 	// 		return err
 	// 	}
@@ -186,7 +190,7 @@ func (grammarSQL Hdb) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 
 	// Create table
 	defer log.Debug(sql)
-	_, err = grammarSQL.DB.Exec(sql)
+	_, err = grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -251,14 +255,14 @@ func (grammarSQL Hdb) createTableCreateIndex(table *dbal.Table, indexes []*dbal.
 		// sql := strings.Join(indexStmts, ";\n")
 		for _, sql := range indexStmts {
 			defer log.Debug(sql)
-			_, err := grammarSQL.DB.Exec(sql)
+			_, err := grammarSQL.Executor().Exec(sql)
 			if err != nil {
 				return err
 			}
 
 		}
 		// defer log.Debug(sql)
-		// _, err := grammarSQL.DB.Exec(sql)
+		// _, err := grammarSQL.Executor().Exec(sql)
 
 	}
 	return nil
@@ -268,12 +272,12 @@ func (grammarSQL Hdb) createTableAddComment(table *dbal.Table, commentStmts []st
 	if len(commentStmts) > 0 {
 		// sql := strings.Join(commentStmts, ";\n")
 		// defer log.Debug(sql)
-		// _, err := grammarSQL.DB.Exec(sql)
+		// _, err := grammarSQL.Executor().Exec(sql)
 		// return err
 
 		for _, sql := range commentStmts {
 			defer log.Debug(sql)
-			_, err := grammarSQL.DB.Exec(sql)
+			_, err := grammarSQL.Executor().Exec(sql)
 			if err != nil {
 				return err
 			}
@@ -286,7 +290,7 @@ func (grammarSQL Hdb) createTableAddComment(table *dbal.Table, commentStmts []st
 func (grammarSQL Hdb) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("RENAME TABLE %s TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -587,7 +591,7 @@ func (grammarSQL Hdb) alterTableRenameIndex(table *dbal.Table, command *dbal.Com
 
 // ExecSQL execute sql then update table structure
 func (grammarSQL Hdb) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -720,7 +724,7 @@ func (grammarSQL Hdb) GetIndexListing(dbName string, tableName string) ([]*dbal.
 	)
 	defer log.Debug(sql)
 	indexes := []*dbal.Index{}
-	err := grammarSQL.DB.Select(&indexes, sql)
+	err := grammarSQL.Executor().Select(&indexes, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -783,7 +787,7 @@ func (grammarSQL Hdb) GetColumnListing(dbName string, tableName string) ([]*dbal
 	)
 	defer log.Debug(sql)
 	columns := []*dbal.Column{}
-	err := grammarSQL.DB.Select(&columns, sql)
+	err := grammarSQL.Executor().Select(&columns, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -818,7 +822,7 @@ func (grammarSQL Hdb) GetColumnListing(dbName string, tableName string) ([]*dbal
 				column.Type = "enum"
 				if _, has := enumOptions[column.TypeName]; !has {
 					optionRange := []string{}
-					err := grammarSQL.DB.Select(&optionRange, fmt.Sprintf("select enum_range(null::%s.%s)", dbName, column.TypeName))
+					err := grammarSQL.Executor().Select(&optionRange, fmt.Sprintf("select enum_range(null::%s.%s)", dbName, column.TypeName))
 					if err != nil {
 						return nil, err
 					}
@@ -841,7 +845,7 @@ func (grammarSQL Hdb) GetColumnListing(dbName string, tableName string) ([]*dbal
 func (grammarSQL Hdb) DropTable(name string) error {
 	sql := fmt.Sprintf("DROP TABLE %s CASCADE", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -849,6 +853,6 @@ func (grammarSQL Hdb) DropTable(name string) error {
 func (grammarSQL Hdb) DropTableIfExists(name string) error {
 	sql := fmt.Sprintf("DROP TABLE %s CASCADE", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	grammarSQL.DB.Exec(sql)
+	grammarSQL.Executor().Exec(sql)
 	return nil
 }
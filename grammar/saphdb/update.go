@@ -24,7 +24,7 @@ func (grammarSQL Hdb) Upsert(query *dbal.Query, values []xun.R, uniqueBy []inter
 
 	sql, bindings := grammarSQL.CompileUpsert(query, columns, insertValues, uniqueBy, updateValues)
 	defer log.Debug(sql)
-	return grammarSQL.DB.Exec(sql, bindings...)
+	return grammarSQL.Executor().Exec(sql, bindings...)
 }
 
 // CompileUpsert Upsert new records or update the existing ones.
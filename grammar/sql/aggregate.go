@@ -0,0 +1,48 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// CompileAggregateFunctions Replace the aggregate function columns with the compiled raw expressions.
+func (grammarSQL SQL) CompileAggregateFunctions(columns []interface{}) []interface{} {
+	compiled := []interface{}{}
+	for _, col := range columns {
+		fn, ok := col.(dbal.AggregateFunction)
+		if !ok {
+			compiled = append(compiled, col)
+			continue
+		}
+		compiled = append(compiled, dbal.Raw(grammarSQL.CompileAggregateFunction(fn)))
+	}
+	return compiled
+}
+
+// CompileAggregateFunction Compile an aggregate function column into SQL.
+//
+//	count(distinct `email`) as `total`
+func (grammarSQL SQL) CompileAggregateFunction(fn dbal.AggregateFunction) string {
+	column := grammarSQL.Wrap(fn.Column)
+	if fn.Distinct {
+		column = fmt.Sprintf("distinct %s", column)
+	}
+
+	sql := fmt.Sprintf("%s(%s)", fn.Func, column)
+	if fn.Alias != "" {
+		sql = fmt.Sprintf("%s as %s", sql, grammarSQL.ID(fn.Alias))
+	}
+	return sql
+}
+
+// WrapHavingColumn Wrap the column of the having clause, the aggregate function is compiled without the alias.
+//
+//	having count(*) > ?
+func (grammarSQL SQL) WrapHavingColumn(column interface{}) string {
+	if fn, ok := column.(dbal.AggregateFunction); ok {
+		fn.Alias = ""
+		return grammarSQL.CompileAggregateFunction(fn)
+	}
+	return grammarSQL.Wrap(column)
+}
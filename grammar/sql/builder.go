@@ -122,7 +122,7 @@ func (grammarSQL SQL) SQLAddIndex(index *dbal.Index) string {
 	// UNIQUE KEY `unionid` (`unionid`) COMMENT 'xxxx'
 	columns := []string{}
 	for _, column := range index.Columns {
-		if column.Type == "text" || column.Type == "mediumText" || column.Type == "longText" {
+		if index.Type != "fulltext" && (column.Type == "text" || column.Type == "mediumText" || column.Type == "longText") {
 			columns = append(columns, fmt.Sprintf("%s(%d)", quoter.ID(column.Name), maxKeyLength))
 		} else if column.Type == "json" || column.Type == "jsonb" { // ignore json and jsonb
 			continue
@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yaoapp/kun/log"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// BulkLoad Load the rows into the table by executing a prepared insert statement for each row,
+// it should be called within a transaction. It returns the number of the loaded rows.
+func (grammarSQL SQL) BulkLoad(query *dbal.Query, columns []string, rows dbal.RowIterator) (int64, error) {
+	names := []interface{}{}
+	params := []string{}
+	for i, column := range columns {
+		names = append(names, column)
+		params = append(params, grammarSQL.Parameter(nil, i+1))
+	}
+
+	sql := fmt.Sprintf("insert into %s (%s) values (%s)", grammarSQL.WrapTable(query.From), grammarSQL.Columnize(names), strings.Join(params, ", "))
+	defer log.Debug(sql)
+
+	stmt, err := grammarSQL.Executor().Prepare(sql)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var loaded int64 = 0
+	for {
+		row, err := rows.Next()
+		if err == io.EOF {
+			return loaded, nil
+		}
+		if err != nil {
+			return loaded, err
+		}
+
+		_, err = stmt.ExecContext(grammarSQL.Context(), row...)
+		if err != nil {
+			return loaded, err
+		}
+		loaded++
+	}
+}
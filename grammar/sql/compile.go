@@ -46,6 +46,7 @@ func (grammarSQL SQL) CompileSelectOffset(query *dbal.Query, offset *int) string
 	// To compile the query, we'll spin through each component of the query and
 	// see if that component exists. If it does we'll just call the compiler
 	// function for the component which is responsible for making the SQL.
+	sqls["with"] = grammarSQL.CompileWith(query, offset)
 	sqls["aggregate"] = grammarSQL.CompileAggregate(query, query.Aggregate)
 	sqls["columns"] = grammarSQL.CompileColumns(query, query.Columns, offset)
 	sqls["from"] = grammarSQL.CompileFrom(query, query.From, offset)
@@ -53,13 +54,14 @@ func (grammarSQL SQL) CompileSelectOffset(query *dbal.Query, offset *int) string
 	sqls["wheres"] = grammarSQL.CompileWheres(query, query.Wheres, offset)
 	sqls["groups"] = grammarSQL.CompileGroups(query, query.Groups, offset)
 	sqls["havings"] = grammarSQL.CompileHavings(query, query.Havings, offset)
+	sqls["windows"] = grammarSQL.CompileWindows(query, query.Windows)
 	sqls["orders"] = grammarSQL.CompileOrders(query, query.Orders, offset)
 	sqls["limit"] = grammarSQL.CompileLimit(query, query.Limit, offset)
 	sqls["offset"] = grammarSQL.CompileOffset(query, query.Offset)
 	sqls["lock"] = grammarSQL.CompileLock(query, query.Lock)
 
 	sql := ""
-	for _, name := range []string{"aggregate", "columns", "from", "joins", "wheres", "groups", "havings", "orders", "limit", "offset", "lock"} {
+	for _, name := range []string{"with", "aggregate", "columns", "from", "joins", "wheres", "groups", "havings", "windows", "orders", "limit", "offset", "lock"} {
 		segment, has := sqls[name]
 		if has && segment != "" {
 			sql = sql + segment + " "
@@ -76,6 +78,63 @@ func (grammarSQL SQL) CompileSelectOffset(query *dbal.Query, offset *int) string
 	return strings.Trim(sql, " ")
 }
 
+// CompileWith Compile the common table expressions of the query, the bindings of
+// the common table expressions are placed before all of the other bindings.
+func (grammarSQL SQL) CompileWith(query *dbal.Query, bindingOffset *int) string {
+	if len(query.CTEs) == 0 {
+		return ""
+	}
+
+	recursive := ""
+	ctes := []string{}
+	for _, cte := range query.CTEs {
+		if cte.Recursive {
+			recursive = "recursive "
+		}
+
+		sql := cte.SQL
+		if cte.Query != nil {
+			offset := *bindingOffset
+			sql = grammarSQL.compileCTEQuery(cte, &offset)
+		}
+		*bindingOffset = *bindingOffset + cte.Offset
+
+		name := grammarSQL.ID(cte.Name)
+		if len(cte.Columns) > 0 {
+			name = fmt.Sprintf("%s (%s)", name, grammarSQL.Columnize(cte.Columns))
+		}
+
+		materialized := ""
+		if grammarSQL.Materialized && cte.Materialized != "" {
+			materialized = cte.Materialized + " "
+		}
+
+		ctes = append(ctes, fmt.Sprintf("%s as %s(%s)", name, materialized, sql))
+	}
+
+	return fmt.Sprintf("with %s%s", recursive, strings.Join(ctes, ", "))
+}
+
+// compileCTEQuery Compile the query of a common table expression. The union members of a recursive
+// expression are not wrapped, the recursive term must reference the expression directly.
+func (grammarSQL SQL) compileCTEQuery(cte dbal.CTE, offset *int) string {
+	if !cte.Recursive || len(cte.Query.Unions) == 0 {
+		return grammarSQL.CompileSelectOffset(cte.Query, offset)
+	}
+
+	anchor := *cte.Query
+	anchor.Unions = []dbal.Union{}
+	sql := grammarSQL.CompileSelectOffset(&anchor, offset)
+	for _, union := range cte.Query.Unions {
+		conjunction := "union"
+		if union.All {
+			conjunction = "union all"
+		}
+		sql = fmt.Sprintf("%s %s %s", sql, conjunction, grammarSQL.CompileSelectOffset(union.Query, offset))
+	}
+	return sql
+}
+
 // CompileExists Compile an exists statement into SQL.
 func (grammarSQL SQL) CompileExists(query *dbal.Query) string {
 	sql := grammarSQL.CompileSelect(query)
@@ -208,14 +267,8 @@ func (grammarSQL SQL) CompileColumns(query *dbal.Query, columns []interface{}, b
 		sql = "select distinct"
 	}
 
-	sql = fmt.Sprintf("%s %s", sql, grammarSQL.Columnize(columns))
-	for _, col := range columns {
-		switch col.(type) {
-		case dbal.Select:
-			*bindingOffset = *bindingOffset + col.(dbal.Select).Offset
-		}
-	}
-
+	columns = grammarSQL.CompileFullTextColumns(columns, bindingOffset, grammarSQL.CompileFullTextRank)
+	sql = fmt.Sprintf("%s %s", sql, grammarSQL.Columnize(grammarSQL.CompileWindowFunctions(grammarSQL.CompileAggregateFunctions(columns))))
 	return sql
 }
 
@@ -279,7 +332,7 @@ func (grammarSQL SQL) HavingBasic(query *dbal.Query, having dbal.Having, binding
 	if !dbal.IsExpression(having.Value) {
 		*bindingOffset = *bindingOffset + having.Offset
 	}
-	column := grammarSQL.Wrap(having.Column)
+	column := grammarSQL.WrapHavingColumn(having.Column)
 	parameter := grammarSQL.Parameter(having.Value, *bindingOffset)
 
 	return fmt.Sprintf("%s %s %s %s", having.Boolean, column, having.Operator, parameter)
@@ -294,7 +347,7 @@ func (grammarSQL SQL) HavingBetween(query *dbal.Query, having dbal.Having, bindi
 	if having.Not {
 		between = "not between"
 	}
-	column := grammarSQL.Wrap(having.Column)
+	column := grammarSQL.WrapHavingColumn(having.Column)
 	if !dbal.IsExpression(having.Values[0]) {
 		*bindingOffset = *bindingOffset + having.Offset
 	}
@@ -317,6 +370,7 @@ func (grammarSQL SQL) CompileOrders(query *dbal.Query, orders []dbal.Order, bind
 	}
 
 	clauses := []string{}
+	orders = grammarSQL.CompileFullTextOrders(orders, bindingOffset, grammarSQL.CompileFullTextRank)
 	for _, order := range orders {
 		if order.SQL != "" {
 			clauses = append(clauses, order.SQL)
@@ -7,33 +7,64 @@ import (
 	"github.com/yaoapp/xun/dbal"
 )
 
+// SupportsDeleteJoinOrderLimit Determine if the joined deletes could be ordered and limited,
+// the multiple-table deletes of MySQL don't accept the order and limit clauses.
+func (grammarSQL SQL) SupportsDeleteJoinOrderLimit() bool {
+	return false
+}
+
 // CompileDelete Compile a delete statement into SQL.
+// The joined deletes compile to "delete t from t join ...", the order and limit are only available for the single table deletes.
 func (grammarSQL SQL) CompileDelete(query *dbal.Query) (string, []interface{}) {
 
 	offset := 0
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
-	alias := ""
 
-	joins := ""
+	with := grammarSQL.CompileWith(query, &offset)
+	bindings = append(bindings, query.GetBindings("with")...)
+
 	if len(query.Joins) > 0 {
-		joins = grammarSQL.CompileJoins(query, query.Joins, &offset)
-		bindings = append(bindings, query.GetBindings("join")...)
-		offset = len(bindings)
+		if len(query.Orders) > 0 || query.Limit >= 0 {
+			panic(fmt.Errorf("the joined delete with the order or the limit is not supported by %s", grammarSQL.Driver))
+		}
+
+		alias := table
 		tableArr := strings.Split(table, " as ")
-		if len(tableArr) >= 1 {
+		if len(tableArr) > 1 {
 			alias = tableArr[1]
 		}
+
+		joins := grammarSQL.CompileJoins(query, query.Joins, &offset)
+		bindings = append(bindings, query.GetBindings("join")...)
+		offset = len(bindings)
+
+		wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
+		bindings = append(bindings, query.GetBindings("where")...)
+
+		sql := strings.TrimSpace(fmt.Sprintf("delete %s from %s %s %s", alias, table, joins, wheres))
+		if with != "" {
+			sql = fmt.Sprintf("%s %s", with, sql)
+		}
+		return sql, bindings
 	}
 
 	wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
 	bindings = append(bindings, query.GetBindings("where")...)
 
-	if len(query.Joins) > 0 {
-		return fmt.Sprintf("delete %s from %s %s %s", alias, table, joins, wheres), bindings
+	sql := fmt.Sprintf("delete from %s %s", table, wheres)
+	if len(query.Orders) > 0 {
+		sql = fmt.Sprintf("%s %s", sql, grammarSQL.CompileOrders(query, query.Orders, &offset))
+		bindings = append(bindings, query.GetBindings("order")...)
+	}
+	if query.Limit >= 0 {
+		sql = fmt.Sprintf("%s %s", sql, grammarSQL.CompileLimit(query, query.Limit, &offset))
 	}
 
-	return fmt.Sprintf("delete from %s %s", table, wheres), bindings
+	if with != "" {
+		sql = fmt.Sprintf("%s %s", with, sql)
+	}
+	return strings.TrimSpace(sql), bindings
 }
 
 // CompileTruncate Compile a truncate table statement into SQL.
@@ -0,0 +1,35 @@
+package sql
+
+import (
+	"context"
+	dbsql "database/sql"
+	"errors"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// TranslateError Translate the error of the driver into a *dbal.Error, the unknown errors are returned as they are.
+// The grammars of the drivers translate their own errors and fall back to this one.
+func (grammarSQL SQL) TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var dbErr *dbal.Error
+	if errors.As(err, &dbErr) {
+		return err
+	}
+
+	if errors.Is(err, dbsql.ErrNoRows) {
+		return dbal.NewError(dbal.ErrNotFound, err)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return dbal.NewError(dbal.ErrQueryTimeout, err)
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return dbal.NewError(dbal.ErrQueryCanceled, err)
+	}
+	return err
+}
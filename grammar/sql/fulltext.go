@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsFullText Determine if the given version of the database server supports the full-text search.
+func (grammarSQL SQL) SupportsFullText(version *dbal.Version) bool {
+	return true
+}
+
+// WhereFullText Compile a "where full-text" clause.
+func (grammarSQL SQL) WhereFullText(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	*bindingOffset = *bindingOffset + where.Offset
+	return grammarSQL.CompileFullTextMatch(*where.FullText, grammarSQL.Parameter(where.FullText.Term, *bindingOffset))
+}
+
+// CompileFullTextMatch Compile the full-text search expression.
+//
+//	match (`title`, `content`) against (? in natural language mode)
+func (grammarSQL SQL) CompileFullTextMatch(fulltext dbal.FullText, parameter string) string {
+	mode := "in natural language mode"
+	if fulltext.Mode == "boolean" {
+		mode = "in boolean mode"
+	} else if fulltext.Expanded {
+		mode = "in natural language mode with query expansion"
+	}
+	return fmt.Sprintf("match (%s) against (%s %s)", grammarSQL.Columnize(fulltext.Columns), parameter, mode)
+}
+
+// CompileFullTextRank Compile the full-text rank expression, the match expression returns the relevance value.
+func (grammarSQL SQL) CompileFullTextRank(fulltext dbal.FullText, parameter string) string {
+	return grammarSQL.CompileFullTextMatch(fulltext, parameter)
+}
+
+// CompileFullTextColumns Replace the full-text rank columns with the raw expressions compiled by the given rank compiler,
+// the binding offset is moved past the sub-selects and the ranks in order.
+func (grammarSQL SQL) CompileFullTextColumns(columns []interface{}, bindingOffset *int, rank func(dbal.FullText, string) string) []interface{} {
+	compiled := []interface{}{}
+	for _, col := range columns {
+		switch column := col.(type) {
+		case dbal.Select:
+			*bindingOffset = *bindingOffset + column.Offset
+		case dbal.FullText:
+			*bindingOffset = *bindingOffset + 1
+			sql := rank(column, grammarSQL.Parameter(column.Term, *bindingOffset))
+			if column.Alias != "" {
+				sql = fmt.Sprintf("%s as %s", sql, grammarSQL.ID(column.Alias))
+			}
+			col = dbal.Raw(sql)
+		}
+		compiled = append(compiled, col)
+	}
+	return compiled
+}
+
+// CompileFullTextOrders Replace the relevance orders with the raw orders compiled by the given rank compiler.
+func (grammarSQL SQL) CompileFullTextOrders(orders []dbal.Order, bindingOffset *int, rank func(dbal.FullText, string) string) []dbal.Order {
+	compiled := []dbal.Order{}
+	for _, order := range orders {
+		if fulltext, ok := order.Column.(dbal.FullText); ok && order.SQL == "" {
+			*bindingOffset = *bindingOffset + 1
+			order.Type = "raw"
+			order.SQL = fmt.Sprintf("%s %s", rank(fulltext, grammarSQL.Parameter(fulltext.Term, *bindingOffset)), order.Direction)
+		}
+		compiled = append(compiled, order)
+	}
+	return compiled
+}
@@ -12,13 +12,20 @@ import (
 func (grammarSQL SQL) CompileInsert(query *dbal.Query, columns []interface{}, values [][]interface{}) (string, []interface{}) {
 
 	table := grammarSQL.WrapTable(query.From)
-	if len(values) == 0 {
+	offset := 0
+	with := grammarSQL.CompileWith(query, &offset)
+	if with != "" {
+		with = with + " "
+	}
+
+	if len(values) == 0 && with == "" {
 		return fmt.Sprintf("insert into %s default values", table), nil
+	} else if len(values) == 0 {
+		return fmt.Sprintf("%sinsert into %s default values", with, table), query.GetBindings("with")
 	}
 
-	offset := 0
 	parameters := []string{}
-	bindings := []interface{}{}
+	bindings := query.GetBindings("with")
 	for _, value := range values {
 		parameters = append(parameters, fmt.Sprintf("(%s)", grammarSQL.Parameterize(value, offset)))
 		for _, v := range value {
@@ -29,7 +36,7 @@ func (grammarSQL SQL) CompileInsert(query *dbal.Query, columns []interface{}, va
 		}
 	}
 
-	return fmt.Sprintf("insert into %s (%s) values %s", table, grammarSQL.Columnize(columns), strings.Join(parameters, ",")), bindings
+	return fmt.Sprintf("%sinsert into %s (%s) values %s", with, table, grammarSQL.Columnize(columns), strings.Join(parameters, ",")), bindings
 }
 
 // CompileInsertOrIgnore Compile an insert ignore statement into SQL.
@@ -44,14 +51,14 @@ func (grammarSQL SQL) CompileInsertGetID(query *dbal.Query, columns []interface{
 
 // ProcessInsertGetID Execute an insert and get ID statement and return the id
 func (grammarSQL SQL) ProcessInsertGetID(sql string, bindings []interface{}, sequence string) (int64, error) {
-	stmt, err := grammarSQL.DB.Prepare(sql)
+	stmt, err := grammarSQL.Executor().Prepare(sql)
 	if err != nil {
 		return 0, err
 	}
 
 	defer stmt.Close()
 	// res, err := stmt.Exec(bindings...)
-	res, err := utils.StmtExec(stmt, bindings)
+	res, err := utils.StmtExecContext(grammarSQL.Context(), stmt, bindings)
 	if err != nil {
 		return 0, err
 	}
@@ -61,5 +68,9 @@ func (grammarSQL SQL) ProcessInsertGetID(sql string, bindings []interface{}, seq
 
 // CompileInsertUsing Compile an insert statement using a subquery into SQL.
 func (grammarSQL SQL) CompileInsertUsing(query *dbal.Query, columns []interface{}, sql string) string {
+	offset := 0
+	if with := grammarSQL.CompileWith(query, &offset); with != "" {
+		return fmt.Sprintf("%s INSERT INTO %s (%s) %s", with, grammarSQL.WrapTable(query.From), grammarSQL.Columnize(columns), sql)
+	}
 	return fmt.Sprintf("INSERT INTO %s (%s) %s", grammarSQL.WrapTable(query.From), grammarSQL.Columnize(columns), sql)
 }
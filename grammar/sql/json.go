@@ -0,0 +1,109 @@
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+var reJSONIndex = regexp.MustCompile(`^[0-9]+$`)
+
+// IsJSONSelector Determine if the given value is a JSON selector (meta->address->city).
+func IsJSONSelector(value string) bool {
+	return strings.Contains(value, "->")
+}
+
+// ParseJSONSelector Split the given JSON selector into the column name and the path segments.
+//
+//	ParseJSONSelector("meta->address->city") // "meta", []string{"address", "city"}
+func ParseJSONSelector(selector string) (string, []string) {
+	segments := strings.Split(selector, "->")
+	column := strings.TrimSpace(segments[0])
+	path := []string{}
+	for _, segment := range segments[1:] {
+		segment = strings.TrimSpace(segment)
+		segment = strings.NewReplacer("'", "", "\"", "", "\\", "").Replace(segment)
+		if segment != "" {
+			path = append(path, segment)
+		}
+	}
+	return column, path
+}
+
+// IsJSONIndex Determine if the given path segment is an array index.
+func IsJSONIndex(segment string) bool {
+	return reJSONIndex.MatchString(segment)
+}
+
+// JSONPath Make the JSON path expression of the given path segments ( $."address"."city", $."tags"[0] ).
+func JSONPath(path []string) string {
+	sql := "$"
+	for _, segment := range path {
+		if IsJSONIndex(segment) {
+			sql = fmt.Sprintf("%s[%s]", sql, segment)
+			continue
+		}
+		sql = fmt.Sprintf(`%s."%s"`, sql, segment)
+	}
+	return sql
+}
+
+// JSONValue Encode the given value to a JSON document for binding.
+func JSONValue(value interface{}) interface{} {
+	if dbal.IsExpression(value) {
+		return value
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Errorf("the value can't be encoded to JSON. (%s)", err))
+	}
+	return string(bytes)
+}
+
+// WhereJsonContains Compile a "where JSON contains" clause.
+func (grammarSQL SQL) WhereJsonContains(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := ParseJSONSelector(where.Column.(string))
+	value := grammarSQL.jsonParameter(where, bindingOffset)
+
+	not := ""
+	if where.Not {
+		not = "not "
+	}
+
+	if len(path) == 0 {
+		return fmt.Sprintf("%sjson_contains(%s, %s)", not, grammarSQL.Wrap(column), value)
+	}
+	return fmt.Sprintf("%sjson_contains(%s, %s, '%s')", not, grammarSQL.Wrap(column), value, JSONPath(path))
+}
+
+// WhereJsonLength Compile a "where JSON length" clause.
+func (grammarSQL SQL) WhereJsonLength(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := ParseJSONSelector(where.Column.(string))
+	value := grammarSQL.jsonParameter(where, bindingOffset)
+	if len(path) == 0 {
+		return fmt.Sprintf("json_length(%s) %s %s", grammarSQL.Wrap(column), where.Operator, value)
+	}
+	return fmt.Sprintf("json_length(%s, '%s') %s %s", grammarSQL.Wrap(column), JSONPath(path), where.Operator, value)
+}
+
+// WhereJsonContainsKey Compile a "where JSON contains key" clause.
+func (grammarSQL SQL) WhereJsonContainsKey(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := ParseJSONSelector(where.Column.(string))
+	not := ""
+	if where.Not {
+		not = "not "
+	}
+	return fmt.Sprintf("%sifnull(json_contains_path(%s, 'one', '%s'), 0)", not, grammarSQL.Wrap(column), JSONPath(path))
+}
+
+// jsonParameter Get the parameter place-holder of the JSON where clause
+func (grammarSQL SQL) jsonParameter(where dbal.Where, bindingOffset *int) string {
+	if dbal.IsExpression(where.Value) {
+		return where.Value.(dbal.Expression).GetValue()
+	}
+	*bindingOffset = *bindingOffset + where.Offset
+	return grammarSQL.Parameter(where.Value, *bindingOffset)
+}
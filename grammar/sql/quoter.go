@@ -78,10 +78,14 @@ func (quoter *Quoter) Wrap(value interface{}) string {
 		return value.(dbal.Expression).GetValue()
 	case dbal.Name:
 		col := value.(dbal.Name)
+		name := quoter.ID(col.Name)
+		if IsJSONSelector(col.Name) {
+			name = quoter.WrapJSONSelector(col.Name)
+		}
 		if col.As() != "" {
-			return fmt.Sprintf("%s as %s", quoter.ID(col.Name), quoter.ID(col.As()))
+			return fmt.Sprintf("%s as %s", name, quoter.ID(col.As()))
 		}
-		return quoter.ID(value.(dbal.Name).Name)
+		return name
 	case dbal.Select:
 		col := value.(dbal.Select)
 		if col.Alias != "" {
@@ -89,6 +93,13 @@ func (quoter *Quoter) Wrap(value interface{}) string {
 		}
 		return fmt.Sprintf("%s ", col.SQL)
 	case string:
+		if IsJSONSelector(value.(string)) {
+			name := dbal.NewName(value.(string))
+			if name.As() != "" {
+				return fmt.Sprintf("%s as %s", quoter.WrapJSONSelector(name.Name), quoter.ID(name.As()))
+			}
+			return quoter.WrapJSONSelector(name.Name)
+		}
 		return quoter.WrapAliasedValue(value.(string))
 	default:
 		return fmt.Sprintf("%v", value)
@@ -114,6 +125,18 @@ func (quoter *Quoter) WrapAliasedValue(value string) string {
 	return fmt.Sprintf("%s", quoter.ID(name.Fullname()))
 }
 
+// WrapJSONSelector Wrap the given JSON selector as an unquoted value (json_unquote(json_extract(`meta`, '$."address"."city"'))).
+func (quoter *Quoter) WrapJSONSelector(selector string) string {
+	column, path := ParseJSONSelector(selector)
+	return fmt.Sprintf("json_unquote(json_extract(%s, '%s'))", quoter.WrapAliasedValue(column), JSONPath(path))
+}
+
+// WrapJSONSet Wrap the given target to set the value of the JSON selector (json_set(`meta`, '$."address"."city"', json_extract(?, '$'))).
+func (quoter *Quoter) WrapJSONSet(target string, selector string, parameter string) string {
+	_, path := ParseJSONSelector(selector)
+	return fmt.Sprintf("json_set(%s, '%s', json_extract(%s, '$'))", target, JSONPath(path), parameter)
+}
+
 // WrapTable Wrap a table in keyword identifiers.
 func (quoter *Quoter) WrapTable(value interface{}) string {
 	switch value.(type) {
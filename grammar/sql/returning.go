@@ -0,0 +1,20 @@
+package sql
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsReturning Determine if the given version of the database server supports the "returning" clause.
+func (grammarSQL SQL) SupportsReturning(version *dbal.Version) bool {
+	return false
+}
+
+// CompileReturning Compile the "returning" clause of the insert, update, delete and upsert statements.
+func (grammarSQL SQL) CompileReturning(columns []interface{}) string {
+	if len(columns) == 0 {
+		return "returning *"
+	}
+	return fmt.Sprintf("returning %s", grammarSQL.Columnize(columns))
+}
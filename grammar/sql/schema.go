@@ -24,10 +24,14 @@ func (grammarSQL SQL) GetSchema() string {
 
 // GetVersion get the version of the connection database
 func (grammarSQL SQL) GetVersion() (*dbal.Version, error) {
+	if grammarSQL.Config != nil && grammarSQL.Config.Version != nil {
+		return grammarSQL.Config.Version, nil
+	}
+
 	sql := fmt.Sprintf("SELECT VERSION()")
 	// defer logger.Debug(logger.RETRIEVE, sql).TimeCost(time.Now())
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +79,7 @@ func (grammarSQL SQL) GetTables() ([]string, error) {
 	sql := "SHOW TABLES"
 	defer log.Debug(sql)
 	tables := []string{}
-	err := grammarSQL.DB.Select(&tables, sql)
+	err := grammarSQL.Executor().Select(&tables, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +91,7 @@ func (grammarSQL SQL) TableExists(name string) (bool, error) {
 	sql := fmt.Sprintf("SHOW TABLES like %s", grammarSQL.VAL(name))
 	defer log.Debug(sql)
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return false, err
 	}
@@ -204,7 +208,7 @@ func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.
 	)
 	defer log.Debug(sql)
 	indexes := []*dbal.Index{}
-	err := grammarSQL.DB.Select(&indexes, sql)
+	err := grammarSQL.Executor().Select(&indexes, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -215,6 +219,8 @@ func (grammarSQL SQL) GetIndexListing(dbName string, tableName string) ([]*dbal.
 			index.Type = "primary"
 		} else if index.Unique {
 			index.Type = "unique"
+		} else if index.IndexType == "FULLTEXT" {
+			index.Type = "fulltext"
 		} else {
 			index.Type = "index"
 		}
@@ -267,7 +273,7 @@ func (grammarSQL SQL) GetColumnListing(dbName string, tableName string) ([]*dbal
 	)
 	defer log.Debug(sql)
 	columns := []*dbal.Column{}
-	err := grammarSQL.DB.Select(&columns, sql)
+	err := grammarSQL.Executor().Select(&columns, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -391,7 +397,7 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 	)
 
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 
 	// Callback
 	for _, cmd := range cbCommands {
@@ -404,7 +410,7 @@ func (grammarSQL SQL) CreateTable(table *dbal.Table, options ...dbal.CreateTable
 func (grammarSQL SQL) DropTable(name string) error {
 	sql := fmt.Sprintf("DROP TABLE %s", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -412,7 +418,7 @@ func (grammarSQL SQL) DropTable(name string) error {
 func (grammarSQL SQL) DropTableIfExists(name string) error {
 	sql := fmt.Sprintf("DROP TABLE IF EXISTS %s", grammarSQL.ID(name))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -420,7 +426,7 @@ func (grammarSQL SQL) DropTableIfExists(name string) error {
 func (grammarSQL SQL) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("ALTER TABLE %s RENAME %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -639,7 +645,7 @@ func (grammarSQL SQL) alterTableDropPrimary(table *dbal.Table, command *dbal.Com
 
 // ExecSQL execute sql then update table structure
 func (grammarSQL SQL) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path/filepath"
@@ -24,6 +25,9 @@ type SQL struct {
 	Read         *sqlx.DB
 	ReadConfig   *dbal.Config
 	Option       *dbal.Option
+	Tx           *sqlx.Tx
+	Ctx          context.Context
+	Materialized bool // Whether the materialized hints of the common table expressions are supported
 	dbal.Grammar
 	dbal.Quoter
 }
@@ -35,8 +39,9 @@ func NewSQL(quoter dbal.Quoter, opts ...Option) SQL {
 		Mode:   "production",
 		Quoter: quoter,
 		IndexTypes: map[string]string{
-			"unique": "UNIQUE KEY",
-			"index":  "KEY",
+			"unique":   "UNIQUE KEY",
+			"index":    "KEY",
+			"fulltext": "FULLTEXT KEY",
 		},
 		FlipTypes: map[string]string{},
 		Types: map[string]string{
@@ -144,6 +149,40 @@ func (grammarSQL SQL) NewWithRead(write *sqlx.DB, writeConfig *dbal.Config, read
 	return grammarSQL, nil
 }
 
+// NewWithTx Create a new grammar interface, using the given *sqlx.Tx for executing statements.
+func (grammarSQL SQL) NewWithTx(tx *sqlx.Tx) (dbal.Grammar, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("tx is nil")
+	}
+	grammarSQL.Tx = tx
+	return grammarSQL, nil
+}
+
+// NewWithContext Create a new grammar interface, using the given context for executing statements.
+func (grammarSQL SQL) NewWithContext(ctx context.Context) (dbal.Grammar, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("ctx is nil")
+	}
+	grammarSQL.Ctx = ctx
+	return grammarSQL, nil
+}
+
+// Context get the context of the grammar, returns context.Background() if the grammar is not bound to one
+func (grammarSQL SQL) Context() context.Context {
+	if grammarSQL.Ctx != nil {
+		return grammarSQL.Ctx
+	}
+	return context.Background()
+}
+
+// Executor get the statement executor, the transaction if the grammar is bound to one, or the primary connection
+func (grammarSQL SQL) Executor() dbal.Executor {
+	if grammarSQL.Tx != nil {
+		return dbal.WithContext(grammarSQL.Ctx, grammarSQL.Tx)
+	}
+	return dbal.WithContext(grammarSQL.Ctx, grammarSQL.DB)
+}
+
 // OnConnected the event will be triggered when db server was connected
 func (grammarSQL SQL) OnConnected() error {
 	return nil
@@ -161,6 +200,16 @@ func (grammarSQL SQL) GetOperators() []string {
 	}
 }
 
+// GetMaxBindings get the maximum number of the bindings of one statement
+func (grammarSQL SQL) GetMaxBindings() int {
+	return 65535
+}
+
+// GetMaxPacketSize get the maximum size in bytes of one statement, 0 if the size is not limited
+func (grammarSQL SQL) GetMaxPacketSize() (int, error) {
+	return 0, nil
+}
+
 // Wrap a value in keyword identifiers.
 func (grammarSQL SQL) Wrap(value interface{}) string {
 	return grammarSQL.Quoter.Wrap(value)
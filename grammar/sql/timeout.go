@@ -0,0 +1,20 @@
+package sql
+
+import "time"
+
+// CompileStatementTimeout Compile the SQL statement to limit the execution time of the statements of the current transaction,
+// the timeout is only enforced by the context if it returns an empty string.
+func (grammarSQL SQL) CompileStatementTimeout(timeout time.Duration) string {
+	return ""
+}
+
+// CompileCurrentStatementTimeout Compile the SQL statement to get the current limit of the execution time of the statements
+func (grammarSQL SQL) CompileCurrentStatementTimeout() string {
+	return ""
+}
+
+// CompileRestoreStatementTimeout Compile the SQL statement to restore the limit of the execution time of the statements
+// of the current transaction, the limit returned by the CompileCurrentStatementTimeout statement is given as the binding.
+func (grammarSQL SQL) CompileRestoreStatementTimeout() string {
+	return ""
+}
@@ -0,0 +1,23 @@
+package sql
+
+import "fmt"
+
+// SupportsTransactionalDDL Determine if the DDL statements can be rolled back within a transaction.
+func (grammarSQL SQL) SupportsTransactionalDDL() bool {
+	return false
+}
+
+// CompileSavepoint Compile the SQL statement to define a savepoint.
+func (grammarSQL SQL) CompileSavepoint(name string) string {
+	return fmt.Sprintf("SAVEPOINT %s", grammarSQL.ID(name))
+}
+
+// CompileSavepointRelease Compile the SQL statement to release a savepoint.
+func (grammarSQL SQL) CompileSavepointRelease(name string) string {
+	return fmt.Sprintf("RELEASE SAVEPOINT %s", grammarSQL.ID(name))
+}
+
+// CompileSavepointRollback Compile the SQL statement to execute a savepoint rollback.
+func (grammarSQL SQL) CompileSavepointRollback(name string) string {
+	return fmt.Sprintf("ROLLBACK TO SAVEPOINT %s", grammarSQL.ID(name))
+}
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/yaoapp/xun/dbal"
@@ -19,9 +20,12 @@ func (grammarSQL SQL) CompileUpdate(query *dbal.Query, values map[string]interfa
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
 
+	with := grammarSQL.CompileWith(query, &offset)
+	bindings = append(bindings, query.GetBindings("with")...)
+
 	joins := ""
 	if len(query.Joins) > 0 {
-		joins = grammarSQL.CompileJoins(query, query.Joins, &offset)
+		joins = grammarSQL.CompileJoins(query, query.Joins, &offset) + " "
 		bindings = append(bindings, query.GetBindings("join")...)
 		offset = len(bindings)
 	}
@@ -32,19 +36,216 @@ func (grammarSQL SQL) CompileUpdate(query *dbal.Query, values map[string]interfa
 	wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
 	bindings = append(bindings, query.GetBindings("where")...)
 
-	return fmt.Sprintf("update %s %sset %s %s", table, joins, columns, wheres), bindings
+	sql := fmt.Sprintf("update %s %sset %s %s", table, joins, columns, wheres)
+	if with != "" {
+		sql = fmt.Sprintf("%s %s", with, sql)
+	}
+	return sql, bindings
+}
+
+// IsUpdateFrom Determine if the joins of the update statement could be compiled to an "update ... from" statement,
+// only the inner and cross joins of tables and subqueries are supported and the limit should not be set.
+func (grammarSQL SQL) IsUpdateFrom(query *dbal.Query) bool {
+	if len(query.Joins) == 0 || query.Limit >= 0 {
+		return false
+	}
+	for _, join := range query.Joins {
+		if join.Type != "inner" && join.Type != "cross" {
+			return false
+		}
+		if join.Query == nil || len(join.Query.Joins) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// CompileFromJoins Compile the joined tables of the "update ... from" and "delete ... using" statements, and the join conditions
+// with the where clauses, the conditions are compiled by the given where compiler of the driver.
+func (grammarSQL SQL) CompileFromJoins(query *dbal.Query, offset *int, compileWheres func(*dbal.Query, []dbal.Where, *int) string) (string, string, []interface{}) {
+	bindings := []interface{}{}
+	tables := []string{}
+	for _, join := range query.Joins {
+		name := grammarSQL.WrapTable(join.Name)
+		if join.SQL != nil && join.Alias != "" {
+			name = fmt.Sprintf("(%s) as %s", grammarSQL.CompileSub(join.SQL, offset), grammarSQL.ID(join.Alias))
+			if sub, ok := join.SQL.(*dbal.Query); ok {
+				bindings = append(bindings, sub.GetBindings()...)
+			}
+		}
+		tables = append(tables, name)
+	}
+
+	conditions := []string{}
+	for _, join := range query.Joins {
+		if len(join.Query.Wheres) == 0 {
+			continue
+		}
+		on := compileWheres(join.Query, join.Query.Wheres, offset)
+		conditions = append(conditions, strings.TrimPrefix(on, "on "))
+		bindings = append(bindings, join.Query.GetBindings()...)
+	}
+
+	if len(query.Wheres) > 0 {
+		wheres := compileWheres(query, query.Wheres, offset)
+		conditions = append(conditions, fmt.Sprintf("(%s)", strings.TrimPrefix(wheres, "where ")))
+		bindings = append(bindings, query.GetBindings("where")...)
+	}
+
+	return strings.Join(tables, ", "), strings.Join(conditions, " and "), bindings
+}
+
+// IsDeleteUsing Determine if the joins of the delete statement could be compiled to a "delete ... using" statement,
+// the conditions are the same as the "update ... from" statements.
+func (grammarSQL SQL) IsDeleteUsing(query *dbal.Query) bool {
+	return grammarSQL.IsUpdateFrom(query)
+}
+
+// FromReference Get the name referring to the table of the query, the alias is returned if it's given.
+func (grammarSQL SQL) FromReference(from dbal.From) string {
+	if from.Alias != "" {
+		return from.Alias
+	}
+	switch name := from.Name.(type) {
+	case dbal.Name:
+		return name.Fullname()
+	case dbal.Expression:
+		return name.GetValue()
+	default:
+		return fmt.Sprintf("%v", name)
+	}
+}
+
+// UpdateFromValues Remove the table qualifiers of the update columns, the columns to set of an "update ... from" statement
+// belong to the updated table and must not be qualified.
+//
+//	grammarSQL.UpdateFromValues(map[string]interface{}{"users.vote": 1, "users.meta->tags": "x"}) // {"vote": 1, "meta->tags": "x"}
+func (grammarSQL SQL) UpdateFromValues(values map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{}
+	for key, value := range values {
+		column, selector := key, ""
+		if idx := strings.Index(key, "->"); idx >= 0 {
+			column, selector = key[:idx], key[idx:]
+		}
+		if idx := strings.LastIndex(column, "."); idx >= 0 {
+			column = column[idx+1:]
+		}
+		res[column+selector] = value
+	}
+	return res
 }
 
 // CompileUpdateColumns Compile the columns for an update statement.
+// The JSON selectors (meta->address->city) of the same column are merged into one assignment.
 func (grammarSQL SQL) CompileUpdateColumns(query *dbal.Query, values map[string]interface{}, offset *int) (string, []interface{}) {
 	columns := []string{}
 	bindings := []interface{}{}
+	selectors := []string{}
 	for key, value := range values {
+		if IsJSONSelector(key) {
+			selectors = append(selectors, key)
+			continue
+		}
 		columns = append(columns, fmt.Sprintf("%s=%s", grammarSQL.Wrap(key), grammarSQL.Parameter(value, *offset+1)))
 		if !dbal.IsExpression(value) {
 			bindings = append(bindings, value)
 			*offset++
 		}
 	}
+
+	// The bindings should be in the same order of the place-holders
+	sort.Slice(selectors, func(i, j int) bool {
+		first, _ := ParseJSONSelector(selectors[i])
+		second, _ := ParseJSONSelector(selectors[j])
+		if first != second {
+			return first < second
+		}
+		return selectors[i] < selectors[j]
+	})
+	targets := map[string]string{}
+	names := []string{}
+	for _, selector := range selectors {
+		value := values[selector]
+		column, _ := ParseJSONSelector(selector)
+		target, has := targets[column]
+		if !has {
+			target = grammarSQL.Wrap(column)
+			names = append(names, column)
+		}
+
+		targets[column] = grammarSQL.WrapJSONSet(target, selector, grammarSQL.Parameter(value, *offset+1))
+		if !dbal.IsExpression(value) {
+			bindings = append(bindings, JSONValue(value))
+			*offset++
+		}
+	}
+
+	for _, name := range names {
+		columns = append(columns, fmt.Sprintf("%s=%s", grammarSQL.Wrap(name), targets[name]))
+	}
 	return strings.Join(columns, ", "), bindings
 }
+
+// SupportsUpdateBatchValues Determine if the given version of the database server supports the "values" strategy of the batch updates.
+func (grammarSQL SQL) SupportsUpdateBatchValues(version *dbal.Version) bool {
+	return false
+}
+
+// CompileUpdateBatch Compile a batch update statement into SQL, the rows are matched by the key column
+// and each column is set by a "case" expression.
+//
+//	update `users` set `vote`=case `id` when ? then ? when ? then ? else `vote` end where `id` in (?, ?)
+func (grammarSQL SQL) CompileUpdateBatch(query *dbal.Query, key string, columns []string, values []map[string]interface{}, strategy string) (string, []interface{}) {
+	if strategy != "" && strategy != "case" {
+		panic(fmt.Errorf("the %s strategy of the batch update is not supported by %s", strategy, grammarSQL.Driver))
+	}
+
+	offset := 0
+	table := grammarSQL.WrapTable(query.From)
+	sets, keys, bindings := grammarSQL.CompileUpdateBatchCases(key, columns, values, &offset)
+	sql := fmt.Sprintf("update %s set %s where %s in (%s)", table, sets, grammarSQL.Wrap(key), keys)
+	if len(query.Wheres) > 0 {
+		wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
+		sql = fmt.Sprintf("%s and (%s)", sql, strings.TrimPrefix(wheres, "where "))
+		bindings = append(bindings, query.GetBindings("where")...)
+	}
+	return sql, bindings
+}
+
+// CompileUpdateBatchCases Compile the "case" expressions and the key place-holders of a batch update statement.
+// A row without the column keeps the value of the column.
+func (grammarSQL SQL) CompileUpdateBatchCases(key string, columns []string, values []map[string]interface{}, offset *int) (string, string, []interface{}) {
+	bindings := []interface{}{}
+	sets := []string{}
+	for _, column := range columns {
+		cases := []string{}
+		for _, row := range values {
+			value, has := row[column]
+			if !has {
+				continue
+			}
+			when := grammarSQL.Parameter(row[key], *offset+1)
+			if !dbal.IsExpression(row[key]) {
+				bindings = append(bindings, row[key])
+				*offset++
+			}
+			then := grammarSQL.Parameter(value, *offset+1)
+			if !dbal.IsExpression(value) {
+				bindings = append(bindings, value)
+				*offset++
+			}
+			cases = append(cases, fmt.Sprintf("when %s then %s", when, then))
+		}
+		sets = append(sets, fmt.Sprintf("%s=case %s %s else %s end", grammarSQL.Wrap(column), grammarSQL.Wrap(key), strings.Join(cases, " "), grammarSQL.Wrap(column)))
+	}
+
+	keys := []string{}
+	for _, row := range values {
+		keys = append(keys, grammarSQL.Parameter(row[key], *offset+1))
+		if !dbal.IsExpression(row[key]) {
+			bindings = append(bindings, row[key])
+			*offset++
+		}
+	}
+	return strings.Join(sets, ", "), strings.Join(keys, ", "), bindings
+}
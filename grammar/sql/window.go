@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsWindowFunctions Determine if the given version of the database server supports the window functions.
+func (grammarSQL SQL) SupportsWindowFunctions(version *dbal.Version) bool {
+	return true
+}
+
+// CompileWindowFunctions Replace the window function columns with the compiled raw expressions.
+func (grammarSQL SQL) CompileWindowFunctions(columns []interface{}) []interface{} {
+	compiled := []interface{}{}
+	for _, col := range columns {
+		fn, ok := col.(dbal.WindowFunction)
+		if !ok {
+			compiled = append(compiled, col)
+			continue
+		}
+		compiled = append(compiled, dbal.Raw(grammarSQL.CompileWindowFunction(fn)))
+	}
+	return compiled
+}
+
+// CompileWindowFunction Compile a window function column into SQL.
+func (grammarSQL SQL) CompileWindowFunction(fn dbal.WindowFunction) string {
+	args := []string{}
+	for _, arg := range fn.Args {
+		args = append(args, grammarSQL.Wrap(arg))
+	}
+
+	sql := fmt.Sprintf("%s(%s) over %s", fn.Func, strings.Join(args, ", "), grammarSQL.CompileWindow(fn.Window))
+	if fn.Alias != "" {
+		sql = fmt.Sprintf("%s as %s", sql, grammarSQL.ID(fn.Alias))
+	}
+	return sql
+}
+
+// CompileWindow Compile a window specification into SQL.
+// The window refers to a named window directly, if it has the name only.
+func (grammarSQL SQL) CompileWindow(window dbal.Window) string {
+	clauses := []string{}
+	if window.Name != "" {
+		clauses = append(clauses, grammarSQL.ID(window.Name))
+	}
+
+	if len(window.Partitions) > 0 {
+		clauses = append(clauses, fmt.Sprintf("partition by %s", grammarSQL.Columnize(window.Partitions)))
+	}
+
+	if len(window.Orders) > 0 {
+		orders := []string{}
+		for _, order := range window.Orders {
+			orders = append(orders, fmt.Sprintf("%s %s", grammarSQL.Wrap(order.Column), order.Direction))
+		}
+		clauses = append(clauses, fmt.Sprintf("order by %s", strings.Join(orders, ", ")))
+	}
+
+	if window.Frame != "" {
+		if window.FrameEnd != "" {
+			clauses = append(clauses, fmt.Sprintf("%s between %s and %s", window.Frame, window.FrameStart, window.FrameEnd))
+		} else {
+			clauses = append(clauses, fmt.Sprintf("%s %s", window.Frame, window.FrameStart))
+		}
+	}
+
+	if window.Name != "" && len(clauses) == 1 {
+		return clauses[0]
+	}
+	return fmt.Sprintf("(%s)", strings.Join(clauses, " "))
+}
+
+// CompileWindows Compile the "window" portions of the query.
+func (grammarSQL SQL) CompileWindows(query *dbal.Query, windows []dbal.NamedWindow) string {
+	if len(windows) == 0 {
+		return ""
+	}
+
+	clauses := []string{}
+	for _, window := range windows {
+		clauses = append(clauses, fmt.Sprintf("%s as %s", grammarSQL.ID(window.Name), grammarSQL.CompileWindow(window.Window)))
+	}
+	return fmt.Sprintf("window %s", strings.Join(clauses, ", "))
+}
@@ -105,7 +105,7 @@ func (grammarSQL SQLite3) getType(column *dbal.Column) string {
 
 	switch typ {
 	case "JSON", "JSONB":
-		typ = "TEXT"
+		typ = "TEXT JSON" // the TEXT affinity, the JSON in the declared type lets the query builder decode the values
 		break
 	case "UUID":
 		typ = "VARCHAR(36)"
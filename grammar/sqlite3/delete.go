@@ -7,31 +7,32 @@ import (
 )
 
 // CompileDelete Compile a delete statement into SQL.
+// The joins, orders and limit are matched by the rowid with a subquery.
 func (grammarSQL SQLite3) CompileDelete(query *dbal.Query) (string, []interface{}) {
 
-	if len(query.Joins) == 0 && query.Limit < 0 {
+	if len(query.Joins) == 0 && query.Limit < 0 && len(query.Orders) == 0 {
 		return grammarSQL.SQL.CompileDelete(query)
 	}
 
 	offset := 0
 	bindings := []interface{}{}
 	table := grammarSQL.WrapTable(query.From)
-
-	alias := query.From.Alias
-	if alias != "" {
-		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", alias)}
-	} else {
-		query.Columns = []interface{}{"rowid"}
-	}
+	query.Columns = []interface{}{fmt.Sprintf("%s.rowid", grammarSQL.FromReference(query.From))}
 
 	selectSQL := grammarSQL.CompileSelectOffset(query, &offset)
 
 	bindings = append(bindings, query.GetBindings()...)
-	sql := fmt.Sprintf("delete from  %s where %s in (%s)", table, grammarSQL.Wrap("rowid"), selectSQL)
+	sql := fmt.Sprintf("delete from %s where %s in (%s)", table, grammarSQL.Wrap("rowid"), selectSQL)
 
 	return sql, bindings
 }
 
+// SupportsDeleteJoinOrderLimit Determine if the joined deletes could be ordered and limited,
+// the rows of the joined deletes are matched by a subquery.
+func (grammarSQL SQLite3) SupportsDeleteJoinOrderLimit() bool {
+	return true
+}
+
 // CompileTruncate Compile a truncate table statement into SQL.
 func (grammarSQL SQLite3) CompileTruncate(query *dbal.Query) ([]string, [][]interface{}) {
 
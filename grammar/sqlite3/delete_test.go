@@ -0,0 +1,26 @@
+package sqlite3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestDeleteCompileDeleteJoin(t *testing.T) {
+	grammarSQL := New().(SQLite3)
+	query := newUpdateJoinQueryForTest()
+	sql, bindings := grammarSQL.CompileDelete(query)
+	assert.Equal(t, "delete from `users` where `rowid` in (select `users`.`rowid` from `users` inner join `scores` on `users`.`id` = `scores`.`user_id` where `scores`.`vote` > ?)", sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestDeleteCompileDeleteOrderLimit(t *testing.T) {
+	grammarSQL := New().(SQLite3)
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	query.Orders = []dbal.Order{{Type: "basic", Column: "id", Direction: "desc"}}
+	query.Limit = 2
+	sql, _ := grammarSQL.CompileDelete(query)
+	assert.Equal(t, "delete from `users` where `rowid` in (select `users`.`rowid` from `users` order by `id` desc limit 2)", sql)
+}
@@ -0,0 +1,47 @@
+package sqlite3
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+	"github.com/yaoapp/xun/dbal"
+)
+
+var errorConstraint = regexp.MustCompile(`constraint failed: (.+)$`)
+
+// TranslateError Translate the sqlite3.Error into a *dbal.Error by the extended code,
+// the table and column are extracted from the message.
+func (grammarSQL SQLite3) TranslateError(err error) error {
+	var sqliteErr sqlite3.Error
+	if err == nil || !errors.As(err, &sqliteErr) {
+		return grammarSQL.SQL.TranslateError(err)
+	}
+
+	var kind error
+	switch {
+	case sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey:
+		kind = dbal.ErrUniqueViolation
+	case sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey:
+		kind = dbal.ErrForeignKeyViolation
+	case sqliteErr.ExtendedCode == sqlite3.ErrConstraintNotNull:
+		kind = dbal.ErrNotNullViolation
+	case sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked:
+		kind = dbal.ErrLockTimeout
+	case sqliteErr.Code == sqlite3.ErrInterrupt:
+		kind = dbal.ErrQueryCanceled
+	default:
+		return grammarSQL.SQL.TranslateError(err)
+	}
+
+	// UNIQUE constraint failed: users.email, users.name
+	dbErr := dbal.NewError(kind, err)
+	if matched := errorConstraint.FindStringSubmatch(sqliteErr.Error()); len(matched) == 2 {
+		column := strings.TrimSpace(strings.Split(matched[1], ",")[0])
+		if idx := strings.Index(column, "."); idx >= 0 {
+			dbErr.Table, dbErr.Column = column[:idx], column[idx+1:]
+		}
+	}
+	return dbErr
+}
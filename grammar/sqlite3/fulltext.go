@@ -0,0 +1,11 @@
+package sqlite3
+
+import (
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsFullText Determine if the given version of the database server supports the full-text search.
+// The full-text search of SQLite needs the FTS virtual tables, which are not managed by the schema builder.
+func (grammarSQL SQLite3) SupportsFullText(version *dbal.Version) bool {
+	return false
+}
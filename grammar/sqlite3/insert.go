@@ -9,6 +9,6 @@ import (
 // CompileInsertOrIgnore Compile an insert ignore statement into SQL.
 func (grammarSQL SQLite3) CompileInsertOrIgnore(query *dbal.Query, columns []interface{}, values [][]interface{}) (string, []interface{}) {
 	sql, bindings := grammarSQL.CompileInsert(query, columns, values)
-	sql = strings.Replace(sql, "insert", "insert or ignore", 1)
+	sql = strings.Replace(sql, "insert into", "insert or ignore into", 1)
 	return sql, bindings
 }
@@ -0,0 +1,66 @@
+package sqlite3
+
+import (
+	"fmt"
+
+	"github.com/yaoapp/xun/dbal"
+	"github.com/yaoapp/xun/grammar/sql"
+)
+
+// WhereJsonContains Compile a "where JSON contains" clause.
+// Every value of the given JSON document must be found in the JSON array of the column.
+func (grammarSQL SQLite3) WhereJsonContains(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := sql.ParseJSONSelector(where.Column.(string))
+	value := grammarSQL.jsonParameter(where, bindingOffset)
+
+	not := ""
+	if where.Not {
+		not = "not "
+	}
+	return fmt.Sprintf(
+		"%snot exists (select 1 from json_each(%s) as `needle` where `needle`.`value` not in (select `value` from json_each(%s, '%s')))",
+		not, value, grammarSQL.Wrap(column), sql.JSONPath(path),
+	)
+}
+
+// WhereJsonLength Compile a "where JSON length" clause.
+func (grammarSQL SQLite3) WhereJsonLength(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := sql.ParseJSONSelector(where.Column.(string))
+	value := grammarSQL.jsonParameter(where, bindingOffset)
+	return fmt.Sprintf("json_array_length(%s, '%s') %s %s", grammarSQL.Wrap(column), sql.JSONPath(path), where.Operator, value)
+}
+
+// WhereJsonContainsKey Compile a "where JSON contains key" clause.
+func (grammarSQL SQLite3) WhereJsonContainsKey(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+	column, path := sql.ParseJSONSelector(where.Column.(string))
+	is := "is not null"
+	if where.Not {
+		is = "is null"
+	}
+	return fmt.Sprintf("json_type(%s, '%s') %s", grammarSQL.Wrap(column), sql.JSONPath(path), is)
+}
+
+// WhereNested Compile a nested where clause.
+func (grammarSQL SQLite3) WhereNested(query *dbal.Query, where dbal.Where, bindingOffset *int) string {
+
+	offset := 6 // - where
+	if query.IsJoinClause {
+		offset = 3 // - on
+	}
+
+	sql := grammarSQL.CompileWheres(where.Query, where.Query.Wheres, bindingOffset)
+	end := len(sql)
+	if end > offset {
+		sql = sql[offset:end]
+	}
+	return fmt.Sprintf("(%s)", sql)
+}
+
+// jsonParameter Get the parameter place-holder of the JSON where clause
+func (grammarSQL SQLite3) jsonParameter(where dbal.Where, bindingOffset *int) string {
+	if dbal.IsExpression(where.Value) {
+		return where.Value.(dbal.Expression).GetValue()
+	}
+	*bindingOffset = *bindingOffset + where.Offset
+	return grammarSQL.Parameter(where.Value, *bindingOffset)
+}
@@ -2,7 +2,9 @@ package sqlite3
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/yaoapp/xun/dbal"
 	"github.com/yaoapp/xun/grammar/sql"
 )
 
@@ -12,6 +14,50 @@ type Quoter struct {
 }
 
 // WrapUnion a union subquery in parentheses.
-func (quoter *Quoter) WrapUnion(sql string) string {
-	return fmt.Sprintf("select * from (%s)", sql)
+func (quoter *Quoter) WrapUnion(stmt string) string {
+	return fmt.Sprintf("select * from (%s)", stmt)
+}
+
+// Wrap a value in keyword identifiers.
+func (quoter *Quoter) Wrap(value interface{}) string {
+	switch v2 := value.(type) {
+	case dbal.Name:
+		if sql.IsJSONSelector(v2.Name) {
+			if v2.As() != "" {
+				return fmt.Sprintf("%s as %s", quoter.WrapJSONSelector(v2.Name), quoter.ID(v2.As()))
+			}
+			return quoter.WrapJSONSelector(v2.Name)
+		}
+	case string:
+		if sql.IsJSONSelector(v2) {
+			name := dbal.NewName(v2)
+			if name.As() != "" {
+				return fmt.Sprintf("%s as %s", quoter.WrapJSONSelector(name.Name), quoter.ID(name.As()))
+			}
+			return quoter.WrapJSONSelector(name.Name)
+		}
+	}
+	return quoter.Quoter.Wrap(value)
+}
+
+// WrapJSONSelector Wrap the given JSON selector (json_extract(`meta`, '$."address"."city"')).
+// The JSON functions are available when the go-sqlite3 driver is built with the "sqlite_json" tag.
+func (quoter *Quoter) WrapJSONSelector(selector string) string {
+	column, path := sql.ParseJSONSelector(selector)
+	return fmt.Sprintf("json_extract(%s, '%s')", quoter.WrapAliasedValue(column), sql.JSONPath(path))
+}
+
+// WrapJSONSet Wrap the given target to set the value of the JSON selector (json_set(`meta`, '$."address"."city"', json(?))).
+func (quoter *Quoter) WrapJSONSet(target string, selector string, parameter string) string {
+	_, path := sql.ParseJSONSelector(selector)
+	return fmt.Sprintf("json_set(%s, '%s', json(%s))", target, sql.JSONPath(path), parameter)
+}
+
+// Columnize Convert an array of column names into a delimited string.
+func (quoter *Quoter) Columnize(columns []interface{}) string {
+	wrapColumns := []string{}
+	for _, col := range columns {
+		wrapColumns = append(wrapColumns, quoter.Wrap(col))
+	}
+	return strings.Join(wrapColumns, ", ")
 }
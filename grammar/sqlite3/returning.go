@@ -0,0 +1,12 @@
+package sqlite3
+
+import (
+	"github.com/blang/semver/v4"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsReturning Determine if the given version of the database server supports the "returning" clause.
+// The "returning" clause is available since SQLite 3.35.0.
+func (grammarSQL SQLite3) SupportsReturning(version *dbal.Version) bool {
+	return version.GTE(semver.MustParse("3.35.0"))
+}
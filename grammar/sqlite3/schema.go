@@ -15,10 +15,14 @@ import (
 
 // GetVersion get the version of the connection database
 func (grammarSQL SQLite3) GetVersion() (*dbal.Version, error) {
+	if grammarSQL.Config != nil && grammarSQL.Config.Version != nil {
+		return grammarSQL.Config.Version, nil
+	}
+
 	sql := fmt.Sprintf("SELECT SQLITE_VERSION()")
 	// defer logger.Debug(logger.RETRIEVE, sql).TimeCost(time.Now())
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +46,7 @@ func (grammarSQL SQLite3) GetTables() ([]string, error) {
 	sql := fmt.Sprintf("SELECT `name` FROM `sqlite_master` WHERE type='table'")
 	defer log.Debug(sql)
 	tables := []string{}
-	err := grammarSQL.DB.Select(&tables, sql)
+	err := grammarSQL.Executor().Select(&tables, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +58,7 @@ func (grammarSQL SQLite3) TableExists(name string) (bool, error) {
 	sql := fmt.Sprintf("SELECT `name` FROM `sqlite_master` WHERE type='table' AND name=%s", grammarSQL.VAL(name))
 	defer log.Debug(sql)
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, sql)
+	err := grammarSQL.Executor().Select(&rows, sql)
 	if err != nil {
 		return false, err
 	}
@@ -120,7 +124,7 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 
 	// Create table
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
@@ -133,7 +137,7 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 		)
 	}
 	defer log.Debug(strings.Join(indexStmts, ";\n"))
-	_, err = grammarSQL.DB.Exec(strings.Join(indexStmts, ";\n"))
+	_, err = grammarSQL.Executor().Exec(strings.Join(indexStmts, ";\n"))
 
 	for _, cmd := range cbCommands {
 		cmd.Callback(err)
@@ -150,7 +154,7 @@ func (grammarSQL SQLite3) CreateTable(table *dbal.Table, options ...dbal.CreateT
 func (grammarSQL SQLite3) RenameTable(old string, new string) error {
 	sql := fmt.Sprintf("ALTER TABLE %s RENAME TO %s", grammarSQL.ID(old), grammarSQL.ID(new))
 	defer log.Debug(sql)
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	return err
 }
 
@@ -280,7 +284,7 @@ func (grammarSQL SQLite3) GetIndexListing(dbName string, tableName string) ([]*d
 	)
 	defer log.Debug(sql)
 	indexes := []*dbal.Index{}
-	err := grammarSQL.DB.Select(&indexes, sql)
+	err := grammarSQL.Executor().Select(&indexes, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -304,6 +308,7 @@ func (grammarSQL SQLite3) GetColumnListing(schemaName string, tableName string)
 		"p.cid AS `position`",
 		"p.dflt_value AS `default`",
 		"UPPER(p.type) as `type`",
+		"UPPER(p.type) as `type_name`",
 		`CASE
 			WHEN ` + "p.`notnull`" + ` == 0 THEN 1
 			ELSE 0
@@ -333,7 +338,7 @@ func (grammarSQL SQLite3) GetColumnListing(schemaName string, tableName string)
 	)
 	defer log.Debug(sql)
 	columns := []*dbal.Column{}
-	err := grammarSQL.DB.Select(&columns, sql)
+	err := grammarSQL.Executor().Select(&columns, sql)
 	if err != nil {
 		return nil, err
 	}
@@ -453,7 +458,7 @@ func (grammarSQL SQLite3) AlterTable(table *dbal.Table) error {
 // GetConstraintListing get the constraints of the table
 func (grammarSQL SQLite3) GetConstraintListing(schemaName string, tableName string) (map[string]*dbal.Constraint, error) {
 	rows := []string{}
-	err := grammarSQL.DB.Select(&rows, "SELECT `sql` FROM sqlite_master WHERE type='table' and name=?", tableName)
+	err := grammarSQL.Executor().Select(&rows, "SELECT `sql` FROM sqlite_master WHERE type='table' and name=?", tableName)
 	if err != nil {
 		return nil, err
 	}
@@ -499,7 +504,7 @@ func (grammarSQL SQLite3) parseConstraint(schemaName string, tableName string, l
 
 // ExecSQL execute sql then update table structure
 func (grammarSQL SQLite3) ExecSQL(table *dbal.Table, sql string) error {
-	_, err := grammarSQL.DB.Exec(sql)
+	_, err := grammarSQL.Executor().Exec(sql)
 	if err != nil {
 		return err
 	}
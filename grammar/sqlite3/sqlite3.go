@@ -1,11 +1,13 @@
 package sqlite3
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"path/filepath"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3" // Load sqlite3 driver
 	"github.com/yaoapp/xun/dbal"
@@ -16,6 +18,7 @@ import (
 // SQLite3 the sqlite3 Grammar
 type SQLite3 struct {
 	sql.SQL
+	UpdateFrom bool // Whether the "update ... from" statement is supported, it's resolved by the version when the grammar is set up
 }
 
 func init() {
@@ -56,6 +59,13 @@ func (grammarSQL *SQLite3) setup(db *sqlx.DB, config *dbal.Config, option *dbal.
 	filename := filepath.Base(uinfo.Path)
 	grammarSQL.DatabaseName = strings.TrimSuffix(filename, filepath.Ext(filename))
 	grammarSQL.SchemaName = grammarSQL.DatabaseName
+
+	// the "update ... from" statement is available since SQLite 3.33.0,
+	// the latest syntax is assumed when the version can't be detected.
+	grammarSQL.UpdateFrom = true
+	if version, err := grammarSQL.GetVersion(); err == nil {
+		grammarSQL.UpdateFrom = version.GTE(semver.MustParse("3.33.0"))
+	}
 	return nil
 }
 
@@ -82,17 +92,37 @@ func (grammarSQL SQLite3) NewWithRead(write *sqlx.DB, writeConfig *dbal.Config,
 	return grammarSQL, nil
 }
 
+// NewWithTx Create a new grammar interface, using the given *sqlx.Tx for executing statements.
+func (grammarSQL SQLite3) NewWithTx(tx *sqlx.Tx) (dbal.Grammar, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("tx is nil")
+	}
+	grammarSQL.Tx = tx
+	return grammarSQL, nil
+}
+
+// NewWithContext Create a new grammar interface, using the given context for executing statements.
+func (grammarSQL SQLite3) NewWithContext(ctx context.Context) (dbal.Grammar, error) {
+	if ctx == nil {
+		return nil, fmt.Errorf("ctx is nil")
+	}
+	grammarSQL.Ctx = ctx
+	return grammarSQL, nil
+}
+
 // New Create a new mysql grammar inteface
 func New(opts ...sql.Option) dbal.Grammar {
 	sqlite := SQLite3{
-		SQL: sql.NewSQL(&Quoter{}, opts...),
+		SQL:        sql.NewSQL(&Quoter{}, opts...),
+		UpdateFrom: true,
 	}
 	if sqlite.Driver == "" || sqlite.Driver == "sql" {
 		sqlite.Driver = "sqlite3"
 	}
 	sqlite.IndexTypes = map[string]string{
-		"unique": "UNIQUE INDEX",
-		"index":  "INDEX",
+		"unique":   "UNIQUE INDEX",
+		"index":    "INDEX",
+		"fulltext": "INDEX",
 	}
 
 	// overwrite types
@@ -108,6 +138,7 @@ func New(opts ...sql.Option) dbal.Grammar {
 	if ok {
 		sqlite.FlipTypes = flipTypes.(map[string]string)
 		sqlite.FlipTypes["DATETIME"] = "dateTime"
+		sqlite.FlipTypes["TEXT JSON"] = "text"
 		sqlite.FlipTypes["TIME"] = "time"
 		sqlite.FlipTypes["TIMESTAMP"] = "timestamp"
 		sqlite.FlipTypes["UNSIGNED BIG INT"] = "bigInteger"
@@ -116,6 +147,12 @@ func New(opts ...sql.Option) dbal.Grammar {
 	return sqlite
 }
 
+// GetMaxBindings get the maximum number of the bindings of one statement
+// The limit is 999 before SQLite 3.32.0, it's used for all the versions.
+func (grammarSQL SQLite3) GetMaxBindings() int {
+	return 999
+}
+
 // GetOperators get the operators
 func (grammarSQL SQLite3) GetOperators() []string {
 	return []string{
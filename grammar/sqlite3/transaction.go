@@ -0,0 +1,6 @@
+package sqlite3
+
+// SupportsTransactionalDDL Determine if the DDL statements can be rolled back within a transaction.
+func (grammarSQL SQLite3) SupportsTransactionalDDL() bool {
+	return true
+}
@@ -3,6 +3,7 @@ package sqlite3
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/yaoapp/xun/dbal"
@@ -45,6 +46,13 @@ func (grammarSQL SQLite3) CompileUpsert(query *dbal.Query, columns []interface{}
 // CompileUpdate Compile an update statement into SQL.
 func (grammarSQL SQLite3) CompileUpdate(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
 
+	if grammarSQL.IsUpdateFrom(query) {
+		if grammarSQL.UpdateFrom {
+			return grammarSQL.compileUpdateFrom(query, values)
+		}
+		return grammarSQL.compileUpdateCorrelated(query, values)
+	}
+
 	if len(query.Joins) == 0 && query.Limit < 0 {
 		return grammarSQL.SQL.CompileUpdate(query, values)
 	}
@@ -56,17 +64,111 @@ func (grammarSQL SQLite3) CompileUpdate(query *dbal.Query, values map[string]int
 	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, values, &offset)
 	bindings = append(bindings, columnsBindings...)
 
+	query.Columns = []interface{}{fmt.Sprintf("%s.rowid", grammarSQL.FromReference(query.From))}
+
+	selectSQL := grammarSQL.CompileSelectOffset(query, &offset)
+
+	bindings = append(bindings, query.GetBindings()...)
+	sql := fmt.Sprintf("update %s set %s where %s in (%s)", table, columns, grammarSQL.Wrap("rowid"), selectSQL)
+
+	return sql, bindings
+}
+
+// compileUpdateFrom Compile an "update ... from" statement, the joined tables are moved to the "from" clause
+// and the join conditions are moved to the "where" clause.
+//
+//	update `users` set `vote`=`scores`.`vote` from `scores` where `users`.`id` = `scores`.`user_id` and (`scores`.`vote` > ?)
+func (grammarSQL SQLite3) compileUpdateFrom(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
+	offset := 0
+	bindings := []interface{}{}
+	table := grammarSQL.WrapTable(query.From)
+
+	with := grammarSQL.CompileWith(query, &offset)
+	bindings = append(bindings, query.GetBindings("with")...)
+
+	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, grammarSQL.UpdateFromValues(values), &offset)
+	bindings = append(bindings, columnsBindings...)
+
+	tables, conditions, joinBindings := grammarSQL.CompileFromJoins(query, &offset, grammarSQL.CompileWheres)
+	bindings = append(bindings, joinBindings...)
+
+	sql := fmt.Sprintf("update %s set %s from %s", table, columns, tables)
+	if conditions != "" {
+		sql = fmt.Sprintf("%s where %s", sql, conditions)
+	}
+	if with != "" {
+		sql = fmt.Sprintf("%s %s", with, sql)
+	}
+	return sql, bindings
+}
+
+// compileUpdateCorrelated Compile the update statement with joins for the servers without "update ... from",
+// the expressions referring to the joined tables are rewritten to correlated subqueries and the rows are matched by the rowid.
+//
+//	update `users` set `vote`=(select `scores`.`vote` from `scores` where `users`.`id` = `scores`.`user_id` limit 1) where `rowid` in (select `users`.`rowid` from `users` inner join `scores` on ...)
+func (grammarSQL SQLite3) compileUpdateCorrelated(query *dbal.Query, values map[string]interface{}) (string, []interface{}) {
+	offset := 0
+	bindings := []interface{}{}
+	table := grammarSQL.WrapTable(query.From)
+
+	values = grammarSQL.UpdateFromValues(values)
+	params := map[string]interface{}{}
+	expressions := []string{}
+	for key, value := range values {
+		if dbal.IsExpression(value) {
+			expressions = append(expressions, key)
+			continue
+		}
+		params[key] = value
+	}
+	sort.Strings(expressions)
+
+	columns, columnsBindings := grammarSQL.CompileUpdateColumns(query, params, &offset)
+	bindings = append(bindings, columnsBindings...)
+
+	segments := []string{}
+	if columns != "" {
+		segments = append(segments, columns)
+	}
+	for _, key := range expressions {
+		tables, conditions, joinBindings := grammarSQL.CompileFromJoins(query, &offset, grammarSQL.CompileWheres)
+		bindings = append(bindings, joinBindings...)
+		if conditions != "" {
+			conditions = fmt.Sprintf(" where %s", conditions)
+		}
+		segments = append(segments, fmt.Sprintf(
+			"%s=(select %s from %s%s limit 1)",
+			grammarSQL.Wrap(key), values[key].(dbal.Expression).GetValue(), tables, conditions,
+		))
+	}
+
 	alias := query.From.Alias
 	if alias != "" {
 		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", alias)}
 	} else {
-		query.Columns = []interface{}{"rowid"}
+		query.Columns = []interface{}{fmt.Sprintf("%s.rowid", grammarSQL.getTableName(query))}
 	}
 
 	selectSQL := grammarSQL.CompileSelectOffset(query, &offset)
-
 	bindings = append(bindings, query.GetBindings()...)
-	sql := fmt.Sprintf("update %s set %s where %s in (%s)", table, columns, grammarSQL.Wrap("rowid"), selectSQL)
+	sql := fmt.Sprintf("update %s set %s where %s in (%s)", table, strings.Join(segments, ", "), grammarSQL.Wrap("rowid"), selectSQL)
+	return sql, bindings
+}
+
+// CompileUpdateBatch Compile a batch update statement into SQL, each column is set by a "case" expression.
+func (grammarSQL SQLite3) CompileUpdateBatch(query *dbal.Query, key string, columns []string, values []map[string]interface{}, strategy string) (string, []interface{}) {
+	if strategy != "" && strategy != "case" {
+		panic(fmt.Errorf("the %s strategy of the batch update is not supported by %s", strategy, grammarSQL.Driver))
+	}
 
+	offset := 0
+	table := grammarSQL.WrapTable(query.From)
+	sets, keys, bindings := grammarSQL.CompileUpdateBatchCases(key, columns, values, &offset)
+	sql := fmt.Sprintf("update %s set %s where %s in (%s)", table, sets, grammarSQL.Wrap(key), keys)
+	if len(query.Wheres) > 0 {
+		wheres := grammarSQL.CompileWheres(query, query.Wheres, &offset)
+		sql = fmt.Sprintf("%s and (%s)", sql, strings.TrimPrefix(wheres, "where "))
+		bindings = append(bindings, query.GetBindings("where")...)
+	}
 	return sql, bindings
 }
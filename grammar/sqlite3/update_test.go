@@ -0,0 +1,70 @@
+package sqlite3
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/yaoapp/xun/dbal"
+)
+
+func TestUpdateCompileUpdateFrom(t *testing.T) {
+	grammarSQL := New().(SQLite3)
+	query := newUpdateJoinQueryForTest()
+	sql, bindings := grammarSQL.CompileUpdate(query, map[string]interface{}{
+		"users.vote": dbal.Raw("`scores`.`vote`"),
+	})
+	assert.Equal(t, "update `users` set `vote`=`scores`.`vote` from `scores` where `users`.`id` = `scores`.`user_id` and (`scores`.`vote` > ?)", sql)
+	assert.Equal(t, []interface{}{10}, bindings)
+}
+
+func TestUpdateCompileUpdateCorrelated(t *testing.T) {
+	grammarSQL := New().(SQLite3)
+	query := newUpdateJoinQueryForTest()
+	sql, bindings := grammarSQL.compileUpdateCorrelated(query, map[string]interface{}{
+		"vote":   dbal.Raw("`scores`.`vote`"),
+		"status": "DONE",
+	})
+	assert.Equal(t, "update `users` set `status`=?, `vote`=(select `scores`.`vote` from `scores` where `users`.`id` = `scores`.`user_id` and (`scores`.`vote` > ?) limit 1) where `rowid` in (select `users`.`rowid` from `users` inner join `scores` on `users`.`id` = `scores`.`user_id` where `scores`.`vote` > ?)", sql)
+	assert.Equal(t, []interface{}{"DONE", 10, 10}, bindings)
+}
+
+func TestUpdateCompileUpdateLeftJoin(t *testing.T) {
+	grammarSQL := New().(SQLite3)
+	query := newUpdateJoinQueryForTest()
+	query.Joins[0].Type = "left"
+	sql, bindings := grammarSQL.CompileUpdate(query, map[string]interface{}{"status": "DONE"})
+	assert.Equal(t, "update `users` set `status`=? where `rowid` in (select `users`.`rowid` from `users` left join `scores` on `users`.`id` = `scores`.`user_id` where `scores`.`vote` > ?)", sql)
+	assert.Equal(t, []interface{}{"DONE", 10}, bindings)
+}
+
+func TestUpdateCompileUpdateVersion(t *testing.T) {
+	db := dbal.NewOffline(false).Open("sqlite3")
+	version := &dbal.Version{Version: semver.MustParse("3.31.1"), Driver: "sqlite3"}
+	grammar, err := New().NewWith(db, &dbal.Config{Driver: "sqlite3", Version: version}, &dbal.Option{})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.False(t, grammar.(SQLite3).UpdateFrom, "the update from statement should not be supported by SQLite 3.31.1")
+
+	sql, _ := grammar.CompileUpdate(newUpdateJoinQueryForTest(), map[string]interface{}{"status": "DONE"})
+	assert.Equal(t, "update `users` set `status`=? where `rowid` in (select `users`.`rowid` from `users` inner join `scores` on `users`.`id` = `scores`.`user_id` where `scores`.`vote` > ?)", sql)
+
+	// the latest syntax is assumed when the version can't be detected
+	grammar, err = New().NewWith(db, &dbal.Config{Driver: "sqlite3"}, &dbal.Option{})
+	if assert.Nil(t, err) {
+		assert.True(t, grammar.(SQLite3).UpdateFrom, "the update from statement should be supported")
+	}
+}
+
+func newUpdateJoinQueryForTest() *dbal.Query {
+	query := dbal.NewQuery()
+	query.From = dbal.From{Type: "basic", Name: dbal.NewName("users")}
+	on := dbal.NewQuery()
+	on.IsJoinClause = true
+	on.Wheres = []dbal.Where{{Type: "column", First: "users.id", Operator: "=", Second: "scores.user_id", Boolean: "and"}}
+	query.Joins = []dbal.Join{{Type: "inner", Name: dbal.NewName("scores"), Query: on}}
+	query.Wheres = []dbal.Where{{Type: "basic", Column: "scores.vote", Operator: ">", Value: 10, Boolean: "and", Offset: 1}}
+	query.AddBinding("where", 10)
+	return query
+}
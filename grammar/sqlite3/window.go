@@ -0,0 +1,12 @@
+package sqlite3
+
+import (
+	"github.com/blang/semver/v4"
+	"github.com/yaoapp/xun/dbal"
+)
+
+// SupportsWindowFunctions Determine if the given version of the database server supports the window functions.
+// The window functions are available since SQLite 3.25.0.
+func (grammarSQL SQLite3) SupportsWindowFunctions(version *dbal.Version) bool {
+	return version.GTE(semver.MustParse("3.25.0"))
+}
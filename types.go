@@ -28,6 +28,17 @@ type P struct {
 	Options      map[string]interface{} `json:"options,omtempty"`
 }
 
+// CP an Cursor Paginator struct, CP is the first letters of "Cursor Paginator"
+// the cursors are opaque strings, an empty string means there is no next (or previous) page.
+type CP struct {
+	Items          []interface{}          `json:"items"`
+	PageSize       int                    `json:"page_size"`
+	Cursor         string                 `json:"cursor"`
+	NextCursor     string                 `json:"next_cursor"`
+	PreviousCursor string                 `json:"previous_cursor"`
+	Options        map[string]interface{} `json:"options,omitempty"`
+}
+
 // UploadFile deprecated -> gou.UploadFile upload file
 type UploadFile struct {
 	Name     string
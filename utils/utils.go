@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"reflect"
@@ -206,6 +207,12 @@ func CopySlice(values []interface{}) []interface{} {
 }
 
 func StmtExec(stmt *sql.Stmt, bindings []interface{}) (sql.Result, error) {
+	return StmtExecContext(context.Background(), stmt, bindings)
+}
+
+// StmtExecContext execute the prepared statement with the bindings using the given context,
+// the statement will be executed for each row if the bindings is a list of rows.
+func StmtExecContext(ctx context.Context, stmt *sql.Stmt, bindings []interface{}) (sql.Result, error) {
 
 	var err error
 	var res sql.Result
@@ -214,16 +221,16 @@ func StmtExec(stmt *sql.Stmt, bindings []interface{}) (sql.Result, error) {
 		if _, ok := bindings[0].([]interface{}); ok {
 			// if reflect.TypeOf(bindings[0]) == reflect.TypeOf(dummy1) {
 			for _, row := range bindings {
-				res, err = stmt.Exec(row.([]interface{})...)
+				res, err = stmt.ExecContext(ctx, row.([]interface{})...)
 				if err != nil {
 					return res, err
 				}
 			}
 		} else {
-			res, err = stmt.Exec(bindings...)
+			res, err = stmt.ExecContext(ctx, bindings...)
 		}
 	} else {
-		res, err = stmt.Exec(bindings...)
+		res, err = stmt.ExecContext(ctx, bindings...)
 	}
 	return res, err
 }
@@ -39,6 +39,9 @@ func MakeTime(value ...interface{}) T {
 	if len(value) == 0 {
 		return T{Time: time.Now()}
 	}
+	if t, ok := value[0].(T); ok {
+		return t
+	}
 	return T{
 		Time: value[0],
 	}
@@ -132,6 +135,14 @@ func (t *T) MarshalJSON() ([]byte, error) {
 	return json.Marshal(t.Time)
 }
 
+// String the string of the time value
+func (t T) String() string {
+	if t.Time == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", t.Time)
+}
+
 // UnmarshalJSON for json marshalJSON
 func (t *T) UnmarshalJSON(data []byte) error {
 	*t = MakeTime(data)
@@ -207,6 +218,25 @@ func MakeP(total int, pageSize int, currentPage int, items ...interface{}) P {
 
 }
 
+// MakeCP create a new CP struct
+func MakeCP(pageSize int, cursor string, nextCursor string, previousCursor string, items ...interface{}) CP {
+	if pageSize < 1 {
+		pageSize = 15
+	}
+
+	if items == nil {
+		items = []interface{}{}
+	}
+
+	return CP{
+		Items:          items,
+		PageSize:       pageSize,
+		Cursor:         cursor,
+		NextCursor:     nextCursor,
+		PreviousCursor: previousCursor,
+	}
+}
+
 // Value get the value of the given key ( alias Get)
 func (row R) Value(key interface{}) interface{} {
 	return row.Get(key)
@@ -475,6 +505,14 @@ func (n *N) MarshalJSON() ([]byte, error) {
 	return json.Marshal(n.Number)
 }
 
+// String the string of the numeric value
+func (n N) String() string {
+	if n.Number == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", n.Number)
+}
+
 // UnmarshalJSON for json marshalJSON
 func (n *N) UnmarshalJSON(data []byte) error {
 	var v float64